@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+// newBuildsCommand creates the builds command
+func newBuildsCommand(opts *options) *cobra.Command {
+	builds := &cobra.Command{
+		Use:   "builds",
+		Short: "Manage uploaded builds",
+	}
+
+	var buildId, appId, buildNumber string
+	var interval, timeout time.Duration
+	wait := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait until a build has been processed",
+		Long: "Wait until a build has been processed, selected by --id or by --app and --build-number.\n" +
+			"Exits with an error when processing fails or the build is invalid.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if buildId == "" && (appId == "" || buildNumber == "") {
+				return fmt.Errorf("either --id or --app and --build-number are required")
+			}
+
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+			buildsAPI := appstore.NewBuildsAPI(client)
+
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			progress := func(build appstore.Build) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "build %s: %s\n", build.Version, build.ProcessingState)
+			}
+			var build appstore.Build
+			if buildId != "" {
+				build, err = buildsAPI.WaitForProcessing(ctx, buildId, interval, progress)
+			} else {
+				build, err = buildsAPI.WaitForUpload(ctx, appId, buildNumber, interval, progress)
+			}
+			if err != nil {
+				return err
+			}
+
+			object, err := withID(build.ID, build)
+			if err != nil {
+				return err
+			}
+			t := table{headers: []string{"ID", "BUILD", "STATE", "UPLOADED", "EXPIRES"}}
+			t.add(build.ID, build.Version, string(build.ProcessingState), build.UploadedDate, build.ExpirationDate)
+			if err := opts.print(cmd.OutOrStdout(), object, t); err != nil {
+				return err
+			}
+			if build.ProcessingState != appstore.BuildProcessingStateValid {
+				return fmt.Errorf("build %s finished processing as %s", build.Version, build.ProcessingState)
+			}
+			return nil
+		},
+	}
+	wait.Flags().StringVar(&buildId, "id", "", "ID of the build")
+	wait.Flags().StringVar(&appId, "app", "", "ID of the app the build was uploaded for")
+	wait.Flags().StringVar(&buildNumber, "build-number", "", "build number (CFBundleVersion) of the build")
+	wait.Flags().DurationVar(&interval, "interval", 30*time.Second, "polling interval")
+	wait.Flags().DurationVar(&timeout, "timeout", 0, "give up after this long, 0 waits indefinitely")
+
+	builds.AddCommand(wait)
+	return builds
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+// newCertsCommand creates the certs command
+func newCertsCommand(opts *options) *cobra.Command {
+	certs := &cobra.Command{
+		Use:   "certs",
+		Short: "Manage signing certificates",
+	}
+
+	var certificateType, csrPath, outPath string
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a certificate from a certificate signing request",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			csr, err := os.ReadFile(csrPath)
+			if err != nil {
+				return fmt.Errorf("failed to read csr: %w", err)
+			}
+
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+			response, err := appstore.NewCertificatesAPI(client).CreateFromCSR(appstore.CertificateType(certificateType), string(csr))
+			if err != nil {
+				return err
+			}
+			certificate := responseData(response)
+
+			if outPath != "" {
+				content, err := base64.StdEncoding.DecodeString(attribute(certificate, "certificateContent"))
+				if err != nil {
+					return fmt.Errorf("failed to decode certificate: %w", err)
+				}
+				if err := os.WriteFile(outPath, content, 0644); err != nil {
+					return fmt.Errorf("failed to write certificate: %w", err)
+				}
+			}
+
+			t := table{headers: []string{"ID", "NAME", "TYPE", "SERIAL", "EXPIRES"}}
+			t.add(resourceID(certificate), attribute(certificate, "name"), attribute(certificate, "certificateType"),
+				attribute(certificate, "serialNumber"), attribute(certificate, "expirationDate"))
+			return opts.print(cmd.OutOrStdout(), certificate, t)
+		},
+	}
+	create.Flags().StringVar(&certificateType, "type", "IOS_DISTRIBUTION", "certificate type, e.g. DEVELOPMENT or DISTRIBUTION")
+	create.Flags().StringVar(&csrPath, "csr", "", "path of the certificate signing request")
+	create.Flags().StringVar(&outPath, "out", "", "write the DER encoded certificate (.cer) to this path")
+	create.MarkFlagRequired("csr")
+
+	certs.AddCommand(create)
+	return certs
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+// newDevicesCommand creates the devices command
+func newDevicesCommand(opts *options) *cobra.Command {
+	devices := &cobra.Command{
+		Use:   "devices",
+		Short: "Manage registered devices",
+	}
+
+	var platform, status string
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List registered devices",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			params := map[string]string{}
+			if platform != "" {
+				params["filter[platform]"] = strings.ToUpper(platform)
+			}
+			if status != "" {
+				params["filter[status]"] = strings.ToUpper(status)
+			}
+			result, err := appstore.NewDeviceAPI(client).List(params)
+			if err != nil {
+				return err
+			}
+
+			objects := make([]map[string]interface{}, 0, len(result))
+			t := table{headers: []string{"ID", "NAME", "PLATFORM", "CLASS", "STATUS", "UDID"}}
+			for _, device := range result {
+				object, err := withID(device.ID, device)
+				if err != nil {
+					return err
+				}
+				objects = append(objects, object)
+				t.add(device.ID, device.Name, device.Platform, device.DeviceClass, device.Status, device.UDID)
+			}
+			return opts.print(cmd.OutOrStdout(), objects, t)
+		},
+	}
+	list.Flags().StringVar(&platform, "platform", "", "only list devices of a platform: IOS or MAC_OS")
+	list.Flags().StringVar(&status, "status", "", "only list devices with a status: ENABLED or DISABLED")
+
+	devices.AddCommand(list)
+	return devices
+}
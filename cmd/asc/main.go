@@ -0,0 +1,12 @@
+// Command asc is a command line client for the App Store Connect API.
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+// Output formats
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	// outputFastlane prints JSON in the snake_case shapes of fastlane's Spaceship models
+	outputFastlane = "fastlane"
+)
+
+// table holds the rows printed in table output
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+// add appends a row to the table
+func (t *table) add(values ...string) {
+	t.rows = append(t.rows, values)
+}
+
+// validateOutput checks the --output flag
+func (o *options) validateOutput() error {
+	switch o.output {
+	case outputTable, outputJSON, outputFastlane:
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q, expected table, json, or fastlane", o.output)
+}
+
+// print writes value as indented JSON, converted to fastlane shapes for the
+// fastlane output, or t as an aligned table
+func (o *options) print(w io.Writer, value interface{}, t table) error {
+	if o.output == outputFastlane {
+		converted, err := appstore.FastlaneJSON(value)
+		if err != nil {
+			return err
+		}
+		value = converted
+	}
+	if o.output != outputTable {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.headers, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// attribute returns a string attribute of a resource object in a response
+func attribute(resource map[string]interface{}, name string) string {
+	attributes, _ := resource["attributes"].(map[string]interface{})
+	if value, ok := attributes[name]; ok && value != nil {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+// resourceID returns the ID of a resource object
+func resourceID(resource map[string]interface{}) string {
+	id, _ := resource["id"].(string)
+	return id
+}
+
+// withID returns value as a JSON object including its ID, which the typed
+// models of package appstore leave out of their attributes
+func withID(id string, value interface{}) (map[string]interface{}, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(content, &object); err != nil {
+		return nil, err
+	}
+	object["id"] = id
+	return object, nil
+}
+
+// responseData returns the resource object of a single resource response
+func responseData(response map[string]interface{}) map[string]interface{} {
+	data, _ := response["data"].(map[string]interface{})
+	return data
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+// newProfilesCommand creates the profiles command
+func newProfilesCommand(opts *options) *cobra.Command {
+	profiles := &cobra.Command{
+		Use:   "profiles",
+		Short: "Manage provisioning profiles",
+	}
+
+	var allDevices bool
+	regenerate := &cobra.Command{
+		Use:   "regenerate PROFILE_ID...",
+		Short: "Replace profiles with new ones of the same name, bundle ID, and type",
+		Long: "Replace profiles with new ones of the same name, bundle ID, type, and certificates.\n" +
+			"Development and ad hoc profiles keep their devices, or get every enabled device with --all-devices.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+			profilesAPI := appstore.NewProfilesAPI(client)
+
+			var created []map[string]interface{}
+			t := table{headers: []string{"REPLACED", "ID", "NAME", "TYPE", "STATE", "EXPIRES"}}
+			for _, id := range args {
+				response, err := profilesAPI.Regenerate(id, allDevices)
+				if err != nil {
+					return err
+				}
+				profile := responseData(response)
+				created = append(created, profile)
+				t.add(id, resourceID(profile), attribute(profile, "name"), attribute(profile, "profileType"),
+					attribute(profile, "profileState"), attribute(profile, "expirationDate"))
+			}
+			return opts.print(cmd.OutOrStdout(), created, t)
+		},
+	}
+	regenerate.Flags().BoolVar(&allDevices, "all-devices", false, "include every enabled device of the profile's platform")
+
+	profiles.AddCommand(regenerate)
+	return profiles
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+// newProvisioningCommand creates the provisioning command
+func newProvisioningCommand(opts *options) *cobra.Command {
+	provisioning := &cobra.Command{
+		Use:   "provisioning",
+		Short: "Sync, repair, and nuke certificates and profiles, like fastlane match and sigh",
+	}
+
+	var specPath, outDir string
+	sync := &cobra.Command{
+		Use:   "sync",
+		Short: "Converge bundle IDs, certificates, and profiles to a spec file",
+		Long: "Register missing bundle IDs and capabilities, create a certificate when none is valid, and\n" +
+			"create or regenerate one profile per bundle ID holding every valid certificate and enabled device.\n" +
+			"New private keys, certificates, and all managed profiles are written to --out.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := appstore.LoadProvisioningSpec(specPath)
+			if err != nil {
+				return err
+			}
+			provisioningSync, err := opts.provisioningSync(outDir)
+			if err != nil {
+				return err
+			}
+			actions, err := provisioningSync.Sync(spec)
+			if printErr := opts.printActions(cmd, actions); printErr != nil {
+				return printErr
+			}
+			return err
+		},
+	}
+	sync.Flags().StringVar(&specPath, "spec", "", "path of the provisioning spec (.yaml, .yml, or .json)")
+	sync.Flags().StringVar(&outDir, "out", "", "directory for new private keys, certificates, and profiles")
+	sync.MarkFlagRequired("spec")
+
+	repair := &cobra.Command{
+		Use:   "repair",
+		Short: "Regenerate every invalid profile with all enabled devices",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			provisioningSync, err := opts.provisioningSync("")
+			if err != nil {
+				return err
+			}
+			actions, err := provisioningSync.Repair()
+			if printErr := opts.printActions(cmd, actions); printErr != nil {
+				return printErr
+			}
+			return err
+		},
+	}
+
+	var provisioningType string
+	var yes bool
+	nuke := &cobra.Command{
+		Use:   "nuke",
+		Short: "Delete all profiles and revoke all certificates of a type",
+		Long: "Delete all profiles and revoke all certificates of a type: development, adhoc, appstore,\n" +
+			"or developer_id. Ad hoc and App Store profiles share distribution certificates.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !yes {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Revoke all %s certificates and delete their profiles? Type %q to confirm: ", provisioningType, provisioningType)
+				answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if strings.TrimSpace(answer) != provisioningType {
+					return fmt.Errorf("aborted")
+				}
+			}
+
+			provisioningSync, err := opts.provisioningSync("")
+			if err != nil {
+				return err
+			}
+			actions, err := provisioningSync.Nuke(appstore.ProvisioningType(provisioningType))
+			if printErr := opts.printActions(cmd, actions); printErr != nil {
+				return printErr
+			}
+			return err
+		},
+	}
+	nuke.Flags().StringVar(&provisioningType, "type", "", "provisioning type: development, adhoc, appstore, or developer_id")
+	nuke.Flags().BoolVarP(&yes, "yes", "y", false, "do not ask for confirmation")
+	nuke.MarkFlagRequired("type")
+
+	provisioning.AddCommand(sync, repair, nuke)
+	return provisioning
+}
+
+// provisioningSync creates a provisioning sync writing to outDir
+func (o *options) provisioningSync(outDir string) (*appstore.ProvisioningSync, error) {
+	client, err := o.client()
+	if err != nil {
+		return nil, err
+	}
+	provisioningSync := appstore.NewProvisioningSync(client)
+	provisioningSync.OutputDir = outDir
+	return provisioningSync, nil
+}
+
+// printActions prints the changes made by a provisioning command, which may
+// be partial when the command failed
+func (o *options) printActions(cmd *cobra.Command, actions []appstore.ProvisioningAction) error {
+	if actions == nil {
+		actions = []appstore.ProvisioningAction{}
+	}
+	t := table{headers: []string{"ACTION", "RESOURCE", "NAME", "ID"}}
+	for _, action := range actions {
+		t.add(action.Action, action.Resource, action.Name, action.ID)
+	}
+	return o.print(cmd.OutOrStdout(), actions, t)
+}
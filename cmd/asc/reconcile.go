@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+// newReconcileCommand creates the reconcile command
+func newReconcileCommand(opts *options) *cobra.Command {
+	reconcile := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Converge bundle IDs, certificates, devices, and profiles to a spec file",
+	}
+
+	var specPath string
+	plan := &cobra.Command{
+		Use:   "plan",
+		Short: "Show the changes needed to match a spec without applying them",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := appstore.LoadReconcileSpec(specPath)
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+			reconcilePlan, err := appstore.NewReconciler(client).Plan(spec)
+			if err != nil {
+				return err
+			}
+			return opts.printChanges(cmd, reconcilePlan.Changes)
+		},
+	}
+	plan.Flags().StringVar(&specPath, "spec", "", "path of the reconcile spec (.yaml, .yml, or .json)")
+	plan.MarkFlagRequired("spec")
+
+	apply := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply the changes needed to match a spec",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := appstore.LoadReconcileSpec(specPath)
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+			reconciler := appstore.NewReconciler(client)
+			reconcilePlan, err := reconciler.Plan(spec)
+			if err != nil {
+				return err
+			}
+			result := reconciler.Apply(reconcilePlan)
+			applied := append(result.Applied, result.Warnings...)
+			if printErr := opts.printChanges(cmd, applied); printErr != nil {
+				return printErr
+			}
+			return result.Err()
+		},
+	}
+	apply.Flags().StringVar(&specPath, "spec", "", "path of the reconcile spec (.yaml, .yml, or .json)")
+	apply.MarkFlagRequired("spec")
+
+	state := &cobra.Command{
+		Use:   "state",
+		Short: "Print the observed and desired state of a spec as stable JSON",
+		Long: "Print the observed and desired state of the resources managed by a spec as JSON with resource\n" +
+			"IDs and content hashes, for Terraform providers, GitOps controllers, and other tools.\n" +
+			"The output is always JSON.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := appstore.LoadReconcileSpec(specPath)
+			if err != nil {
+				return err
+			}
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+			reconcilePlan, err := appstore.NewReconciler(client).Plan(spec)
+			if err != nil {
+				return err
+			}
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(reconcilePlan.State())
+		},
+	}
+	state.Flags().StringVar(&specPath, "spec", "", "path of the reconcile spec (.yaml, .yml, or .json)")
+	state.MarkFlagRequired("spec")
+
+	reconcile.AddCommand(plan, apply, state)
+	return reconcile
+}
+
+// printChanges prints reconcile changes
+func (o *options) printChanges(cmd *cobra.Command, changes []appstore.ReconcileChange) error {
+	if changes == nil {
+		changes = []appstore.ReconcileChange{}
+	}
+	t := table{headers: []string{"ACTION", "RESOURCE", "NAME", "ID", "DETAIL"}}
+	for _, change := range changes {
+		t.add(string(change.Action), change.Resource, change.Name, change.ID, change.Detail)
+	}
+	return o.print(cmd.OutOrStdout(), changes, t)
+}
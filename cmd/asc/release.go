@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+// newReleaseCommand creates the release command
+func newReleaseCommand(opts *options) *cobra.Command {
+	var config appstore.ReleaseConfig
+	var platform, statePath string
+	var usesEncryption bool
+	var timeout time.Duration
+
+	release := &cobra.Command{
+		Use:   "release",
+		Short: "Ship a build: wait for processing, create the version, and submit it for review",
+		Long: "Wait for a build to be processed, answer export compliance, create or find the App Store\n" +
+			"version, set release notes, attach the build, optionally enable phased release, and\n" +
+			"optionally submit for review. With --state the progress is saved after every step and\n" +
+			"running the same command again resumes after the last completed step.\n\n" +
+			"Progress is printed to stderr, as JSON lines with --output json.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.Platform = appstore.AppStorePlatform(platform)
+			if cmd.Flags().Changed("uses-encryption") {
+				config.UsesNonExemptEncryption = &usesEncryption
+			}
+
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+			r, err := appstore.NewRelease(client, config)
+			if err != nil {
+				return err
+			}
+			r.StatePath = statePath
+
+			encoder := json.NewEncoder(cmd.ErrOrStderr())
+			r.Progress = func(event appstore.ReleaseEvent) {
+				if opts.output != outputTable {
+					encoder.Encode(event)
+					return
+				}
+				line := fmt.Sprintf("%s %-22s %-9s", event.Time.Format(time.RFC3339), event.Step, event.Status)
+				if event.Message != "" {
+					line += " " + event.Message
+				}
+				fmt.Fprintln(cmd.ErrOrStderr(), line)
+			}
+
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			if err := r.Run(ctx); err != nil {
+				return err
+			}
+
+			t := table{headers: []string{"BUILD", "VERSION", "REVIEW SUBMISSION"}}
+			t.add(r.State.BuildID, r.State.AppStoreVersionID, r.State.ReviewSubmissionID)
+			return opts.print(cmd.OutOrStdout(), r.State, t)
+		},
+	}
+
+	flags := release.Flags()
+	flags.StringVar(&config.AppID, "app", "", "ID of the app")
+	flags.StringVar(&config.VersionString, "version", "", "version string of the App Store version, such as 2.1.0")
+	flags.StringVar(&config.BuildNumber, "build-number", "", "build number (CFBundleVersion) of the build")
+	flags.StringVar(&platform, "platform", string(appstore.AppStorePlatformIOS), "platform: IOS, MAC_OS, TV_OS, or VISION_OS")
+	flags.BoolVar(&usesEncryption, "uses-encryption", false, "export compliance answer, set when the build has none")
+	flags.StringToStringVar(&config.ReleaseNotes, "notes", nil, "release notes by locale, such as en-US=\"Bug fixes\"")
+	flags.BoolVar(&config.PhasedRelease, "phased", false, "enable phased release")
+	flags.BoolVar(&config.Submit, "submit", false, "submit the version for review")
+	flags.StringVar(&statePath, "state", "", "file the progress is saved to and resumed from")
+	flags.DurationVar(&config.PollInterval, "interval", 30*time.Second, "build processing polling interval")
+	flags.DurationVar(&timeout, "timeout", 0, "give up after this long, 0 waits indefinitely")
+	release.MarkFlagRequired("app")
+	release.MarkFlagRequired("version")
+	release.MarkFlagRequired("build-number")
+	return release
+}
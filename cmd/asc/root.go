@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// userAgent is the User-Agent header of requests sent by asc
+const userAgent = "asc (appstore-connect-api)"
+
+// options holds the global flags shared by every command
+type options struct {
+	issuerID   string
+	keyID      string
+	privateKey string
+	keyFile    string
+	output     string
+	proxy      string
+	caCert     string
+	dryRun     bool
+	verbose    bool
+}
+
+// newRootCommand creates the asc command with all subcommands
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+
+	root := &cobra.Command{
+		Use:          "asc",
+		Short:        "Command line client for the App Store Connect API",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.validateOutput()
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.issuerID, "issuer-id", "", "API key issuer ID (env ASC_ISSUER_ID)")
+	flags.StringVar(&opts.keyID, "key-id", "", "API key ID (env ASC_KEY_ID)")
+	flags.StringVar(&opts.privateKey, "private-key", "", "path to, content of, or secret reference such as aws-sm://name of the .p8 private key (env ASC_PRIVATE_KEY_PATH or ASC_PRIVATE_KEY)")
+	flags.StringVar(&opts.keyFile, "key-file", "", "fastlane API key JSON file (env ASC_KEY_FILE or APP_STORE_CONNECT_API_KEY_PATH)")
+	flags.StringVar(&opts.proxy, "proxy", "", "URL of the HTTP(S) proxy to send requests through (env HTTPS_PROXY)")
+	flags.StringVar(&opts.caCert, "ca-cert", "", "PEM file of additional root certificates, such as those of a TLS-intercepting proxy")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "print write requests instead of sending them")
+	flags.BoolVarP(&opts.verbose, "verbose", "v", false, "log requests, retries, and token renewals to stderr")
+	flags.StringVarP(&opts.output, "output", "o", outputTable, "output format: table, json, or fastlane")
+
+	root.AddCommand(
+		newDevicesCommand(opts),
+		newProfilesCommand(opts),
+		newCertsCommand(opts),
+		newBuildsCommand(opts),
+		newProvisioningCommand(opts),
+		newReconcileCommand(opts),
+		newReleaseCommand(opts),
+	)
+	return root
+}
+
+// client creates an App Store Connect client from flags, falling back to the environment
+func (o *options) client() (*appstore.Client, error) {
+	config, err := o.keyConfig()
+	if err != nil {
+		return nil, err
+	}
+	// Identify the tool in Apple's request logs
+	config.UserAgent = userAgent
+	config.DryRun = o.dryRun
+	if o.verbose {
+		config.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	transport, err := o.transport()
+	if err != nil {
+		return nil, err
+	}
+	config.Transport = transport
+
+	client, err := appstore.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key configuration: %w", err)
+	}
+	return client, nil
+}
+
+// keyConfig returns the key of the flags, completed by the key file and the
+// environment, see appstore.ConfigFromEnv
+func (o *options) keyConfig() (appstore.Config, error) {
+	var config appstore.Config
+	if o.keyFile != "" {
+		fileConfig, err := appstore.LoadFastlaneKey(o.keyFile)
+		if err != nil {
+			return appstore.Config{}, err
+		}
+		config = fileConfig
+	} else if envConfig, err := appstore.ConfigFromEnv(); err == nil {
+		config = envConfig
+	}
+
+	config.Issuer = firstNonEmpty(o.issuerID, config.Issuer)
+	config.KeyID = firstNonEmpty(o.keyID, config.KeyID)
+	config.Secret = firstNonEmpty(o.privateKey, config.Secret)
+	return config, nil
+}
+
+// transport returns the proxy and TLS settings of the flags, or nil without any
+func (o *options) transport() (*httpclient.TransportConfig, error) {
+	if o.proxy == "" && o.caCert == "" {
+		return nil, nil
+	}
+	transport := &httpclient.TransportConfig{}
+	if o.proxy != "" {
+		proxy, err := url.Parse(o.proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = proxy
+	}
+	if o.caCert != "" {
+		roots, err := httpclient.LoadCertPool(o.caCert)
+		if err != nil {
+			return nil, err
+		}
+		transport.RootCAs = roots
+	}
+	return transport, nil
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
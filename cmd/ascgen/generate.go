@@ -0,0 +1,409 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// header marks the generated files so tools and reviewers skip them
+const header = "// Code generated by ascgen from the App Store Connect OpenAPI specification. DO NOT EDIT.\n\n"
+
+var (
+	versionPattern   = regexp.MustCompile(`^/(v[0-9]+)(/.*)$`)
+	pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+)
+
+// methods are the HTTP methods generated for each path, in output order
+var methods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// namedSchema is a schema waiting to be declared as a Go type
+type namedSchema struct {
+	name   string
+	origin string
+	schema *Schema
+}
+
+// generator turns a specification into Go source
+type generator struct {
+	spec        *Spec
+	packageName string
+	typeNames   map[string]string // schema name to Go type name
+	used        map[string]bool   // declared Go type names
+	queue       []namedSchema
+	models      bytes.Buffer
+	endpoints   bytes.Buffer
+	constants   bytes.Buffer
+	usesJSON    bool
+	usesURL     bool
+}
+
+// newGenerator creates a generator, reserving the Go names of all top-level schemas
+func newGenerator(spec *Spec, packageName string) *generator {
+	g := &generator{
+		spec:        spec,
+		packageName: packageName,
+		typeNames:   make(map[string]string),
+		used:        make(map[string]bool),
+	}
+	for _, name := range sortedKeys(spec.Components.Schemas) {
+		g.typeNames[name] = g.uniqueName(goName(name))
+	}
+	return g
+}
+
+// generate returns the formatted models and endpoints files keyed by file name
+func (g *generator) generate() (map[string][]byte, error) {
+	for _, name := range sortedKeys(g.spec.Components.Schemas) {
+		g.queue = append(g.queue, namedSchema{g.typeNames[name], name, g.spec.Components.Schemas[name]})
+	}
+	g.drain()
+
+	for _, path := range sortedKeys(g.spec.Paths) {
+		if err := g.path(path, g.spec.Paths[path]); err != nil {
+			return nil, err
+		}
+	}
+	// request bodies and responses may declare inline schemas
+	g.drain()
+
+	models := header + "package " + g.packageName + "\n\n"
+	if g.usesJSON {
+		models += "import \"encoding/json\"\n\n"
+	}
+	endpoints := header + "package " + g.packageName + "\n\n"
+	if g.usesURL {
+		endpoints += "import \"net/url\"\n\n"
+	}
+	if version := g.spec.Info.Version; version != "" {
+		endpoints += "// SpecVersion is the version of the specification the package was generated from\n" +
+			"const SpecVersion = " + strconv.Quote(version) + "\n\n"
+	}
+	if g.constants.Len() > 0 {
+		endpoints += "// Endpoints of the operations, as \"METHOD /version/path\" with the path\n" +
+			"// parameters in braces, to name requests in logs and metrics and to build\n" +
+			"// token scopes, see jwtutil.JWTConfig.Scope\nconst (\n" + g.constants.String() + ")\n\n"
+	}
+
+	files := map[string][]byte{}
+	for name, source := range map[string]string{
+		"models_gen.go":    models + g.models.String(),
+		"endpoints_gen.go": endpoints + g.endpoints.String(),
+	} {
+		formatted, err := format.Source([]byte(source))
+		if err != nil {
+			return nil, fmt.Errorf("failed to format %s: %w", name, err)
+		}
+		files[name] = formatted
+	}
+	return files, nil
+}
+
+// drain declares the queued schemas, including those queued while declaring
+func (g *generator) drain() {
+	for len(g.queue) > 0 {
+		next := g.queue[0]
+		g.queue = g.queue[1:]
+		g.declare(next)
+	}
+}
+
+// uniqueName returns name, or name with a numeric suffix if it is taken
+func (g *generator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	g.used[unique] = true
+	return unique
+}
+
+// resolve follows references and single-element allOf wrappers to the underlying schema
+func (g *generator) resolve(s *Schema) *Schema {
+	for s != nil {
+		switch {
+		case s.Ref != "":
+			s = g.spec.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		case len(s.AllOf) == 1:
+			s = s.AllOf[0]
+		default:
+			return s
+		}
+	}
+	return &Schema{}
+}
+
+// isStruct reports whether a schema is declared as a Go struct
+func isStruct(s *Schema) bool {
+	return (s.Type == "object" || s.Type == "") && len(s.Properties) > 0
+}
+
+// pointable reports whether optional values of a schema are referenced through
+// pointers, so that zero values can be told apart from missing ones
+func (g *generator) pointable(s *Schema) bool {
+	s = g.resolve(s)
+	switch {
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.AllOf) > 0:
+		return false
+	case isStruct(s):
+		return true
+	}
+	switch s.Type {
+	case "string", "integer", "number", "boolean":
+		return true
+	}
+	return false
+}
+
+// typeFor returns the Go type of a schema, queueing inline structs and enums
+// for declaration under name
+func (g *generator) typeFor(name, origin string, s *Schema) string {
+	if s == nil {
+		return "interface{}"
+	}
+	if s.Ref != "" {
+		refName := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		if typeName, ok := g.typeNames[refName]; ok {
+			return typeName
+		}
+		fmt.Fprintf(os.Stderr, "ascgen: unknown reference %s\n", s.Ref)
+		return "interface{}"
+	}
+	if len(s.AllOf) == 1 {
+		return g.typeFor(name, origin, s.AllOf[0])
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.AllOf) > 0 {
+		g.usesJSON = true
+		return "json.RawMessage"
+	}
+
+	switch s.Type {
+	case "string":
+		if len(s.Enum) > 0 {
+			return g.inline(name, origin, s)
+		}
+		return "string"
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.typeFor(name+"Item", origin+"[]", s.Items)
+	}
+
+	if isStruct(s) {
+		return g.inline(name, origin, s)
+	}
+	if additional := s.additional(); additional != nil {
+		return "map[string]" + g.typeFor(name+"Value", origin+"{}", additional)
+	}
+	if s.Type == "object" {
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// inline queues an inline schema for declaration and returns its type name
+func (g *generator) inline(name, origin string, s *Schema) string {
+	name = g.uniqueName(name)
+	g.queue = append(g.queue, namedSchema{name, origin, s})
+	return name
+}
+
+// declare writes the type declaration of a schema
+func (g *generator) declare(named namedSchema) {
+	s := named.schema
+	for len(s.AllOf) == 1 && s.AllOf[0].Ref == "" {
+		s = s.AllOf[0]
+	}
+
+	w := &g.models
+	fmt.Fprintf(w, "// %s is generated from %s\n", named.name, named.origin)
+	if s.Deprecated {
+		fmt.Fprintf(w, "//\n// Deprecated: Apple has deprecated %s\n", named.origin)
+	}
+
+	switch {
+	case s.Type == "string" && len(s.Enum) > 0:
+		fmt.Fprintf(w, "type %s string\n\n", named.name)
+		fmt.Fprintf(w, "// %s values\nconst (\n", named.name)
+		seen := make(map[string]bool)
+		for _, value := range s.Enum {
+			text := fmt.Sprint(value)
+			constName := named.name + goName(text)
+			for i := 2; seen[constName]; i++ {
+				constName = named.name + goName(text) + strconv.Itoa(i)
+			}
+			seen[constName] = true
+			fmt.Fprintf(w, "\t%s %s = %q\n", constName, named.name, text)
+		}
+		fmt.Fprint(w, ")\n\n")
+
+	case isStruct(s):
+		required := make(map[string]bool)
+		for _, name := range s.Required {
+			required[name] = true
+		}
+		fields := make(map[string]bool)
+
+		var b strings.Builder
+		for _, property := range sortedKeys(s.Properties) {
+			schema := s.Properties[property]
+			fieldName := goName(property)
+			for i := 2; fields[fieldName]; i++ {
+				fieldName = goName(property) + strconv.Itoa(i)
+			}
+			fields[fieldName] = true
+
+			fieldType := g.typeFor(named.name+goName(property), named.origin+"."+property, schema)
+			tag := property
+			if !required[property] {
+				if g.pointable(schema) {
+					fieldType = "*" + fieldType
+				}
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", fieldName, fieldType, tag)
+		}
+		fmt.Fprintf(w, "type %s struct {\n%s}\n\n", named.name, b.String())
+
+	default:
+		fmt.Fprintf(w, "type %s %s\n\n", named.name, g.typeFor(named.name+"Value", named.origin, s))
+	}
+}
+
+// path writes the endpoint stubs of a path
+func (g *generator) path(path string, item *PathItem) error {
+	match := versionPattern.FindStringSubmatch(path)
+	if match == nil {
+		fmt.Fprintf(os.Stderr, "ascgen: skipping unversioned path %s\n", path)
+		return nil
+	}
+	version, route := match[1], match[2]
+
+	operations := item.operations()
+	for _, method := range methods {
+		operation := operations[method]
+		if operation == nil {
+			continue
+		}
+		if err := g.operation(method, path, version, route, item, operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// operation writes the endpoint stub of an operation
+func (g *generator) operation(method, path, version, route string, item *PathItem, operation *Operation) error {
+	name := goName(operation.OperationID)
+	if operation.OperationID == "" {
+		name = goName(strings.ToLower(method) + " " + path)
+	}
+
+	// path parameters become positional arguments, in path order
+	var args []string
+	var expression []string
+	last := 0
+	for _, match := range pathParamPattern.FindAllStringSubmatchIndex(route, -1) {
+		if match[0] > last {
+			expression = append(expression, strconv.Quote(route[last:match[0]]))
+		}
+		arg := argName(route[match[2]:match[3]])
+		args = append(args, arg+" string")
+		expression = append(expression, "url.PathEscape("+arg+")")
+		g.usesURL = true
+		last = match[1]
+	}
+	if last < len(route) {
+		expression = append(expression, strconv.Quote(route[last:]))
+	}
+	routeExpression := strings.Join(expression, " + ")
+
+	params := "nil"
+	if g.hasQuery(item.Parameters) || g.hasQuery(operation.Parameters) {
+		args = append(args, "params map[string]string")
+		params = "params"
+	}
+
+	body := "nil"
+	if operation.RequestBody != nil {
+		content := operation.RequestBody.Content["application/json"]
+		if content == nil {
+			return fmt.Errorf("%s %s: unsupported request content type", method, path)
+		}
+		args = append(args, "body "+g.typeFor(name+"Request", name+" request", content.Schema))
+		body = "body"
+	}
+
+	fmt.Fprintf(&g.constants, "\t%s = %q\n", g.uniqueName(name+"Endpoint"), method+" "+path)
+
+	w := &g.endpoints
+	fmt.Fprintf(w, "// %s performs %s %s\n", name, method, path)
+	if operation.Deprecated {
+		fmt.Fprint(w, "//\n// Deprecated: Apple has deprecated this endpoint\n")
+	}
+	signature := fmt.Sprintf("func (c *Client) %s(%s)", name, strings.Join(args, ", "))
+	call := fmt.Sprintf("c, %q, %q, %s, %s, %s", method, version, routeExpression, params, body)
+
+	resultType, accept := g.result(name, operation)
+	switch {
+	case resultType != "":
+		fmt.Fprintf(w, "%s (*%s, error) {\n", signature, resultType)
+		fmt.Fprintf(w, "\treturn do[%s](%s)\n}\n\n", resultType, call)
+	case accept != "" && method == "GET":
+		fmt.Fprintf(w, "%s ([]byte, error) {\n", signature)
+		fmt.Fprint(w, "\tif err := c.client.EnsureAuth(); err != nil {\n\t\treturn nil, err\n\t}\n")
+		fmt.Fprintf(w, "\treturn c.client.GetHTTPClient().WithAPIVersion(%q).GetRaw(%s, %s, %q)\n}\n\n", version, routeExpression, params, accept)
+	default:
+		fmt.Fprintf(w, "%s error {\n", signature)
+		fmt.Fprintf(w, "\t_, err := do[struct{}](%s)\n\treturn err\n}\n\n", call)
+	}
+	return nil
+}
+
+// hasQuery reports whether any of the parameters is a query parameter
+func (g *generator) hasQuery(parameters []*Parameter) bool {
+	for _, parameter := range parameters {
+		if parameter.Ref != "" {
+			parameter = g.spec.Components.Parameters[strings.TrimPrefix(parameter.Ref, "#/components/parameters/")]
+		}
+		if parameter != nil && parameter.In == "query" {
+			return true
+		}
+	}
+	return false
+}
+
+// result returns the Go type of a JSON success response, or the content type
+// of a non-JSON one
+func (g *generator) result(name string, operation *Operation) (string, string) {
+	for _, status := range []string{"200", "201", "202"} {
+		response := operation.Responses[status]
+		if response == nil || len(response.Content) == 0 {
+			continue
+		}
+		if content := response.Content["application/json"]; content != nil {
+			return g.typeFor(name+"Response", name+" response", content.Schema), ""
+		}
+		return "", sortedKeys(response.Content)[0]
+	}
+	return "", ""
+}
+
+// sortedKeys returns the keys of a map in sorted order, for stable output
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,59 @@
+// Command ascgen generates typed models and endpoint stubs for the App Store
+// Connect API from Apple's OpenAPI specification. It is run through
+// go generate in pkg/ascapi, which generates from the copy of the
+// specification committed there. With -save it stores the specification it
+// read, so a new one downloaded from Apple replaces the committed copy.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// defaultSpecURL is where Apple publishes the zipped OpenAPI specification
+const defaultSpecURL = "https://developer.apple.com/sample-code/app-store-connect/app-store-connect-openapi-specification.zip"
+
+func main() {
+	specSource := flag.String("spec", defaultSpecURL, "path or URL of the OpenAPI specification, optionally zipped")
+	outDir := flag.String("out", ".", "directory to write the generated files to")
+	packageName := flag.String("package", "ascapi", "package name of the generated files")
+	savePath := flag.String("save", "", "optional path to store the unzipped specification at")
+	flag.Parse()
+
+	if err := run(*specSource, *outDir, *packageName, *savePath); err != nil {
+		fmt.Fprintln(os.Stderr, "ascgen:", err)
+		os.Exit(1)
+	}
+}
+
+// run loads the specification, stores it at savePath when one is given, and
+// writes the generated models and endpoints
+func run(specSource, outDir, packageName, savePath string) error {
+	content, err := readSpec(specSource)
+	if err != nil {
+		return err
+	}
+	spec, err := parseSpec(content)
+	if err != nil {
+		return err
+	}
+	if savePath != "" {
+		if err := os.WriteFile(savePath, content, 0644); err != nil {
+			return fmt.Errorf("failed to save specification: %w", err)
+		}
+	}
+
+	files, err := newGenerator(spec, packageName).generate()
+	if err != nil {
+		return err
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(outDir, name), content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
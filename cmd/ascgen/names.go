@@ -0,0 +1,98 @@
+package main
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// initialisms are words written in upper case in Go identifiers
+var initialisms = map[string]string{
+	"api":  "API",
+	"id":   "ID",
+	"ids":  "IDs",
+	"ios":  "IOS",
+	"ip":   "IP",
+	"json": "JSON",
+	"os":   "OS",
+	"sdk":  "SDK",
+	"udid": "UDID",
+	"uri":  "URI",
+	"url":  "URL",
+	"urls": "URLs",
+}
+
+// goName converts a schema, property, enum value, or operation name to an
+// exported Go identifier
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range splitWords(name) {
+		if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		if strings.ToUpper(word) == word {
+			word = strings.ToLower(word)
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	result := b.String()
+	if result == "" {
+		return "Value"
+	}
+	if unicode.IsDigit(rune(result[0])) {
+		result = "N" + result
+	}
+	return result
+}
+
+// argName converts a parameter name to an unexported Go identifier
+func argName(name string) string {
+	exported := goName(name)
+	var result string
+	if initialism, ok := initialisms[strings.ToLower(exported)]; ok && initialism == exported {
+		result = strings.ToLower(exported)
+	} else {
+		result = strings.ToLower(exported[:1]) + exported[1:]
+	}
+	if token.IsKeyword(result) || result == "params" || result == "body" || result == "out" {
+		result += "Value"
+	}
+	return result
+}
+
+// splitWords splits a name at non-alphanumeric characters and case changes,
+// keeping runs of upper case letters such as "URL" together
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			previous := current[len(current)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// a trailing "s" pluralizes the run, as in "IDs"
+			if nextIsLower && runes[i+1] == 's' && (i+2 == len(runes) || !unicode.IsLower(runes[i+2])) {
+				nextIsLower = false
+			}
+			if !unicode.IsUpper(previous) || nextIsLower {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// Spec is the subset of an OpenAPI 3 document used by the generator
+type Spec struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components struct {
+		Schemas    map[string]*Schema    `json:"schemas"`
+		Parameters map[string]*Parameter `json:"parameters"`
+	} `json:"components"`
+}
+
+// PathItem holds the operations of a path
+type PathItem struct {
+	Get        *Operation   `json:"get"`
+	Post       *Operation   `json:"post"`
+	Put        *Operation   `json:"put"`
+	Patch      *Operation   `json:"patch"`
+	Delete     *Operation   `json:"delete"`
+	Parameters []*Parameter `json:"parameters"`
+}
+
+// operations returns the operations of the path keyed by HTTP method
+func (p *PathItem) operations() map[string]*Operation {
+	return map[string]*Operation{
+		"GET":    p.Get,
+		"POST":   p.Post,
+		"PUT":    p.Put,
+		"PATCH":  p.Patch,
+		"DELETE": p.Delete,
+	}
+}
+
+// Operation is a single endpoint
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Deprecated  bool                 `json:"deprecated"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path, query, or header parameter
+type Parameter struct {
+	Ref      string `json:"$ref"`
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+// RequestBody is the body of an operation
+type RequestBody struct {
+	Content map[string]*MediaType `json:"content"`
+}
+
+// Response is a response of an operation
+type Response struct {
+	Content map[string]*MediaType `json:"content"`
+}
+
+// MediaType is the schema of a request or response content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON schema
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Deprecated           bool               `json:"deprecated"`
+	Enum                 []interface{}      `json:"enum"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *Schema            `json:"items"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	OneOf                []*Schema          `json:"oneOf"`
+	AnyOf                []*Schema          `json:"anyOf"`
+	AllOf                []*Schema          `json:"allOf"`
+}
+
+// additional returns the schema of additional properties, if they have one
+func (s *Schema) additional() *Schema {
+	if len(s.AdditionalProperties) == 0 || s.AdditionalProperties[0] != '{' {
+		return nil
+	}
+	var schema Schema
+	if err := json.Unmarshal(s.AdditionalProperties, &schema); err != nil {
+		return nil
+	}
+	return &schema
+}
+
+// readSpec reads the specification document from a file or URL, unzipping
+// it if needed
+func readSpec(source string) ([]byte, error) {
+	content, err := readSource(source)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(content, []byte("PK")) {
+		return unzipSpec(content)
+	}
+	return content, nil
+}
+
+// parseSpec parses a specification document
+func parseSpec(content []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(content, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse specification: %w", err)
+	}
+	return &spec, nil
+}
+
+// readSource returns the content of a local file or an http(s) URL
+func readSource(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		content, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read specification: %w", err)
+		}
+		return content, nil
+	}
+
+	resp, err := http.Get(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download specification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("specification download failed with status %d", resp.StatusCode)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read specification: %w", err)
+	}
+	return content, nil
+}
+
+// unzipSpec returns the first JSON document of a zip archive
+func unzipSpec(content []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open specification archive: %w", err)
+	}
+
+	for _, file := range archive.File {
+		name := path.Base(file.Name)
+		if strings.HasPrefix(name, "._") || !strings.HasSuffix(strings.ToLower(name), ".json") {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", file.Name, err)
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	}
+	return nil, fmt.Errorf("specification archive contains no JSON document")
+}
@@ -4,5 +4,12 @@ go 1.21
 
 require (
 	github.com/golang-jwt/jwt/v5 v5.2.0
+	github.com/spf13/cobra v1.10.2
 	golang.org/x/crypto v0.19.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.9 // indirect
 )
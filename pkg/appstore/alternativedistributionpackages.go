@@ -0,0 +1,213 @@
+package appstore
+
+import (
+	"context"
+	"fmt"
+
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// AlternativeDistributionPackageVersion is a signed package of an app version
+// for distribution through an alternative marketplace
+type AlternativeDistributionPackageVersion struct {
+	ID                string `json:"-"`
+	Version           string `json:"version"`
+	State             string `json:"state"`
+	URL               string `json:"url"`
+	URLExpirationDate string `json:"urlExpirationDate"`
+	FileChecksum      string `json:"fileChecksum"`
+}
+
+// AlternativeDistributionPackageFile is a variant or delta of a package
+// version, with the key blob marketplaces need to install it
+type AlternativeDistributionPackageFile struct {
+	ID                             string `json:"-"`
+	URL                            string `json:"url"`
+	URLExpirationDate              string `json:"urlExpirationDate"`
+	AlternativeDistributionKeyBlob string `json:"alternativeDistributionKeyBlob"`
+	FileChecksum                   string `json:"fileChecksum"`
+}
+
+// AlternativeDistributionPackagesAPI handles alternative distribution
+// package, version, variant, and delta operations
+type AlternativeDistributionPackagesAPI struct {
+	client *Client
+}
+
+// NewAlternativeDistributionPackagesAPI creates a new AlternativeDistributionPackages API client
+func NewAlternativeDistributionPackagesAPI(client *Client) *AlternativeDistributionPackagesAPI {
+	return &AlternativeDistributionPackagesAPI{client: client}
+}
+
+// ForAppStoreVersion retrieves the package of an App Store version
+func (a *AlternativeDistributionPackagesAPI) ForAppStoreVersion(appStoreVersionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().Get("/appStoreVersions/"+appStoreVersionId+"/alternativeDistributionPackage", params)
+}
+
+// Get retrieves a package by ID
+func (a *AlternativeDistributionPackagesAPI) Get(packageId string, params map[string]string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().Get("/alternativeDistributionPackages/"+packageId, params)
+}
+
+// Create requests a package for an App Store version
+func (a *AlternativeDistributionPackagesAPI) Create(appStoreVersionId string) (map[string]interface{}, error) {
+	if appStoreVersionId == "" {
+		return nil, fmt.Errorf("app store version id is required")
+	}
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "alternativeDistributionPackages",
+			"relationships": map[string]interface{}{
+				"appStoreVersion": map[string]interface{}{
+					"data": map[string]string{
+						"type": "appStoreVersions",
+						"id":   appStoreVersionId,
+					},
+				},
+			},
+		},
+	}
+
+	return a.client.GetHTTPClient().PostJSON("/alternativeDistributionPackages", data)
+}
+
+// Versions retrieves every version of a package, following pagination
+func (a *AlternativeDistributionPackagesAPI) Versions(packageId string) ([]AlternativeDistributionPackageVersion, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	var versions []AlternativeDistributionPackageVersion
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := a.client.GetHTTPClient().Get("/alternativeDistributionPackages/"+packageId+"/versions", query)
+		if err != nil {
+			return versions, err
+		}
+		for _, resource := range resourceList(response) {
+			var version AlternativeDistributionPackageVersion
+			if err := decodeAttributes(resource, &version); err != nil {
+				return versions, err
+			}
+			version.ID = resourceID(resource)
+			versions = append(versions, version)
+		}
+		query = nextPageParams(response)
+	}
+	return versions, nil
+}
+
+// Version retrieves a package version by ID, including a freshly signed download URL
+func (a *AlternativeDistributionPackagesAPI) Version(versionId string) (AlternativeDistributionPackageVersion, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return AlternativeDistributionPackageVersion{}, err
+	}
+	response, err := a.client.GetHTTPClient().Get("/alternativeDistributionPackageVersions/"+versionId, nil)
+	if err != nil {
+		return AlternativeDistributionPackageVersion{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return AlternativeDistributionPackageVersion{}, err
+	}
+	var version AlternativeDistributionPackageVersion
+	if err := decodeAttributes(resource, &version); err != nil {
+		return AlternativeDistributionPackageVersion{}, err
+	}
+	version.ID = resourceID(resource)
+	return version, nil
+}
+
+// Variants retrieves the device variants of a package version
+func (a *AlternativeDistributionPackagesAPI) Variants(versionId string) ([]AlternativeDistributionPackageFile, error) {
+	return a.files("/alternativeDistributionPackageVersions/" + versionId + "/variants")
+}
+
+// Deltas retrieves the deltas of a package version, which update earlier versions in place
+func (a *AlternativeDistributionPackagesAPI) Deltas(versionId string) ([]AlternativeDistributionPackageFile, error) {
+	return a.files("/alternativeDistributionPackageVersions/" + versionId + "/deltas")
+}
+
+// Variant retrieves a package variant by ID
+func (a *AlternativeDistributionPackagesAPI) Variant(variantId string) (AlternativeDistributionPackageFile, error) {
+	return a.file("/alternativeDistributionPackageVariants/" + variantId)
+}
+
+// Delta retrieves a package delta by ID
+func (a *AlternativeDistributionPackagesAPI) Delta(deltaId string) (AlternativeDistributionPackageFile, error) {
+	return a.file("/alternativeDistributionPackageDeltas/" + deltaId)
+}
+
+// Download downloads the content of a package version, variant, or delta from its signed URL
+func (a *AlternativeDistributionPackagesAPI) Download(url string) ([]byte, error) {
+	if url == "" {
+		return nil, fmt.Errorf("download url is required")
+	}
+	return a.client.GetHTTPClient().DownloadURL(url)
+}
+
+// DownloadTo downloads a package version, variant, or delta from its signed
+// URL to path with parallel range requests, see httpclient.DownloadFile
+func (a *AlternativeDistributionPackagesAPI) DownloadTo(ctx context.Context, url, path string, options httpclient.DownloadOptions) error {
+	if url == "" {
+		return fmt.Errorf("download url is required")
+	}
+	return a.client.GetHTTPClient().DownloadFile(ctx, url, path, options)
+}
+
+// files retrieves every variant or delta listed at path, following pagination
+func (a *AlternativeDistributionPackagesAPI) files(path string) ([]AlternativeDistributionPackageFile, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	var files []AlternativeDistributionPackageFile
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := a.client.GetHTTPClient().Get(path, query)
+		if err != nil {
+			return files, err
+		}
+		for _, resource := range resourceList(response) {
+			var file AlternativeDistributionPackageFile
+			if err := decodeAttributes(resource, &file); err != nil {
+				return files, err
+			}
+			file.ID = resourceID(resource)
+			files = append(files, file)
+		}
+		query = nextPageParams(response)
+	}
+	return files, nil
+}
+
+// file retrieves the variant or delta at path
+func (a *AlternativeDistributionPackagesAPI) file(path string) (AlternativeDistributionPackageFile, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return AlternativeDistributionPackageFile{}, err
+	}
+	response, err := a.client.GetHTTPClient().Get(path, nil)
+	if err != nil {
+		return AlternativeDistributionPackageFile{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return AlternativeDistributionPackageFile{}, err
+	}
+	var file AlternativeDistributionPackageFile
+	if err := decodeAttributes(resource, &file); err != nil {
+		return AlternativeDistributionPackageFile{}, err
+	}
+	file.ID = resourceID(resource)
+	return file, nil
+}
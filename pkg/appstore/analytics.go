@@ -0,0 +1,186 @@
+package appstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// AnalyticsAccessType represents how long an analytics report request produces data
+type AnalyticsAccessType string
+
+// Analytics report access types
+const (
+	AnalyticsAccessOneTimeSnapshot AnalyticsAccessType = "ONE_TIME_SNAPSHOT"
+	AnalyticsAccessOngoing         AnalyticsAccessType = "ONGOING"
+)
+
+// AnalyticsGranularity represents the period covered by an analytics report instance
+type AnalyticsGranularity string
+
+// Analytics report instance granularities
+const (
+	AnalyticsGranularityDaily   AnalyticsGranularity = "DAILY"
+	AnalyticsGranularityWeekly  AnalyticsGranularity = "WEEKLY"
+	AnalyticsGranularityMonthly AnalyticsGranularity = "MONTHLY"
+)
+
+// AnalyticsReportsAPI handles analytics report operations
+type AnalyticsReportsAPI struct {
+	client *Client
+}
+
+// NewAnalyticsReportsAPI creates a new AnalyticsReports API client
+func NewAnalyticsReportsAPI(client *Client) *AnalyticsReportsAPI {
+	return &AnalyticsReportsAPI{client: client}
+}
+
+// CreateRequest requests analytics reports for an app
+func (a *AnalyticsReportsAPI) CreateRequest(appId string, accessType AnalyticsAccessType) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "analyticsReportRequests",
+			"attributes": map[string]string{
+				"accessType": string(accessType),
+			},
+			"relationships": map[string]interface{}{
+				"app": map[string]interface{}{
+					"data": map[string]string{
+						"type": "apps",
+						"id":   appId,
+					},
+				},
+			},
+		},
+	}
+
+	return a.client.GetHTTPClient().PostJSON("/analyticsReportRequests", data)
+}
+
+// FindOrCreateRequest creates a report request for an app and returns its ID.
+// Apps can only have one ongoing request, so an existing one is reused.
+func (a *AnalyticsReportsAPI) FindOrCreateRequest(appId string, accessType AnalyticsAccessType) (string, error) {
+	if accessType == AnalyticsAccessOngoing {
+		existing, err := a.Requests(appId, map[string]string{
+			"filter[accessType]": string(AnalyticsAccessOngoing),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to find analytics report request: %w", err)
+		}
+		if requests := resourceList(existing); len(requests) > 0 {
+			return resourceID(requests[0]), nil
+		}
+	}
+
+	created, err := a.CreateRequest(appId, accessType)
+	if err != nil {
+		return "", fmt.Errorf("failed to create analytics report request: %w", err)
+	}
+	data, ok := created["data"].(map[string]interface{})
+	if !ok || resourceID(data) == "" {
+		return "", fmt.Errorf("invalid analytics report request data")
+	}
+
+	return resourceID(data), nil
+}
+
+// Requests lists the analytics report requests of an app
+func (a *AnalyticsReportsAPI) Requests(appId string, params map[string]string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().Get("/apps/"+appId+"/analyticsReportRequests", params)
+}
+
+// DeleteRequest deletes an analytics report request by ID
+func (a *AnalyticsReportsAPI) DeleteRequest(requestId string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().Delete("/analyticsReportRequests/"+requestId, nil)
+}
+
+// Reports lists the reports produced by an analytics report request
+func (a *AnalyticsReportsAPI) Reports(requestId string, params map[string]string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().Get("/analyticsReportRequests/"+requestId+"/reports", params)
+}
+
+// Instances lists the instances of an analytics report
+func (a *AnalyticsReportsAPI) Instances(reportId string, params map[string]string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().Get("/analyticsReports/"+reportId+"/instances", params)
+}
+
+// Segments lists the downloadable segments of an analytics report instance
+func (a *AnalyticsReportsAPI) Segments(instanceId string, params map[string]string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().Get("/analyticsReportInstances/"+instanceId+"/segments", params)
+}
+
+// DownloadSegment downloads a report segment from its presigned URL, verifies
+// its MD5 checksum when one is given and returns the decompressed content
+func (a *AnalyticsReportsAPI) DownloadSegment(segmentURL, checksum string) ([]byte, error) {
+	body, err := a.client.GetHTTPClient().DownloadURL(segmentURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if checksum != "" {
+		sum := md5.Sum(body)
+		if hex.EncodeToString(sum[:]) != checksum {
+			return nil, fmt.Errorf("segment checksum mismatch")
+		}
+	}
+
+	return gunzip(body)
+}
+
+// DownloadSegmentTo downloads a compressed report segment to path, verifying
+// its MD5 checksum when one is given
+func (a *AnalyticsReportsAPI) DownloadSegmentTo(ctx context.Context, segmentURL, checksum, path string, options httpclient.DownloadOptions) error {
+	options.Checksum = checksum
+	return a.client.GetHTTPClient().DownloadFile(ctx, segmentURL, path, options)
+}
+
+// InstanceReport downloads every segment of a report instance and parses them
+// into a single report
+func (a *AnalyticsReportsAPI) InstanceReport(instanceId string) (*Report, error) {
+	segments, err := a.Segments(instanceId, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instance segments: %w", err)
+	}
+
+	report := &Report{}
+	for _, segment := range resourceList(segments) {
+		content, err := a.DownloadSegment(stringAttribute(segment, "url"), stringAttribute(segment, "checksum"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to download segment: %w", err)
+		}
+
+		part, err := ParseReport(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		if report.Columns == nil {
+			report.Columns = part.Columns
+		}
+		report.Rows = append(report.Rows, part.Rows...)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,98 @@
+package appstore
+
+import (
+	"fmt"
+	"time"
+)
+
+// AnalyticsCategoryBenchmarks is the category of peer group benchmark
+// reports, a value of the category attribute of analytics reports
+const AnalyticsCategoryBenchmarks = "BENCHMARKS"
+
+// AnalyticsBenchmark holds the rows of a peer group benchmark report instance.
+// Benchmark reports compare metrics such as conversion and crash rates of an
+// app against the percentiles of its peer group.
+type AnalyticsBenchmark struct {
+	ReportName     string               `json:"reportName"`
+	Category       string               `json:"category"`
+	Granularity    AnalyticsGranularity `json:"granularity"`
+	ProcessingDate string               `json:"processingDate"`
+	Columns        []string             `json:"columns"`
+	Rows           []ReportRow          `json:"rows"`
+}
+
+// Benchmarks returns the peer group benchmark report instances of an app
+// processed between from and to, inclusive. Reports are selected by their
+// category, so renamed or localized reports are still found.
+func (a *AnalyticsReportsAPI) Benchmarks(appId string, granularity AnalyticsGranularity, from, to time.Time) ([]AnalyticsBenchmark, error) {
+	requestId, err := a.FindOrCreateRequest(appId, AnalyticsAccessOngoing)
+	if err != nil {
+		return nil, err
+	}
+
+	var benchmarks []AnalyticsBenchmark
+	query := map[string]string{"limit": "200", "filter[category]": AnalyticsCategoryBenchmarks}
+	for query != nil {
+		reports, err := a.Reports(requestId, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list analytics reports: %w", err)
+		}
+
+		for _, report := range resourceList(reports) {
+			if stringAttribute(report, "category") != AnalyticsCategoryBenchmarks {
+				continue
+			}
+
+			reportBenchmarks, err := a.benchmarkInstances(report, granularity, from, to)
+			if err != nil {
+				return nil, err
+			}
+			benchmarks = append(benchmarks, reportBenchmarks...)
+		}
+
+		query = nextPageParams(reports)
+	}
+
+	return benchmarks, nil
+}
+
+// benchmarkInstances downloads the instances of a benchmark report within a date range
+func (a *AnalyticsReportsAPI) benchmarkInstances(report map[string]interface{}, granularity AnalyticsGranularity, from, to time.Time) ([]AnalyticsBenchmark, error) {
+	var benchmarks []AnalyticsBenchmark
+	query := map[string]string{"limit": "200"}
+	if granularity != "" {
+		query["filter[granularity]"] = string(granularity)
+	}
+
+	for query != nil {
+		instances, err := a.Instances(resourceID(report), query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list report instances: %w", err)
+		}
+
+		for _, instance := range resourceList(instances) {
+			processingDate := stringAttribute(instance, "processingDate")
+			processed, err := time.Parse("2006-01-02", processingDate)
+			if err != nil || processed.Before(dateOnly(from)) || processed.After(dateOnly(to)) {
+				continue
+			}
+
+			content, err := a.InstanceReport(resourceID(instance))
+			if err != nil {
+				return nil, err
+			}
+			benchmarks = append(benchmarks, AnalyticsBenchmark{
+				ReportName:     stringAttribute(report, "name"),
+				Category:       stringAttribute(report, "category"),
+				Granularity:    AnalyticsGranularity(stringAttribute(instance, "granularity")),
+				ProcessingDate: processingDate,
+				Columns:        content.Columns,
+				Rows:           content.Rows,
+			})
+		}
+
+		query = nextPageParams(instances)
+	}
+
+	return benchmarks, nil
+}
@@ -0,0 +1,150 @@
+package appstore
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AnalyticsMetric represents a daily app metric derived from analytics reports
+type AnalyticsMetric string
+
+// Analytics metrics available through Metrics
+const (
+	// MetricInstalls counts all downloads, including redownloads and updates
+	MetricInstalls AnalyticsMetric = "INSTALLS"
+	// MetricFirstTimeDownloads counts first-time downloads only
+	MetricFirstTimeDownloads AnalyticsMetric = "FIRST_TIME_DOWNLOADS"
+	MetricSessions           AnalyticsMetric = "SESSIONS"
+	MetricCrashes            AnalyticsMetric = "CRASHES"
+)
+
+// analyticsMetricSource describes the report and column a metric is read from
+type analyticsMetricSource struct {
+	reportName  string
+	valueColumn string
+	filter      func(row ReportRow) bool
+}
+
+var analyticsMetricSources = map[AnalyticsMetric]analyticsMetricSource{
+	MetricInstalls: {
+		reportName:  "App Downloads Standard",
+		valueColumn: "Counts",
+	},
+	MetricFirstTimeDownloads: {
+		reportName:  "App Downloads Standard",
+		valueColumn: "Counts",
+		filter: func(row ReportRow) bool {
+			return row["Download Type"] == "First-time download"
+		},
+	},
+	MetricSessions: {
+		reportName:  "App Sessions Standard",
+		valueColumn: "Sessions",
+	},
+	MetricCrashes: {
+		reportName:  "App Crashes",
+		valueColumn: "Crashes",
+	},
+}
+
+// metricProcessingLag is how long after a date its data may still be processed
+const metricProcessingLag = 7 * 24 * time.Hour
+
+// MetricPoint is the value of a metric on a single day
+type MetricPoint struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// Metrics returns the daily values of a metric for an app between from and to,
+// inclusive. It subscribes the app to ongoing analytics reports if needed, so
+// data may only be available a few days after the first call.
+func (a *AnalyticsReportsAPI) Metrics(appId string, metric AnalyticsMetric, from, to time.Time) ([]MetricPoint, error) {
+	source, ok := analyticsMetricSources[metric]
+	if !ok {
+		return nil, fmt.Errorf("unsupported metric: %s", metric)
+	}
+
+	requestId, err := a.FindOrCreateRequest(appId, AnalyticsAccessOngoing)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := a.Reports(requestId, map[string]string{"filter[name]": source.reportName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list analytics reports: %w", err)
+	}
+	reportList := resourceList(reports)
+	if len(reportList) == 0 {
+		return nil, fmt.Errorf("analytics report not available yet: %s", source.reportName)
+	}
+
+	totals := make(map[string]float64)
+	query := map[string]string{
+		"filter[granularity]": string(AnalyticsGranularityDaily),
+		"limit":               "200",
+	}
+	for query != nil {
+		instances, err := a.Instances(resourceID(reportList[0]), query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list report instances: %w", err)
+		}
+
+		for _, instance := range resourceList(instances) {
+			processed, err := time.Parse("2006-01-02", stringAttribute(instance, "processingDate"))
+			if err != nil || processed.Before(dateOnly(from)) || processed.After(dateOnly(to).Add(metricProcessingLag)) {
+				continue
+			}
+			if err := a.sumInstance(resourceID(instance), source, from, to, totals); err != nil {
+				return nil, err
+			}
+		}
+
+		query = nextPageParams(instances)
+	}
+
+	points := make([]MetricPoint, 0, len(totals))
+	for day, value := range totals {
+		date, _ := time.Parse("2006-01-02", day)
+		points = append(points, MetricPoint{Date: date, Value: value})
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Date.Before(points[j].Date)
+	})
+
+	return points, nil
+}
+
+// sumInstance adds the metric values of the rows of a report instance dated
+// between from and to to totals, keyed by date
+func (a *AnalyticsReportsAPI) sumInstance(instanceId string, source analyticsMetricSource, from, to time.Time, totals map[string]float64) error {
+	report, err := a.InstanceReport(instanceId)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range report.Rows {
+		date, err := time.Parse("2006-01-02", row["Date"])
+		if err != nil || date.Before(dateOnly(from)) || date.After(dateOnly(to)) {
+			continue
+		}
+		if source.filter != nil && !source.filter(row) {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(row[source.valueColumn]), 64)
+		if err != nil {
+			continue
+		}
+		totals[row["Date"]] += value
+	}
+
+	return nil
+}
+
+// dateOnly truncates a time to midnight UTC of its date
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
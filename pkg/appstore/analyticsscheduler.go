@@ -0,0 +1,265 @@
+package appstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultAnalyticsPollInterval    = time.Minute
+	defaultAnalyticsMaxPollInterval = time.Hour
+)
+
+// AnalyticsSegment is a downloaded analytics report segment
+type AnalyticsSegment struct {
+	ReportID       string
+	ReportName     string
+	Category       string
+	InstanceID     string
+	Granularity    AnalyticsGranularity
+	ProcessingDate string
+	SegmentID      string
+	Content        []byte
+}
+
+// AnalyticsSegmentHandler is invoked for every newly downloaded segment
+type AnalyticsSegmentHandler func(segment AnalyticsSegment) error
+
+// AnalyticsSchedulerConfig holds the analytics scheduler configuration
+type AnalyticsSchedulerConfig struct {
+	AppID      string
+	AccessType AnalyticsAccessType
+	// RequestID resumes an existing report request instead of creating one
+	RequestID string
+	// Category, ReportNames and Granularity optionally restrict the delivered segments
+	Category    string
+	ReportNames []string
+	Granularity AnalyticsGranularity
+	// PollInterval is the initial delay between polls, doubled while nothing
+	// new is available up to MaxPollInterval
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+}
+
+// AnalyticsScheduler requests analytics reports, polls for completed instances
+// and delivers each downloaded segment to a handler exactly once
+type AnalyticsScheduler struct {
+	api       *AnalyticsReportsAPI
+	config    AnalyticsSchedulerConfig
+	handler   AnalyticsSegmentHandler
+	delivered map[string]bool
+}
+
+// NewAnalyticsScheduler creates a new analytics report scheduler
+func NewAnalyticsScheduler(api *AnalyticsReportsAPI, config AnalyticsSchedulerConfig, handler AnalyticsSegmentHandler) (*AnalyticsScheduler, error) {
+	if config.AppID == "" && config.RequestID == "" {
+		return nil, fmt.Errorf("app id or request id is required")
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("handler is required")
+	}
+	if config.AccessType == "" {
+		config.AccessType = AnalyticsAccessOngoing
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultAnalyticsPollInterval
+	}
+	if config.MaxPollInterval < config.PollInterval {
+		config.MaxPollInterval = defaultAnalyticsMaxPollInterval
+		if config.MaxPollInterval < config.PollInterval {
+			config.MaxPollInterval = config.PollInterval
+		}
+	}
+
+	return &AnalyticsScheduler{
+		api:       api,
+		config:    config,
+		handler:   handler,
+		delivered: make(map[string]bool),
+	}, nil
+}
+
+// RequestID returns the analytics report request the scheduler is subscribed to
+func (s *AnalyticsScheduler) RequestID() string {
+	return s.config.RequestID
+}
+
+// Run subscribes to the report request and delivers segments until the
+// context is cancelled. One-time snapshots return once segments have been
+// delivered and a subsequent poll finds nothing new.
+func (s *AnalyticsScheduler) Run(ctx context.Context) error {
+	api := NewAnalyticsReportsAPI(s.api.client.WithContext(ctx))
+	if err := s.ensureRequest(api); err != nil {
+		return err
+	}
+
+	interval := s.config.PollInterval
+	deliveredAny := false
+	for {
+		delivered, err := s.poll(api)
+		if err != nil {
+			return err
+		}
+
+		if delivered > 0 {
+			deliveredAny = true
+			interval = s.config.PollInterval
+		} else {
+			if deliveredAny && s.config.AccessType == AnalyticsAccessOneTimeSnapshot {
+				return nil
+			}
+			interval *= 2
+			if interval > s.config.MaxPollInterval {
+				interval = s.config.MaxPollInterval
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Poll checks the report request once and delivers any new segments,
+// returning the number of segments delivered
+func (s *AnalyticsScheduler) Poll() (int, error) {
+	if err := s.ensureRequest(s.api); err != nil {
+		return 0, err
+	}
+	return s.poll(s.api)
+}
+
+// poll walks every page of reports of the report request
+func (s *AnalyticsScheduler) poll(api *AnalyticsReportsAPI) (int, error) {
+	delivered := 0
+	query := map[string]string{"limit": "200"}
+	if s.config.Category != "" {
+		query["filter[category]"] = s.config.Category
+	}
+	for query != nil {
+		reports, err := api.Reports(s.config.RequestID, query)
+		if err != nil {
+			return delivered, fmt.Errorf("failed to list analytics reports: %w", err)
+		}
+
+		for _, report := range resourceList(reports) {
+			if !s.wantsReport(stringAttribute(report, "name")) {
+				continue
+			}
+
+			n, err := s.pollReport(api, report)
+			delivered += n
+			if err != nil {
+				return delivered, err
+			}
+		}
+
+		query = nextPageParams(reports)
+	}
+
+	return delivered, nil
+}
+
+// pollReport walks every page of instances of a report
+func (s *AnalyticsScheduler) pollReport(api *AnalyticsReportsAPI, report map[string]interface{}) (int, error) {
+	delivered := 0
+	query := map[string]string{"limit": "200"}
+	if s.config.Granularity != "" {
+		query["filter[granularity]"] = string(s.config.Granularity)
+	}
+	for query != nil {
+		instances, err := api.Instances(resourceID(report), query)
+		if err != nil {
+			return delivered, fmt.Errorf("failed to list report instances: %w", err)
+		}
+
+		for _, instance := range resourceList(instances) {
+			n, err := s.pollInstance(api, report, instance)
+			delivered += n
+			if err != nil {
+				return delivered, err
+			}
+		}
+
+		query = nextPageParams(instances)
+	}
+
+	return delivered, nil
+}
+
+// pollInstance walks every page of segments of a report instance and
+// delivers the ones not delivered before
+func (s *AnalyticsScheduler) pollInstance(api *AnalyticsReportsAPI, report, instance map[string]interface{}) (int, error) {
+	delivered := 0
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		segments, err := api.Segments(resourceID(instance), query)
+		if err != nil {
+			return delivered, fmt.Errorf("failed to list instance segments: %w", err)
+		}
+
+		for _, segment := range resourceList(segments) {
+			segmentID := resourceID(segment)
+			if s.delivered[segmentID] {
+				continue
+			}
+
+			content, err := api.DownloadSegment(stringAttribute(segment, "url"), stringAttribute(segment, "checksum"))
+			if err != nil {
+				return delivered, fmt.Errorf("failed to download segment %s: %w", segmentID, err)
+			}
+
+			err = s.handler(AnalyticsSegment{
+				ReportID:       resourceID(report),
+				ReportName:     stringAttribute(report, "name"),
+				Category:       stringAttribute(report, "category"),
+				InstanceID:     resourceID(instance),
+				Granularity:    AnalyticsGranularity(stringAttribute(instance, "granularity")),
+				ProcessingDate: stringAttribute(instance, "processingDate"),
+				SegmentID:      segmentID,
+				Content:        content,
+			})
+			if err != nil {
+				return delivered, err
+			}
+
+			s.delivered[segmentID] = true
+			delivered++
+		}
+
+		query = nextPageParams(segments)
+	}
+
+	return delivered, nil
+}
+
+// ensureRequest resolves the report request when the scheduler has none yet
+func (s *AnalyticsScheduler) ensureRequest(api *AnalyticsReportsAPI) error {
+	if s.config.RequestID != "" {
+		return nil
+	}
+
+	requestId, err := api.FindOrCreateRequest(s.config.AppID, s.config.AccessType)
+	if err != nil {
+		return err
+	}
+	s.config.RequestID = requestId
+
+	return nil
+}
+
+// wantsReport reports whether segments of the named report should be delivered
+func (s *AnalyticsScheduler) wantsReport(name string) bool {
+	if len(s.config.ReportNames) == 0 {
+		return true
+	}
+	for _, wanted := range s.config.ReportNames {
+		if wanted == name {
+			return true
+		}
+	}
+	return false
+}
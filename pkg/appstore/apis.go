@@ -0,0 +1,226 @@
+package appstore
+
+// Devices returns the device API
+func (c *Client) Devices() *DeviceAPI {
+	return NewDeviceAPI(c)
+}
+
+// BundleIds returns the bundle ID API
+func (c *Client) BundleIds() *BundleIdAPI {
+	return NewBundleIdAPI(c)
+}
+
+// BundleIdCapabilities returns the bundle ID capability API
+func (c *Client) BundleIdCapabilities() *BundleIdCapabilityAPI {
+	return NewBundleIdCapabilityAPI(c)
+}
+
+// Profiles returns the profiles API
+func (c *Client) Profiles() *ProfilesAPI {
+	return NewProfilesAPI(c)
+}
+
+// Certificates returns the certificates API
+func (c *Client) Certificates() *CertificatesAPI {
+	return NewCertificatesAPI(c)
+}
+
+// Builds returns the builds API
+func (c *Client) Builds() *BuildsAPI {
+	return NewBuildsAPI(c)
+}
+
+// SandboxTesters returns the sandbox testers API
+func (c *Client) SandboxTesters() *SandboxTestersAPI {
+	return NewSandboxTestersAPI(c)
+}
+
+// Users returns the users API
+func (c *Client) Users() *UsersAPI {
+	return NewUsersAPI(c)
+}
+
+// UserInvitations returns the user invitations API
+func (c *Client) UserInvitations() *UserInvitationsAPI {
+	return NewUserInvitationsAPI(c)
+}
+
+// Reports returns the reports API
+func (c *Client) Reports() *ReportsAPI {
+	return NewReportsAPI(c)
+}
+
+// Diagnostics returns the diagnostics API
+func (c *Client) Diagnostics() *DiagnosticsAPI {
+	return NewDiagnosticsAPI(c)
+}
+
+// AnalyticsReports returns the analytics reports API
+func (c *Client) AnalyticsReports() *AnalyticsReportsAPI {
+	return NewAnalyticsReportsAPI(c)
+}
+
+// CustomerReviews returns the customer reviews API
+func (c *Client) CustomerReviews() *CustomerReviewsAPI {
+	return NewCustomerReviewsAPI(c)
+}
+
+// PerfPowerMetrics returns the power and performance metrics API
+func (c *Client) PerfPowerMetrics() *PerfPowerMetricsAPI {
+	return NewPerfPowerMetricsAPI(c)
+}
+
+// InAppPurchases returns the in-app purchases API
+func (c *Client) InAppPurchases() *InAppPurchasesAPI {
+	return NewInAppPurchasesAPI(c)
+}
+
+// InAppPurchaseLocalizations returns the in-app purchase localizations API
+func (c *Client) InAppPurchaseLocalizations() *InAppPurchaseLocalizationsAPI {
+	return NewInAppPurchaseLocalizationsAPI(c)
+}
+
+// SubscriptionGroups returns the subscription groups API
+func (c *Client) SubscriptionGroups() *SubscriptionGroupsAPI {
+	return NewSubscriptionGroupsAPI(c)
+}
+
+// Subscriptions returns the subscriptions API
+func (c *Client) Subscriptions() *SubscriptionsAPI {
+	return NewSubscriptionsAPI(c)
+}
+
+// SubscriptionLocalizations returns the subscription localizations API
+func (c *Client) SubscriptionLocalizations() *SubscriptionLocalizationsAPI {
+	return NewSubscriptionLocalizationsAPI(c)
+}
+
+// SubscriptionPrices returns the subscription prices API
+func (c *Client) SubscriptionPrices() *SubscriptionPricesAPI {
+	return NewSubscriptionPricesAPI(c)
+}
+
+// SubscriptionIntroductoryOffers returns the subscription introductory offers API
+func (c *Client) SubscriptionIntroductoryOffers() *SubscriptionIntroductoryOffersAPI {
+	return NewSubscriptionIntroductoryOffersAPI(c)
+}
+
+// SubscriptionPromotionalOffers returns the subscription promotional offers API
+func (c *Client) SubscriptionPromotionalOffers() *SubscriptionPromotionalOffersAPI {
+	return NewSubscriptionPromotionalOffersAPI(c)
+}
+
+// SubscriptionOfferCodes returns the subscription offer codes API
+func (c *Client) SubscriptionOfferCodes() *SubscriptionOfferCodesAPI {
+	return NewSubscriptionOfferCodesAPI(c)
+}
+
+// SubscriptionAvailabilities returns the subscription availabilities API
+func (c *Client) SubscriptionAvailabilities() *SubscriptionAvailabilitiesAPI {
+	return NewSubscriptionAvailabilitiesAPI(c)
+}
+
+// InAppPurchaseAvailabilities returns the in-app purchase availabilities API
+func (c *Client) InAppPurchaseAvailabilities() *InAppPurchaseAvailabilitiesAPI {
+	return NewInAppPurchaseAvailabilitiesAPI(c)
+}
+
+// PriceEqualization returns the price equalization API
+func (c *Client) PriceEqualization() *PriceEqualizationAPI {
+	return NewPriceEqualizationAPI(c)
+}
+
+// CiBuildRuns returns the Xcode Cloud build runs API
+func (c *Client) CiBuildRuns() *CiBuildRunsAPI {
+	return NewCiBuildRunsAPI(c)
+}
+
+// CiWorkflows returns the Xcode Cloud workflows API
+func (c *Client) CiWorkflows() *CiWorkflowsAPI {
+	return NewCiWorkflowsAPI(c)
+}
+
+// CiArtifacts returns the Xcode Cloud artifacts API
+func (c *Client) CiArtifacts() *CiArtifactsAPI {
+	return NewCiArtifactsAPI(c)
+}
+
+// CiTestResults returns the Xcode Cloud test results API
+func (c *Client) CiTestResults() *CiTestResultsAPI {
+	return NewCiTestResultsAPI(c)
+}
+
+// CiIssues returns the Xcode Cloud issues API
+func (c *Client) CiIssues() *CiIssuesAPI {
+	return NewCiIssuesAPI(c)
+}
+
+// ScmProviders returns the source control providers API
+func (c *Client) ScmProviders() *ScmProvidersAPI {
+	return NewScmProvidersAPI(c)
+}
+
+// ScmRepositories returns the source control repositories API
+func (c *Client) ScmRepositories() *ScmRepositoriesAPI {
+	return NewScmRepositoriesAPI(c)
+}
+
+// GameCenterLeaderboards returns the Game Center leaderboards API
+func (c *Client) GameCenterLeaderboards() *GameCenterLeaderboardsAPI {
+	return NewGameCenterLeaderboardsAPI(c)
+}
+
+// GameCenterLeaderboardSets returns the Game Center leaderboard sets API
+func (c *Client) GameCenterLeaderboardSets() *GameCenterLeaderboardSetsAPI {
+	return NewGameCenterLeaderboardSetsAPI(c)
+}
+
+// GameCenterMatchmakingRuleSets returns the Game Center matchmaking rule sets API
+func (c *Client) GameCenterMatchmakingRuleSets() *GameCenterMatchmakingRuleSetsAPI {
+	return NewGameCenterMatchmakingRuleSetsAPI(c)
+}
+
+// GameCenterMatchmakingQueues returns the Game Center matchmaking queues API
+func (c *Client) GameCenterMatchmakingQueues() *GameCenterMatchmakingQueuesAPI {
+	return NewGameCenterMatchmakingQueuesAPI(c)
+}
+
+// AlternativeDistributionPackages returns the alternative distribution packages API
+func (c *Client) AlternativeDistributionPackages() *AlternativeDistributionPackagesAPI {
+	return NewAlternativeDistributionPackagesAPI(c)
+}
+
+// MarketplaceSearchDetails returns the marketplace search details API
+func (c *Client) MarketplaceSearchDetails() *MarketplaceSearchDetailsAPI {
+	return NewMarketplaceSearchDetailsAPI(c)
+}
+
+// MarketplaceWebhooks returns the marketplace webhooks API
+func (c *Client) MarketplaceWebhooks() *MarketplaceWebhooksAPI {
+	return NewMarketplaceWebhooksAPI(c)
+}
+
+// AppStoreVersionLocalizations returns the App Store version localizations API
+func (c *Client) AppStoreVersionLocalizations() *AppStoreVersionLocalizationsAPI {
+	return NewAppStoreVersionLocalizationsAPI(c)
+}
+
+// BetaBuildLocalizations returns the TestFlight build localizations API
+func (c *Client) BetaBuildLocalizations() *BetaBuildLocalizationsAPI {
+	return NewBetaBuildLocalizationsAPI(c)
+}
+
+// AppStoreVersions returns the App Store versions API
+func (c *Client) AppStoreVersions() *AppStoreVersionsAPI {
+	return NewAppStoreVersionsAPI(c)
+}
+
+// ReviewSubmissions returns the review submissions API
+func (c *Client) ReviewSubmissions() *ReviewSubmissionsAPI {
+	return NewReviewSubmissionsAPI(c)
+}
+
+// Webhooks returns the webhooks API
+func (c *Client) Webhooks() *WebhooksAPI {
+	return NewWebhooksAPI(c)
+}
@@ -0,0 +1,102 @@
+package appstore
+
+import (
+	"fmt"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// AppStoreVersionLocalization represents the localized App Store metadata of an app version
+type AppStoreVersionLocalization struct {
+	ID              string `json:"-"`
+	Locale          string `json:"locale"`
+	Description     string `json:"description,omitempty"`
+	Keywords        string `json:"keywords,omitempty"`
+	MarketingURL    string `json:"marketingUrl,omitempty"`
+	PromotionalText string `json:"promotionalText,omitempty"`
+	SupportURL      string `json:"supportUrl,omitempty"`
+	WhatsNew        string `json:"whatsNew,omitempty"`
+}
+
+// AppStoreVersionLocalizationsAPI handles App Store version localization operations
+type AppStoreVersionLocalizationsAPI struct {
+	client *Client
+}
+
+// NewAppStoreVersionLocalizationsAPI creates a new AppStoreVersionLocalizations API client
+func NewAppStoreVersionLocalizationsAPI(client *Client) *AppStoreVersionLocalizationsAPI {
+	return &AppStoreVersionLocalizationsAPI{client: client}
+}
+
+// All retrieves the localizations of an App Store version
+func (a *AppStoreVersionLocalizationsAPI) All(appStoreVersionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().Get("/appStoreVersions/"+appStoreVersionId+"/appStoreVersionLocalizations", params)
+}
+
+// List retrieves every localization of an App Store version
+func (a *AppStoreVersionLocalizationsAPI) List(appStoreVersionId string) ([]AppStoreVersionLocalization, error) {
+	var localizations []AppStoreVersionLocalization
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := a.All(appStoreVersionId, query)
+		if err != nil {
+			return localizations, err
+		}
+		var page []AppStoreVersionLocalization
+		if err := jsonapi.UnmarshalList(response, &page); err != nil {
+			return localizations, err
+		}
+		localizations = append(localizations, page...)
+		query = nextPageParams(response)
+	}
+	return localizations, nil
+}
+
+// Create adds a localization to an App Store version
+func (a *AppStoreVersionLocalizationsAPI) Create(appStoreVersionId string, localization AppStoreVersionLocalization) (map[string]interface{}, error) {
+	if appStoreVersionId == "" {
+		return nil, fmt.Errorf("app store version id is required")
+	}
+	if localization.Locale == "" {
+		return nil, fmt.Errorf("locale is required")
+	}
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	return a.client.GetHTTPClient().PostJSON("/appStoreVersionLocalizations", jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "appStoreVersionLocalizations",
+		Attributes: localization,
+		Relationships: map[string]jsonapi.Relationship{
+			"appStoreVersion": jsonapi.ToOne("appStoreVersions", appStoreVersionId),
+		},
+	}))
+}
+
+// Update changes attributes of an App Store version localization, such as
+// description or whatsNew. Attributes that are not given are left unchanged.
+func (a *AppStoreVersionLocalizationsAPI) Update(localizationId string, attributes map[string]string) (map[string]interface{}, error) {
+	if len(attributes) == 0 {
+		return nil, fmt.Errorf("attributes are required")
+	}
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	return a.client.GetHTTPClient().PatchJSON("/appStoreVersionLocalizations/"+localizationId, jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "appStoreVersionLocalizations",
+		ID:         localizationId,
+		Attributes: attributes,
+	}))
+}
+
+// Delete deletes an App Store version localization by ID
+func (a *AppStoreVersionLocalizationsAPI) Delete(localizationId string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().Delete("/appStoreVersionLocalizations/"+localizationId, nil)
+}
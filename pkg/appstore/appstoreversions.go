@@ -0,0 +1,214 @@
+package appstore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// AppStorePlatform represents the platform of an App Store version
+type AppStorePlatform string
+
+// App Store platforms
+const (
+	AppStorePlatformIOS      AppStorePlatform = "IOS"
+	AppStorePlatformMacOS    AppStorePlatform = "MAC_OS"
+	AppStorePlatformTvOS     AppStorePlatform = "TV_OS"
+	AppStorePlatformVisionOS AppStorePlatform = "VISION_OS"
+)
+
+// AppStoreVersion represents a version of an app on the App Store
+type AppStoreVersion struct {
+	ID            string           `json:"-"`
+	Platform      AppStorePlatform `json:"platform"`
+	VersionString string           `json:"versionString"`
+	AppStoreState string           `json:"appStoreState"`
+	ReleaseType   string           `json:"releaseType"`
+	CreatedDate   string           `json:"createdDate"`
+}
+
+// AppStoreVersionUpdate holds the attributes of an App Store version to
+// change, empty values are left unchanged
+type AppStoreVersionUpdate struct {
+	VersionString string `json:"versionString,omitempty"`
+	Copyright     string `json:"copyright,omitempty"`
+	// ReleaseType is MANUAL, AFTER_APPROVAL, or SCHEDULED
+	ReleaseType string `json:"releaseType,omitempty"`
+	// EarliestReleaseDate is the release date of SCHEDULED releases, in RFC 3339 format
+	EarliestReleaseDate string `json:"earliestReleaseDate,omitempty"`
+}
+
+// AppStoreVersionsAPI handles App Store version operations
+type AppStoreVersionsAPI struct {
+	client *Client
+}
+
+// NewAppStoreVersionsAPI creates a new AppStoreVersions API client
+func NewAppStoreVersionsAPI(client *Client) *AppStoreVersionsAPI {
+	return &AppStoreVersionsAPI{client: client}
+}
+
+// All retrieves the App Store versions of an app
+func (a *AppStoreVersionsAPI) All(appId string, params map[string]string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().Get("/apps/"+appId+"/appStoreVersions", params)
+}
+
+// Get retrieves an App Store version by ID
+func (a *AppStoreVersionsAPI) Get(appStoreVersionId string) (AppStoreVersion, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return AppStoreVersion{}, err
+	}
+	response, err := a.client.GetHTTPClient().Get("/appStoreVersions/"+appStoreVersionId, nil)
+	if err != nil {
+		return AppStoreVersion{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return AppStoreVersion{}, err
+	}
+	var version AppStoreVersion
+	err = jsonapi.Unmarshal(resource, &version)
+	return version, err
+}
+
+// WaitForState polls an App Store version until its App Store state is one
+// of states, such as IN_REVIEW or PENDING_DEVELOPER_RELEASE, to follow a
+// version through App Review
+func (a *AppStoreVersionsAPI) WaitForState(ctx context.Context, appStoreVersionId string, options WaitOptions, states ...string) (AppStoreVersion, error) {
+	if len(states) == 0 {
+		return AppStoreVersion{}, fmt.Errorf("at least one state is required")
+	}
+	return WaitFor(ctx, options, func(ctx context.Context) (AppStoreVersion, bool, error) {
+		version, err := NewAppStoreVersionsAPI(a.client.WithContext(ctx)).Get(appStoreVersionId)
+		if err != nil {
+			return version, false, err
+		}
+		return version, slices.Contains(states, version.AppStoreState), nil
+	})
+}
+
+// Find retrieves the App Store version of an app with a version string on a
+// platform, returning false when it does not exist
+func (a *AppStoreVersionsAPI) Find(appId string, platform AppStorePlatform, versionString string) (AppStoreVersion, bool, error) {
+	if appId == "" {
+		return AppStoreVersion{}, false, fmt.Errorf("app id is required")
+	}
+	if versionString == "" {
+		return AppStoreVersion{}, false, fmt.Errorf("version string is required")
+	}
+
+	response, err := a.All(appId, map[string]string{
+		"filter[platform]":      string(platform),
+		"filter[versionString]": versionString,
+		"limit":                 "1",
+	})
+	if err != nil {
+		return AppStoreVersion{}, false, err
+	}
+	var versions []AppStoreVersion
+	if err := jsonapi.UnmarshalList(response, &versions); err != nil {
+		return AppStoreVersion{}, false, err
+	}
+	if len(versions) == 0 {
+		return AppStoreVersion{}, false, nil
+	}
+	return versions[0], true, nil
+}
+
+// Create creates a new App Store version of an app on a platform
+func (a *AppStoreVersionsAPI) Create(appId string, platform AppStorePlatform, versionString string) (AppStoreVersion, error) {
+	if appId == "" {
+		return AppStoreVersion{}, fmt.Errorf("app id is required")
+	}
+	if platform == "" {
+		return AppStoreVersion{}, fmt.Errorf("platform is required")
+	}
+	if versionString == "" {
+		return AppStoreVersion{}, fmt.Errorf("version string is required")
+	}
+	if err := a.client.EnsureAuth(); err != nil {
+		return AppStoreVersion{}, err
+	}
+
+	response, err := a.client.GetHTTPClient().PostJSON("/appStoreVersions", jsonapi.NewDocument(jsonapi.Resource{
+		Type: "appStoreVersions",
+		Attributes: map[string]string{
+			"platform":      string(platform),
+			"versionString": versionString,
+		},
+		Relationships: map[string]jsonapi.Relationship{
+			"app": jsonapi.ToOne("apps", appId),
+		},
+	}))
+	if err != nil {
+		return AppStoreVersion{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return AppStoreVersion{}, err
+	}
+	var version AppStoreVersion
+	err = jsonapi.Unmarshal(resource, &version)
+	return version, err
+}
+
+// Update changes the attributes of an App Store version
+func (a *AppStoreVersionsAPI) Update(appStoreVersionId string, update AppStoreVersionUpdate) (AppStoreVersion, error) {
+	if appStoreVersionId == "" {
+		return AppStoreVersion{}, fmt.Errorf("app store version id is required")
+	}
+	if err := a.client.EnsureAuth(); err != nil {
+		return AppStoreVersion{}, err
+	}
+
+	response, err := a.client.GetHTTPClient().PatchJSON("/appStoreVersions/"+appStoreVersionId, jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "appStoreVersions",
+		ID:         appStoreVersionId,
+		Attributes: update,
+	}))
+	if err != nil {
+		return AppStoreVersion{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return AppStoreVersion{}, err
+	}
+	var version AppStoreVersion
+	err = jsonapi.Unmarshal(resource, &version)
+	return version, err
+}
+
+// SetBuild attaches a build to an App Store version
+func (a *AppStoreVersionsAPI) SetBuild(appStoreVersionId, buildId string) (map[string]interface{}, error) {
+	if buildId == "" {
+		return nil, fmt.Errorf("build id is required")
+	}
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().PatchJSON("/appStoreVersions/"+appStoreVersionId+"/relationships/build", jsonapi.Document{
+		Data: jsonapi.Linkage{Type: "builds", ID: buildId},
+	})
+}
+
+// EnablePhasedRelease creates a phased release for an App Store version,
+// which starts rolling out over seven days once the version is released
+func (a *AppStoreVersionsAPI) EnablePhasedRelease(appStoreVersionId string) (map[string]interface{}, error) {
+	if err := a.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return a.client.GetHTTPClient().PostJSON("/appStoreVersionPhasedReleases", jsonapi.NewDocument(jsonapi.Resource{
+		Type: "appStoreVersionPhasedReleases",
+		Attributes: map[string]string{
+			"phasedReleaseState": "INACTIVE",
+		},
+		Relationships: map[string]jsonapi.Relationship{
+			"appStoreVersion": jsonapi.ToOne("appStoreVersions", appStoreVersionId),
+		},
+	}))
+}
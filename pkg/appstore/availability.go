@@ -0,0 +1,78 @@
+package appstore
+
+import "sort"
+
+// ProductAvailability describes the territories a subscription or in-app
+// purchase is available in
+type ProductAvailability struct {
+	ID                        string
+	AvailableInNewTerritories bool
+	Territories               []string
+}
+
+// availableTerritories retrieves every territory ID listed at path
+func (c *Client) availableTerritories(path string) ([]string, error) {
+	var territories []string
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := c.GetHTTPClient().Get(path, query)
+		if err != nil {
+			return territories, err
+		}
+		for _, resource := range resourceList(response) {
+			territories = append(territories, resourceID(resource))
+		}
+		query = nextPageParams(response)
+	}
+	sort.Strings(territories)
+	return territories, nil
+}
+
+// parseProductAvailability reads the availability resource of a response,
+// which has no data when availability was never configured
+func parseProductAvailability(response map[string]interface{}) (ProductAvailability, bool) {
+	resource, ok := response["data"].(map[string]interface{})
+	if !ok {
+		return ProductAvailability{}, false
+	}
+	availability := ProductAvailability{ID: resourceID(resource)}
+	if attributes, ok := resource["attributes"].(map[string]interface{}); ok {
+		availability.AvailableInNewTerritories, _ = attributes["availableInNewTerritories"].(bool)
+	}
+	return availability, true
+}
+
+// territoryLinkages builds the relationship data for a list of territory IDs
+func territoryLinkages(territories []string) map[string]interface{} {
+	territoriesData := make([]map[string]string, len(territories))
+	for i, id := range territories {
+		territoriesData[i] = map[string]string{
+			"type": "territories",
+			"id":   id,
+		}
+	}
+	return map[string]interface{}{
+		"data": territoriesData,
+	}
+}
+
+// mergeTerritories returns current with add included and remove excluded, sorted
+func mergeTerritories(current, add, remove []string) []string {
+	set := make(map[string]bool)
+	for _, id := range current {
+		set[id] = true
+	}
+	for _, id := range add {
+		set[id] = true
+	}
+	for _, id := range remove {
+		delete(set, id)
+	}
+
+	territories := make([]string, 0, len(set))
+	for id := range set {
+		territories = append(territories, id)
+	}
+	sort.Strings(territories)
+	return territories
+}
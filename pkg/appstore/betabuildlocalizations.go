@@ -0,0 +1,96 @@
+package appstore
+
+import (
+	"fmt"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// BetaBuildLocalization represents the localized TestFlight "what to test" notes of a build
+type BetaBuildLocalization struct {
+	ID       string `json:"-"`
+	Locale   string `json:"locale"`
+	WhatsNew string `json:"whatsNew"`
+}
+
+// BetaBuildLocalizationsAPI handles TestFlight build localization operations
+type BetaBuildLocalizationsAPI struct {
+	client *Client
+}
+
+// NewBetaBuildLocalizationsAPI creates a new BetaBuildLocalizations API client
+func NewBetaBuildLocalizationsAPI(client *Client) *BetaBuildLocalizationsAPI {
+	return &BetaBuildLocalizationsAPI{client: client}
+}
+
+// All retrieves the localizations of a build
+func (b *BetaBuildLocalizationsAPI) All(buildId string, params map[string]string) (map[string]interface{}, error) {
+	if err := b.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return b.client.GetHTTPClient().Get("/builds/"+buildId+"/betaBuildLocalizations", params)
+}
+
+// List retrieves every localization of a build
+func (b *BetaBuildLocalizationsAPI) List(buildId string) ([]BetaBuildLocalization, error) {
+	var localizations []BetaBuildLocalization
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := b.All(buildId, query)
+		if err != nil {
+			return localizations, err
+		}
+		var page []BetaBuildLocalization
+		if err := jsonapi.UnmarshalList(response, &page); err != nil {
+			return localizations, err
+		}
+		localizations = append(localizations, page...)
+		query = nextPageParams(response)
+	}
+	return localizations, nil
+}
+
+// Create adds "what to test" notes in a locale to a build
+func (b *BetaBuildLocalizationsAPI) Create(buildId, locale, whatsNew string) (map[string]interface{}, error) {
+	if buildId == "" {
+		return nil, fmt.Errorf("build id is required")
+	}
+	if locale == "" {
+		return nil, fmt.Errorf("locale is required")
+	}
+	if err := b.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	return b.client.GetHTTPClient().PostJSON("/betaBuildLocalizations", jsonapi.NewDocument(jsonapi.Resource{
+		Type: "betaBuildLocalizations",
+		Attributes: map[string]string{
+			"locale":   locale,
+			"whatsNew": whatsNew,
+		},
+		Relationships: map[string]jsonapi.Relationship{
+			"build": jsonapi.ToOne("builds", buildId),
+		},
+	}))
+}
+
+// Update changes the "what to test" notes of a build localization
+func (b *BetaBuildLocalizationsAPI) Update(localizationId, whatsNew string) (map[string]interface{}, error) {
+	if err := b.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	return b.client.GetHTTPClient().PatchJSON("/betaBuildLocalizations/"+localizationId, jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "betaBuildLocalizations",
+		ID:         localizationId,
+		Attributes: map[string]string{"whatsNew": whatsNew},
+	}))
+}
+
+// Delete deletes a build localization by ID
+func (b *BetaBuildLocalizationsAPI) Delete(localizationId string) (map[string]interface{}, error) {
+	if err := b.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return b.client.GetHTTPClient().Delete("/betaBuildLocalizations/"+localizationId, nil)
+}
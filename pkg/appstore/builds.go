@@ -0,0 +1,165 @@
+package appstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+const defaultBuildPollInterval = 30 * time.Second
+
+// BuildProcessingState represents the processing state of an uploaded build
+type BuildProcessingState string
+
+// Build processing states
+const (
+	BuildProcessingStateProcessing BuildProcessingState = "PROCESSING"
+	BuildProcessingStateFailed     BuildProcessingState = "FAILED"
+	BuildProcessingStateInvalid    BuildProcessingState = "INVALID"
+	BuildProcessingStateValid      BuildProcessingState = "VALID"
+)
+
+// Build represents an uploaded build
+type Build struct {
+	ID string `json:"-"`
+	// Version is the build number, CFBundleVersion
+	Version                 string               `json:"version"`
+	UploadedDate            string               `json:"uploadedDate"`
+	ExpirationDate          string               `json:"expirationDate"`
+	Expired                 bool                 `json:"expired"`
+	MinOsVersion            string               `json:"minOsVersion"`
+	ProcessingState         BuildProcessingState `json:"processingState"`
+	UsesNonExemptEncryption *bool                `json:"usesNonExemptEncryption"`
+}
+
+// Processed reports whether App Store Connect has finished processing the build
+func (b Build) Processed() bool {
+	return b.ProcessingState != "" && b.ProcessingState != BuildProcessingStateProcessing
+}
+
+// BuildsAPI handles uploaded build operations
+type BuildsAPI struct {
+	client *Client
+}
+
+// NewBuildsAPI creates a new Builds API client
+func NewBuildsAPI(client *Client) *BuildsAPI {
+	return &BuildsAPI{client: client}
+}
+
+// All retrieves builds with optional parameters
+func (b *BuildsAPI) All(params map[string]string) (map[string]interface{}, error) {
+	if err := b.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return b.client.GetHTTPClient().Get("/builds", params)
+}
+
+// Get retrieves a build by ID
+func (b *BuildsAPI) Get(buildId string) (Build, error) {
+	if err := b.client.EnsureAuth(); err != nil {
+		return Build{}, err
+	}
+	response, err := b.client.GetHTTPClient().Get("/builds/"+buildId, nil)
+	if err != nil {
+		return Build{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return Build{}, err
+	}
+	return parseBuild(resource)
+}
+
+// Find retrieves the build of an app with a build number, returning false
+// when it has not been uploaded or is not yet visible
+func (b *BuildsAPI) Find(appId, buildNumber string) (Build, bool, error) {
+	if appId == "" {
+		return Build{}, false, fmt.Errorf("app id is required")
+	}
+	if buildNumber == "" {
+		return Build{}, false, fmt.Errorf("build number is required")
+	}
+
+	response, err := b.All(map[string]string{
+		"filter[app]":     appId,
+		"filter[version]": buildNumber,
+		"sort":            "-uploadedDate",
+		"limit":           "1",
+	})
+	if err != nil {
+		return Build{}, false, err
+	}
+	resources := resourceList(response)
+	if len(resources) == 0 {
+		return Build{}, false, nil
+	}
+	build, err := parseBuild(resources[0])
+	return build, err == nil, err
+}
+
+// SetUsesNonExemptEncryption sets the export compliance answer of a build,
+// which must be given before it can be tested or submitted
+func (b *BuildsAPI) SetUsesNonExemptEncryption(buildId string, uses bool) (map[string]interface{}, error) {
+	if buildId == "" {
+		return nil, fmt.Errorf("build id is required")
+	}
+	if err := b.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return b.client.GetHTTPClient().PatchJSON("/builds/"+buildId, jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "builds",
+		ID:         buildId,
+		Attributes: map[string]bool{"usesNonExemptEncryption": uses},
+	}))
+}
+
+// WaitForProcessing polls a build until App Store Connect has processed it,
+// calling progress whenever its processing state changes
+func (b *BuildsAPI) WaitForProcessing(ctx context.Context, buildId string, interval time.Duration, progress func(build Build)) (Build, error) {
+	return b.wait(ctx, interval, progress, func(api *BuildsAPI) (Build, bool, error) {
+		build, err := api.Get(buildId)
+		return build, err == nil, err
+	})
+}
+
+// WaitForUpload polls until a build of an app with a build number appears
+// and has been processed, for waiting on a build that was just uploaded
+func (b *BuildsAPI) WaitForUpload(ctx context.Context, appId, buildNumber string, interval time.Duration, progress func(build Build)) (Build, error) {
+	return b.wait(ctx, interval, progress, func(api *BuildsAPI) (Build, bool, error) {
+		return api.Find(appId, buildNumber)
+	})
+}
+
+// wait polls fetch until it returns a processed build. fetch is given an API
+// bound to the context of the attempt, so the wait cancels its requests.
+func (b *BuildsAPI) wait(ctx context.Context, interval time.Duration, progress func(build Build), fetch func(api *BuildsAPI) (Build, bool, error)) (Build, error) {
+	if interval <= 0 {
+		interval = defaultBuildPollInterval
+	}
+
+	var last BuildProcessingState
+	return WaitFor(ctx, WaitOptions{Interval: interval}, func(ctx context.Context) (Build, bool, error) {
+		build, found, err := fetch(NewBuildsAPI(b.client.WithContext(ctx)))
+		if err != nil || !found {
+			return build, false, err
+		}
+		if progress != nil && build.ProcessingState != last {
+			progress(build)
+		}
+		last = build.ProcessingState
+		return build, build.Processed(), nil
+	})
+}
+
+// parseBuild converts a builds resource object to a Build
+func parseBuild(resource map[string]interface{}) (Build, error) {
+	var build Build
+	if err := decodeAttributes(resource, &build); err != nil {
+		return Build{}, err
+	}
+	build.ID = resourceID(resource)
+	return build, nil
+}
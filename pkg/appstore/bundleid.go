@@ -1,5 +1,49 @@
 package appstore
 
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// BundleIdPlatform is the platform of a bundle ID or device
+type BundleIdPlatform string
+
+// Bundle ID platforms
+const (
+	BundleIdPlatformIOS       BundleIdPlatform = "IOS"
+	BundleIdPlatformMacOS     BundleIdPlatform = "MAC_OS"
+	BundleIdPlatformUniversal BundleIdPlatform = "UNIVERSAL"
+	BundleIdPlatformServices  BundleIdPlatform = "SERVICES"
+)
+
+// bundleIdPlatforms lists every known bundle ID platform
+var bundleIdPlatforms = []BundleIdPlatform{
+	BundleIdPlatformIOS,
+	BundleIdPlatformMacOS,
+	BundleIdPlatformUniversal,
+	BundleIdPlatformServices,
+}
+
+// IsValid reports whether the platform is a known bundle ID platform
+func (p BundleIdPlatform) IsValid() bool {
+	return slices.Contains(bundleIdPlatforms, p)
+}
+
+// BundleId represents a registered bundle ID. Relationships holds the links
+// of its profiles, capabilities, and app.
+type BundleId struct {
+	ID            string                                  `json:"-"`
+	Name          string                                  `json:"name"`
+	Platform      string                                  `json:"platform"`
+	Identifier    string                                  `json:"identifier"`
+	SeedID        string                                  `json:"seedId"`
+	Relationships map[string]jsonapi.ResourceRelationship `json:"-"`
+	Links         jsonapi.Links                           `json:"-"`
+}
+
 // BundleIdAPI handles bundle ID-related operations
 type BundleIdAPI struct {
 	client *Client
@@ -10,6 +54,11 @@ func NewBundleIdAPI(client *Client) *BundleIdAPI {
 	return &BundleIdAPI{client: client}
 }
 
+// WithContext returns a copy of the API whose requests are sent with ctx
+func (b *BundleIdAPI) WithContext(ctx context.Context) *BundleIdAPI {
+	return NewBundleIdAPI(b.client.WithContext(ctx))
+}
+
 // All retrieves all bundle IDs
 func (b *BundleIdAPI) All(params map[string]string) (map[string]interface{}, error) {
 	if err := b.client.EnsureAuth(); err != nil {
@@ -18,26 +67,103 @@ func (b *BundleIdAPI) All(params map[string]string) (map[string]interface{}, err
 	return b.client.GetHTTPClient().Get("/bundleIds", params)
 }
 
+// AllPages retrieves the bundle IDs of every page in a single response, following pagination
+func (b *BundleIdAPI) AllPages(params map[string]string) (map[string]interface{}, error) {
+	if err := b.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return b.client.GetHTTPClient().GetAllPages("/bundleIds", params)
+}
+
+// List retrieves every bundle ID matching params, following pagination
+func (b *BundleIdAPI) List(params map[string]string) ([]BundleId, error) {
+	if err := b.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	resources, err := b.client.listResources("/bundleIds", params)
+	if err != nil {
+		return nil, err
+	}
+	bundleIds := make([]BundleId, len(resources))
+	for i, resource := range resources {
+		if err := jsonapi.Unmarshal(resource, &bundleIds[i]); err != nil {
+			return nil, err
+		}
+	}
+	return bundleIds, nil
+}
+
+// ListPage retrieves a single page of bundle IDs matching params with its paging
+// metadata. Pass PageInfo.NextParams as params to request the next page.
+func (b *BundleIdAPI) ListPage(params map[string]string) ([]BundleId, jsonapi.PageInfo, error) {
+	if err := b.client.EnsureAuth(); err != nil {
+		return nil, jsonapi.PageInfo{}, err
+	}
+	resources, info, err := b.client.listPage("/bundleIds", params)
+	if err != nil {
+		return nil, jsonapi.PageInfo{}, err
+	}
+	bundleIds, err := unmarshalResources[BundleId](resources)
+	return bundleIds, info, err
+}
+
+// Get retrieves a bundle ID by ID
+func (b *BundleIdAPI) Get(bId string) (BundleId, error) {
+	if err := b.client.EnsureAuth(); err != nil {
+		return BundleId{}, err
+	}
+	response, err := b.client.GetHTTPClient().Get("/bundleIds/"+bId, nil)
+	if err != nil {
+		return BundleId{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return BundleId{}, err
+	}
+	var bundleId BundleId
+	err = jsonapi.Unmarshal(resource, &bundleId)
+	return bundleId, err
+}
+
 // Register registers a new bundle ID
-func (b *BundleIdAPI) Register(name, platform, bundleId string) (map[string]interface{}, error) {
+func (b *BundleIdAPI) Register(name string, platform BundleIdPlatform, bundleId string) (map[string]interface{}, error) {
+	if !platform.IsValid() {
+		return nil, fmt.Errorf("invalid platform: %s", platform)
+	}
 	if err := b.client.EnsureAuth(); err != nil {
 		return nil, err
 	}
 
-	data := map[string]interface{}{
-		"data": map[string]interface{}{
-			"type": "bundleIds",
-			"attributes": map[string]string{
-				"identifier": bundleId,
-				"name":       name,
-				"platform":   platform,
-			},
+	data := jsonapi.NewDocument(jsonapi.Resource{
+		Type: "bundleIds",
+		Attributes: map[string]string{
+			"identifier": bundleId,
+			"name":       name,
+			"platform":   string(platform),
 		},
-	}
+	})
 
 	return b.client.GetHTTPClient().PostJSON("/bundleIds", data)
 }
 
+// Update renames a bundle ID, the only attribute that can change after it is registered
+func (b *BundleIdAPI) Update(bId, name string) (map[string]interface{}, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := b.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "bundleIds",
+		ID:         bId,
+		Attributes: map[string]string{"name": name},
+	})
+
+	return b.client.GetHTTPClient().PatchJSON("/bundleIds/"+bId, data)
+}
+
 // Delete deletes a bundle ID by ID
 func (b *BundleIdAPI) Delete(bId string) (map[string]interface{}, error) {
 	if err := b.client.EnsureAuth(); err != nil {
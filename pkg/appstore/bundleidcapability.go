@@ -1,5 +1,84 @@
 package appstore
 
+import (
+	"fmt"
+	"slices"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// CapabilityType is a capability of a bundle ID
+type CapabilityType string
+
+// Capability types
+const (
+	CapabilityICloud                         CapabilityType = "ICLOUD"
+	CapabilityInAppPurchase                  CapabilityType = "IN_APP_PURCHASE"
+	CapabilityGameCenter                     CapabilityType = "GAME_CENTER"
+	CapabilityPushNotifications              CapabilityType = "PUSH_NOTIFICATIONS"
+	CapabilityWallet                         CapabilityType = "WALLET"
+	CapabilityInterAppAudio                  CapabilityType = "INTER_APP_AUDIO"
+	CapabilityMaps                           CapabilityType = "MAPS"
+	CapabilityAssociatedDomains              CapabilityType = "ASSOCIATED_DOMAINS"
+	CapabilityPersonalVPN                    CapabilityType = "PERSONAL_VPN"
+	CapabilityAppGroups                      CapabilityType = "APP_GROUPS"
+	CapabilityHealthKit                      CapabilityType = "HEALTHKIT"
+	CapabilityHomeKit                        CapabilityType = "HOMEKIT"
+	CapabilityWirelessAccessoryConfiguration CapabilityType = "WIRELESS_ACCESSORY_CONFIGURATION"
+	CapabilityApplePay                       CapabilityType = "APPLE_PAY"
+	CapabilityDataProtection                 CapabilityType = "DATA_PROTECTION"
+	CapabilitySiriKit                        CapabilityType = "SIRIKIT"
+	CapabilityNetworkExtensions              CapabilityType = "NETWORK_EXTENSIONS"
+	CapabilityMultipath                      CapabilityType = "MULTIPATH"
+	CapabilityHotSpot                        CapabilityType = "HOT_SPOT"
+	CapabilityNFCTagReading                  CapabilityType = "NFC_TAG_READING"
+	CapabilityClassKit                       CapabilityType = "CLASSKIT"
+	CapabilityAutoFillCredentialProvider     CapabilityType = "AUTOFILL_CREDENTIAL_PROVIDER"
+	CapabilityAccessWiFiInformation          CapabilityType = "ACCESS_WIFI_INFORMATION"
+	CapabilityNetworkCustomProtocol          CapabilityType = "NETWORK_CUSTOM_PROTOCOL"
+	CapabilityCoreMediaHLSLowLatency         CapabilityType = "COREMEDIA_HLS_LOW_LATENCY"
+	CapabilitySystemExtensionInstall         CapabilityType = "SYSTEM_EXTENSION_INSTALL"
+	CapabilityUserManagement                 CapabilityType = "USER_MANAGEMENT"
+	CapabilitySignInWithApple                CapabilityType = "APPLE_ID_AUTH"
+)
+
+// capabilityTypes lists every known capability type
+var capabilityTypes = []CapabilityType{
+	CapabilityICloud,
+	CapabilityInAppPurchase,
+	CapabilityGameCenter,
+	CapabilityPushNotifications,
+	CapabilityWallet,
+	CapabilityInterAppAudio,
+	CapabilityMaps,
+	CapabilityAssociatedDomains,
+	CapabilityPersonalVPN,
+	CapabilityAppGroups,
+	CapabilityHealthKit,
+	CapabilityHomeKit,
+	CapabilityWirelessAccessoryConfiguration,
+	CapabilityApplePay,
+	CapabilityDataProtection,
+	CapabilitySiriKit,
+	CapabilityNetworkExtensions,
+	CapabilityMultipath,
+	CapabilityHotSpot,
+	CapabilityNFCTagReading,
+	CapabilityClassKit,
+	CapabilityAutoFillCredentialProvider,
+	CapabilityAccessWiFiInformation,
+	CapabilityNetworkCustomProtocol,
+	CapabilityCoreMediaHLSLowLatency,
+	CapabilitySystemExtensionInstall,
+	CapabilityUserManagement,
+	CapabilitySignInWithApple,
+}
+
+// IsValid reports whether the type is a known capability type
+func (t CapabilityType) IsValid() bool {
+	return slices.Contains(capabilityTypes, t)
+}
+
 // BundleIdCapabilityAPI handles bundle ID capability-related operations
 type BundleIdCapabilityAPI struct {
 	client *Client
@@ -11,27 +90,23 @@ func NewBundleIdCapabilityAPI(client *Client) *BundleIdCapabilityAPI {
 }
 
 // Enable enables a capability for a bundle ID
-func (b *BundleIdCapabilityAPI) Enable(bId, capability string) (map[string]interface{}, error) {
+func (b *BundleIdCapabilityAPI) Enable(bId string, capability CapabilityType) (map[string]interface{}, error) {
+	if !capability.IsValid() {
+		return nil, fmt.Errorf("invalid capability type: %s", capability)
+	}
 	if err := b.client.EnsureAuth(); err != nil {
 		return nil, err
 	}
 
-	data := map[string]interface{}{
-		"data": map[string]interface{}{
-			"type": "bundleIdCapabilities",
-			"relationships": map[string]interface{}{
-				"bundleId": map[string]interface{}{
-					"data": map[string]string{
-						"type": "bundleIds",
-						"id":   bId,
-					},
-				},
-			},
-			"attributes": map[string]string{
-				"capabilityType": capability,
-			},
+	data := jsonapi.NewDocument(jsonapi.Resource{
+		Type: "bundleIdCapabilities",
+		Attributes: map[string]string{
+			"capabilityType": string(capability),
 		},
-	}
+		Relationships: map[string]jsonapi.Relationship{
+			"bundleId": jsonapi.ToOne("bundleIds", bId),
+		},
+	})
 
 	return b.client.GetHTTPClient().PostJSON("/bundleIdCapabilities", data)
 }
@@ -0,0 +1,152 @@
+package appstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog is the declarative description of the in-app purchases and
+// subscriptions of an app, reconciled against App Store Connect by CatalogSync
+type Catalog struct {
+	AppID              string                     `json:"appId" yaml:"appId"`
+	SubscriptionGroups []CatalogSubscriptionGroup `json:"subscriptionGroups,omitempty" yaml:"subscriptionGroups,omitempty"`
+	InAppPurchases     []CatalogInAppPurchase     `json:"inAppPurchases,omitempty" yaml:"inAppPurchases,omitempty"`
+}
+
+// CatalogSubscriptionGroup describes a subscription group, matched by reference name
+type CatalogSubscriptionGroup struct {
+	ReferenceName string                `json:"referenceName" yaml:"referenceName"`
+	Subscriptions []CatalogSubscription `json:"subscriptions,omitempty" yaml:"subscriptions,omitempty"`
+}
+
+// CatalogSubscription describes an auto-renewable subscription, matched by product ID
+type CatalogSubscription struct {
+	ProductID      string             `json:"productId" yaml:"productId"`
+	Name           string             `json:"name" yaml:"name"`
+	Period         SubscriptionPeriod `json:"period" yaml:"period"`
+	GroupLevel     int                `json:"groupLevel,omitempty" yaml:"groupLevel,omitempty"`
+	ReviewNote     string             `json:"reviewNote,omitempty" yaml:"reviewNote,omitempty"`
+	FamilySharable bool               `json:"familySharable,omitempty" yaml:"familySharable,omitempty"`
+	// Localizations are keyed by locale, e.g. "en-US"
+	Localizations map[string]LocalizedText `json:"localizations,omitempty" yaml:"localizations,omitempty"`
+	// Prices are customer prices keyed by territory, e.g. "USA": "9.99"
+	Prices map[string]string `json:"prices,omitempty" yaml:"prices,omitempty"`
+	// PreserveCurrentPrice keeps existing subscribers on their price when prices change
+	PreserveCurrentPrice bool `json:"preserveCurrentPrice,omitempty" yaml:"preserveCurrentPrice,omitempty"`
+	// Territories restricts availability, an empty list leaves availability unmanaged
+	Territories               []string                   `json:"territories,omitempty" yaml:"territories,omitempty"`
+	AvailableInNewTerritories bool                       `json:"availableInNewTerritories,omitempty" yaml:"availableInNewTerritories,omitempty"`
+	IntroductoryOffers        []CatalogIntroductoryOffer `json:"introductoryOffers,omitempty" yaml:"introductoryOffers,omitempty"`
+}
+
+// CatalogIntroductoryOffer describes the introductory offer of a subscription in a territory
+type CatalogIntroductoryOffer struct {
+	Territory       string                    `json:"territory" yaml:"territory"`
+	OfferMode       SubscriptionOfferMode     `json:"offerMode" yaml:"offerMode"`
+	Duration        SubscriptionOfferDuration `json:"duration" yaml:"duration"`
+	NumberOfPeriods int                       `json:"numberOfPeriods,omitempty" yaml:"numberOfPeriods,omitempty"`
+	// CustomerPrice is required for pay as you go and pay up front offers
+	CustomerPrice string `json:"customerPrice,omitempty" yaml:"customerPrice,omitempty"`
+}
+
+// CatalogInAppPurchase describes an in-app purchase, matched by product ID
+type CatalogInAppPurchase struct {
+	ProductID      string                   `json:"productId" yaml:"productId"`
+	Name           string                   `json:"name" yaml:"name"`
+	Type           InAppPurchaseType        `json:"type" yaml:"type"`
+	ReviewNote     string                   `json:"reviewNote,omitempty" yaml:"reviewNote,omitempty"`
+	FamilySharable bool                     `json:"familySharable,omitempty" yaml:"familySharable,omitempty"`
+	Localizations  map[string]LocalizedText `json:"localizations,omitempty" yaml:"localizations,omitempty"`
+	// Territories restricts availability, an empty list leaves availability unmanaged
+	Territories               []string `json:"territories,omitempty" yaml:"territories,omitempty"`
+	AvailableInNewTerritories bool     `json:"availableInNewTerritories,omitempty" yaml:"availableInNewTerritories,omitempty"`
+}
+
+// LoadCatalog reads a catalog from a .json, .yaml, or .yml file
+func LoadCatalog(path string) (*Catalog, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+
+	var catalog Catalog
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &catalog)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &catalog)
+	default:
+		return nil, fmt.Errorf("unsupported catalog format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse catalog: %w", err)
+	}
+
+	if err := catalog.Validate(); err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+// Validate checks that the catalog is complete and that product IDs are unique
+func (c *Catalog) Validate() error {
+	if c.AppID == "" {
+		return fmt.Errorf("app id is required")
+	}
+
+	seen := make(map[string]bool)
+	checkProductID := func(productId string) error {
+		if productId == "" {
+			return fmt.Errorf("product id is required")
+		}
+		if seen[productId] {
+			return fmt.Errorf("duplicate product id %s", productId)
+		}
+		seen[productId] = true
+		return nil
+	}
+
+	for _, group := range c.SubscriptionGroups {
+		if group.ReferenceName == "" {
+			return fmt.Errorf("subscription group reference name is required")
+		}
+		for _, subscription := range group.Subscriptions {
+			if err := checkProductID(subscription.ProductID); err != nil {
+				return err
+			}
+			if subscription.Name == "" {
+				return fmt.Errorf("%s: name is required", subscription.ProductID)
+			}
+			if subscription.Period == "" {
+				return fmt.Errorf("%s: period is required", subscription.ProductID)
+			}
+			for _, offer := range subscription.IntroductoryOffers {
+				if offer.Territory == "" {
+					return fmt.Errorf("%s: introductory offer territory is required", subscription.ProductID)
+				}
+				if offer.OfferMode != SubscriptionOfferModeFreeTrial && offer.CustomerPrice == "" {
+					return fmt.Errorf("%s: customer price is required for %s offers", subscription.ProductID, offer.OfferMode)
+				}
+			}
+		}
+	}
+
+	for _, iap := range c.InAppPurchases {
+		if err := checkProductID(iap.ProductID); err != nil {
+			return err
+		}
+		if iap.Name == "" {
+			return fmt.Errorf("%s: name is required", iap.ProductID)
+		}
+		if iap.Type == "" {
+			return fmt.Errorf("%s: type is required", iap.ProductID)
+		}
+	}
+
+	return nil
+}
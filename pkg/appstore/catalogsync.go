@@ -0,0 +1,747 @@
+package appstore
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CatalogAction represents the kind of a catalog change
+type CatalogAction string
+
+// Catalog actions
+const (
+	CatalogActionCreate CatalogAction = "CREATE"
+	CatalogActionUpdate CatalogAction = "UPDATE"
+	// CatalogActionWarn marks a difference that is reported but never applied
+	CatalogActionWarn CatalogAction = "WARN"
+)
+
+// CatalogChange is a single difference between a catalog and App Store Connect
+type CatalogChange struct {
+	Action CatalogAction
+	// Resource is the kind of resource changed, e.g. "subscription" or "price"
+	Resource string
+	// Target is the product ID, or the reference name of a subscription group
+	Target string
+	Detail string
+	apply  func() error
+}
+
+// String formats the change as a line of a diff report
+func (c CatalogChange) String() string {
+	symbol := "~"
+	switch c.Action {
+	case CatalogActionCreate:
+		symbol = "+"
+	case CatalogActionWarn:
+		symbol = "!"
+	}
+	return fmt.Sprintf("%s %s %s: %s", symbol, c.Resource, c.Target, c.Detail)
+}
+
+// CatalogPlan lists the changes needed to make App Store Connect match a catalog, in apply order
+type CatalogPlan struct {
+	Changes []CatalogChange
+}
+
+// HasChanges reports whether the plan contains changes to apply
+func (p *CatalogPlan) HasChanges() bool {
+	for _, change := range p.Changes {
+		if change.apply != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the plan as a diff report, one change per line
+func (p *CatalogPlan) String() string {
+	if len(p.Changes) == 0 {
+		return "no changes"
+	}
+	lines := make([]string, len(p.Changes))
+	for i, change := range p.Changes {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// add appends a change to the plan
+func (p *CatalogPlan) add(change CatalogChange) {
+	p.Changes = append(p.Changes, change)
+}
+
+// CatalogFailure is a change that failed to apply
+type CatalogFailure struct {
+	Change CatalogChange
+	Err    error
+}
+
+// CatalogResult reports the outcome of applying a plan
+type CatalogResult struct {
+	Applied  []CatalogChange
+	Failures []CatalogFailure
+	Warnings []CatalogChange
+}
+
+// Err returns the failures of the result joined into one error, or nil
+func (r CatalogResult) Err() error {
+	errs := make([]error, len(r.Failures))
+	for i, failure := range r.Failures {
+		errs[i] = fmt.Errorf("%s %s: %w", failure.Change.Resource, failure.Change.Target, failure.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// CatalogSync reconciles App Store Connect with a declarative Catalog.
+// Products, localizations, prices, availability, and introductory offers
+// missing from App Store Connect are created and differing ones updated.
+// Nothing is ever deleted: products, localizations, and offers absent from
+// the catalog are left untouched, and existing introductory offers are not
+// modified.
+type CatalogSync struct {
+	client *Client
+	// AcknowledgeFamilySharing allows enabling Family Sharing, which cannot
+	// be undone. Without it such differences are reported as warnings.
+	AcknowledgeFamilySharing bool
+}
+
+// NewCatalogSync creates a new catalog sync
+func NewCatalogSync(client *Client) *CatalogSync {
+	return &CatalogSync{client: client}
+}
+
+// Sync plans and applies the changes needed to match catalog
+func (s *CatalogSync) Sync(catalog *Catalog) (*CatalogPlan, CatalogResult, error) {
+	plan, err := s.Plan(catalog)
+	if err != nil {
+		return nil, CatalogResult{}, err
+	}
+	result := s.Apply(plan)
+	return plan, result, result.Err()
+}
+
+// Plan compares catalog with App Store Connect and returns the changes
+// needed to match it, without applying them
+func (s *CatalogSync) Plan(catalog *Catalog) (*CatalogPlan, error) {
+	if err := catalog.Validate(); err != nil {
+		return nil, err
+	}
+
+	plan := &CatalogPlan{}
+	if len(catalog.SubscriptionGroups) > 0 {
+		if err := s.planSubscriptionGroups(plan, catalog); err != nil {
+			return nil, err
+		}
+	}
+	if len(catalog.InAppPurchases) > 0 {
+		if err := s.planInAppPurchases(plan, catalog); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// Apply applies every change of a plan in order, continuing past failures.
+// Warnings are collected without being applied.
+func (s *CatalogSync) Apply(plan *CatalogPlan) CatalogResult {
+	var result CatalogResult
+	for _, change := range plan.Changes {
+		if change.apply == nil {
+			result.Warnings = append(result.Warnings, change)
+			continue
+		}
+		if err := change.apply(); err != nil {
+			result.Failures = append(result.Failures, CatalogFailure{Change: change, Err: err})
+			continue
+		}
+		result.Applied = append(result.Applied, change)
+	}
+	return result
+}
+
+// planSubscriptionGroups adds the changes of the subscription groups of catalog
+func (s *CatalogSync) planSubscriptionGroups(plan *CatalogPlan, catalog *Catalog) error {
+	groups, err := NewSubscriptionGroupsAPI(s.client).List(catalog.AppID)
+	if err != nil {
+		return fmt.Errorf("failed to list subscription groups: %w", err)
+	}
+	groupIds := make(map[string]string, len(groups))
+	for _, group := range groups {
+		groupIds[group.ReferenceName] = group.ID
+	}
+
+	for _, group := range catalog.SubscriptionGroups {
+		group := group
+		groupId, ok := groupIds[group.ReferenceName]
+		if !ok {
+			plan.add(CatalogChange{
+				Action:   CatalogActionCreate,
+				Resource: "subscriptionGroup",
+				Target:   group.ReferenceName,
+				Detail:   fmt.Sprintf("create with %d subscriptions", len(group.Subscriptions)),
+				apply: func() error {
+					return s.createSubscriptionGroup(catalog.AppID, group)
+				},
+			})
+			for _, subscription := range group.Subscriptions {
+				s.planNewProductFamilySharing(plan, "subscription", subscription.ProductID, subscription.FamilySharable)
+			}
+			continue
+		}
+
+		existing, err := NewSubscriptionsAPI(s.client).List(groupId)
+		if err != nil {
+			return fmt.Errorf("failed to list subscriptions of %s: %w", group.ReferenceName, err)
+		}
+		byProduct := make(map[string]Subscription, len(existing))
+		for _, subscription := range existing {
+			byProduct[subscription.ProductID] = subscription
+		}
+
+		for _, subscription := range group.Subscriptions {
+			subscription := subscription
+			current, ok := byProduct[subscription.ProductID]
+			if !ok {
+				plan.add(CatalogChange{
+					Action:   CatalogActionCreate,
+					Resource: "subscription",
+					Target:   subscription.ProductID,
+					Detail:   "create in " + group.ReferenceName,
+					apply: func() error {
+						return s.createSubscription(groupId, subscription)
+					},
+				})
+				s.planNewProductFamilySharing(plan, "subscription", subscription.ProductID, subscription.FamilySharable)
+				continue
+			}
+			if err := s.planSubscription(plan, current, subscription); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// planSubscription adds the changes that bring an existing subscription in line with desired
+func (s *CatalogSync) planSubscription(plan *CatalogPlan, current Subscription, desired CatalogSubscription) error {
+	subscriptionsAPI := NewSubscriptionsAPI(s.client)
+	target := desired.ProductID
+
+	var update SubscriptionUpdate
+	var fields []string
+	if current.Name != desired.Name {
+		update.Name = &desired.Name
+		fields = append(fields, fmt.Sprintf("name %q -> %q", current.Name, desired.Name))
+	}
+	if current.SubscriptionPeriod != desired.Period {
+		update.SubscriptionPeriod = &desired.Period
+		fields = append(fields, fmt.Sprintf("period %s -> %s", current.SubscriptionPeriod, desired.Period))
+	}
+	if desired.GroupLevel != 0 && current.GroupLevel != desired.GroupLevel {
+		update.GroupLevel = &desired.GroupLevel
+		fields = append(fields, fmt.Sprintf("group level %d -> %d", current.GroupLevel, desired.GroupLevel))
+	}
+	if desired.ReviewNote != "" && current.ReviewNote != desired.ReviewNote {
+		update.ReviewNote = &desired.ReviewNote
+		fields = append(fields, "review note")
+	}
+	if len(fields) > 0 {
+		plan.add(CatalogChange{
+			Action:   CatalogActionUpdate,
+			Resource: "subscription",
+			Target:   target,
+			Detail:   strings.Join(fields, ", "),
+			apply: func() error {
+				_, err := subscriptionsAPI.Update(current.ID, update)
+				return err
+			},
+		})
+	}
+
+	s.planFamilySharing(plan, "subscription", target, current.FamilySharable, desired.FamilySharable, func() error {
+		_, err := subscriptionsAPI.EnableFamilySharing(current.ID, true)
+		return err
+	})
+
+	if len(desired.Localizations) > 0 {
+		localizationsAPI := NewSubscriptionLocalizationsAPI(s.client)
+		localizations, err := localizationsAPI.List(current.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list localizations of %s: %w", target, err)
+		}
+		existing := make(map[string]catalogLocalization, len(localizations))
+		for _, localization := range localizations {
+			existing[localization.Locale] = catalogLocalization{
+				ID:   localization.ID,
+				Text: LocalizedText{Name: localization.Name, Description: localization.Description},
+			}
+		}
+		planLocalizations(plan, target, existing, desired.Localizations, func(locale string, text LocalizedText) error {
+			_, err := localizationsAPI.Create(current.ID, locale, text.Name, text.Description)
+			return err
+		}, func(localizationId string, text LocalizedText) error {
+			_, err := localizationsAPI.Update(localizationId, text.Name, text.Description)
+			return err
+		})
+	}
+
+	if len(desired.Territories) > 0 {
+		availabilityAPI := NewSubscriptionAvailabilitiesAPI(s.client)
+		availability, err := availabilityAPI.Get(current.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get availability of %s: %w", target, err)
+		}
+		planAvailability(plan, "subscription", target, availability, desired.Territories, desired.AvailableInNewTerritories, func() error {
+			_, err := availabilityAPI.Set(current.ID, desired.Territories, desired.AvailableInNewTerritories)
+			return err
+		})
+	}
+
+	if len(desired.Prices) > 0 {
+		prices, err := NewSubscriptionPricesAPI(s.client).CurrentPrices(current.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get prices of %s: %w", target, err)
+		}
+		for _, territory := range sortedKeys(desired.Prices) {
+			territory := territory
+			price := desired.Prices[territory]
+			currentPrice, ok := prices[territory]
+			if ok && samePrice(currentPrice, price) {
+				continue
+			}
+			change := CatalogChange{
+				Action:   CatalogActionCreate,
+				Resource: "price",
+				Target:   target,
+				Detail:   fmt.Sprintf("%s %s", territory, price),
+				apply: func() error {
+					return s.setSubscriptionPrice(current.ID, territory, price, desired.PreserveCurrentPrice)
+				},
+			}
+			if ok {
+				change.Action = CatalogActionUpdate
+				change.Detail = fmt.Sprintf("%s %s -> %s", territory, currentPrice, price)
+			}
+			plan.add(change)
+		}
+	}
+
+	if len(desired.IntroductoryOffers) > 0 {
+		territories, err := s.introductoryOfferTerritories(current.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list introductory offers of %s: %w", target, err)
+		}
+		for _, offer := range desired.IntroductoryOffers {
+			offer := offer
+			if territories[offer.Territory] {
+				continue
+			}
+			plan.add(CatalogChange{
+				Action:   CatalogActionCreate,
+				Resource: "introductoryOffer",
+				Target:   target,
+				Detail:   fmt.Sprintf("%s %s %s", offer.Territory, offer.OfferMode, offer.Duration),
+				apply: func() error {
+					return s.createIntroductoryOffer(current.ID, offer)
+				},
+			})
+		}
+	}
+
+	return nil
+}
+
+// planInAppPurchases adds the changes of the in-app purchases of catalog
+func (s *CatalogSync) planInAppPurchases(plan *CatalogPlan, catalog *Catalog) error {
+	iapAPI := NewInAppPurchasesAPI(s.client)
+	existing, err := iapAPI.List(catalog.AppID)
+	if err != nil {
+		return fmt.Errorf("failed to list in-app purchases: %w", err)
+	}
+	byProduct := make(map[string]InAppPurchase, len(existing))
+	for _, iap := range existing {
+		byProduct[iap.ProductID] = iap
+	}
+
+	for _, iap := range catalog.InAppPurchases {
+		iap := iap
+		current, ok := byProduct[iap.ProductID]
+		if !ok {
+			plan.add(CatalogChange{
+				Action:   CatalogActionCreate,
+				Resource: "inAppPurchase",
+				Target:   iap.ProductID,
+				Detail:   "create " + string(iap.Type),
+				apply: func() error {
+					return s.createInAppPurchase(catalog.AppID, iap)
+				},
+			})
+			s.planNewProductFamilySharing(plan, "inAppPurchase", iap.ProductID, iap.FamilySharable)
+			continue
+		}
+		if err := s.planInAppPurchase(plan, current, iap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planInAppPurchase adds the changes that bring an existing in-app purchase in line with desired
+func (s *CatalogSync) planInAppPurchase(plan *CatalogPlan, current InAppPurchase, desired CatalogInAppPurchase) error {
+	iapAPI := NewInAppPurchasesAPI(s.client)
+	target := desired.ProductID
+
+	if current.InAppPurchaseType != desired.Type {
+		plan.add(CatalogChange{
+			Action:   CatalogActionWarn,
+			Resource: "inAppPurchase",
+			Target:   target,
+			Detail:   fmt.Sprintf("type %s cannot be changed to %s", current.InAppPurchaseType, desired.Type),
+		})
+	}
+
+	var update InAppPurchaseUpdate
+	var fields []string
+	if current.Name != desired.Name {
+		update.Name = &desired.Name
+		fields = append(fields, fmt.Sprintf("name %q -> %q", current.Name, desired.Name))
+	}
+	if desired.ReviewNote != "" && current.ReviewNote != desired.ReviewNote {
+		update.ReviewNote = &desired.ReviewNote
+		fields = append(fields, "review note")
+	}
+	if len(fields) > 0 {
+		plan.add(CatalogChange{
+			Action:   CatalogActionUpdate,
+			Resource: "inAppPurchase",
+			Target:   target,
+			Detail:   strings.Join(fields, ", "),
+			apply: func() error {
+				_, err := iapAPI.Update(current.ID, update)
+				return err
+			},
+		})
+	}
+
+	s.planFamilySharing(plan, "inAppPurchase", target, current.FamilySharable, desired.FamilySharable, func() error {
+		_, err := iapAPI.EnableFamilySharing(current.ID, true)
+		return err
+	})
+
+	if len(desired.Localizations) > 0 {
+		localizationsAPI := NewInAppPurchaseLocalizationsAPI(s.client)
+		localizations, err := localizationsAPI.List(current.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list localizations of %s: %w", target, err)
+		}
+		existing := make(map[string]catalogLocalization, len(localizations))
+		for _, localization := range localizations {
+			existing[localization.Locale] = catalogLocalization{
+				ID:   localization.ID,
+				Text: LocalizedText{Name: localization.Name, Description: localization.Description},
+			}
+		}
+		planLocalizations(plan, target, existing, desired.Localizations, func(locale string, text LocalizedText) error {
+			_, err := localizationsAPI.Create(current.ID, locale, text.Name, text.Description)
+			return err
+		}, func(localizationId string, text LocalizedText) error {
+			_, err := localizationsAPI.Update(localizationId, text.Name, text.Description)
+			return err
+		})
+	}
+
+	if len(desired.Territories) > 0 {
+		availabilityAPI := NewInAppPurchaseAvailabilitiesAPI(s.client)
+		availability, err := availabilityAPI.Get(current.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get availability of %s: %w", target, err)
+		}
+		planAvailability(plan, "inAppPurchase", target, availability, desired.Territories, desired.AvailableInNewTerritories, func() error {
+			_, err := availabilityAPI.Set(current.ID, desired.Territories, desired.AvailableInNewTerritories)
+			return err
+		})
+	}
+
+	return nil
+}
+
+// planFamilySharing adds the change for a Family Sharing difference, which
+// is only applied when enabling it was acknowledged
+func (s *CatalogSync) planFamilySharing(plan *CatalogPlan, resource, target string, current, desired bool, enable func() error) {
+	switch {
+	case current == desired:
+		return
+	case current:
+		plan.add(CatalogChange{
+			Action:   CatalogActionWarn,
+			Resource: resource,
+			Target:   target,
+			Detail:   "family sharing is enabled and cannot be disabled",
+		})
+	case !s.AcknowledgeFamilySharing:
+		plan.add(CatalogChange{
+			Action:   CatalogActionWarn,
+			Resource: resource,
+			Target:   target,
+			Detail:   "enabling family sharing cannot be undone and was not acknowledged",
+		})
+	default:
+		plan.add(CatalogChange{
+			Action:   CatalogActionUpdate,
+			Resource: resource,
+			Target:   target,
+			Detail:   "enable family sharing (irreversible)",
+			apply:    enable,
+		})
+	}
+}
+
+// planNewProductFamilySharing warns when a product to create wants Family
+// Sharing without it being acknowledged, otherwise creation enables it
+func (s *CatalogSync) planNewProductFamilySharing(plan *CatalogPlan, resource, target string, familySharable bool) {
+	if familySharable && !s.AcknowledgeFamilySharing {
+		s.planFamilySharing(plan, resource, target, false, true, nil)
+	}
+}
+
+// catalogLocalization is an existing localization compared during planning
+type catalogLocalization struct {
+	ID   string
+	Text LocalizedText
+}
+
+// planLocalizations adds the changes that bring existing localizations, keyed by locale, in line with desired
+func planLocalizations(plan *CatalogPlan, target string, existing map[string]catalogLocalization, desired map[string]LocalizedText, create func(locale string, text LocalizedText) error, update func(localizationId string, text LocalizedText) error) {
+	for _, locale := range sortedKeys(desired) {
+		locale := locale
+		text := desired[locale]
+		current, ok := existing[locale]
+		switch {
+		case !ok:
+			plan.add(CatalogChange{
+				Action:   CatalogActionCreate,
+				Resource: "localization",
+				Target:   target,
+				Detail:   locale,
+				apply: func() error {
+					return create(locale, text)
+				},
+			})
+		case localizedTextChanged(current.Text, text):
+			plan.add(CatalogChange{
+				Action:   CatalogActionUpdate,
+				Resource: "localization",
+				Target:   target,
+				Detail:   locale,
+				apply: func() error {
+					return update(current.ID, text)
+				},
+			})
+		}
+	}
+}
+
+// localizedTextChanged reports whether a localization differs from the
+// desired text. Empty desired values are unmanaged, as Update leaves them.
+func localizedTextChanged(current, desired LocalizedText) bool {
+	return (desired.Name != "" && current.Name != desired.Name) ||
+		(desired.Description != "" && current.Description != desired.Description)
+}
+
+// planAvailability adds an availability change when the current territories differ from desired
+func planAvailability(plan *CatalogPlan, resource, target string, current ProductAvailability, territories []string, availableInNewTerritories bool, set func() error) {
+	desired := mergeTerritories(territories, nil, nil)
+	added := mergeTerritories(desired, nil, current.Territories)
+	removed := mergeTerritories(current.Territories, nil, desired)
+	if len(added) == 0 && len(removed) == 0 && current.AvailableInNewTerritories == availableInNewTerritories {
+		return
+	}
+
+	action := CatalogActionUpdate
+	if current.ID == "" {
+		action = CatalogActionCreate
+	}
+	plan.add(CatalogChange{
+		Action:   action,
+		Resource: "availability",
+		Target:   target,
+		Detail:   fmt.Sprintf("add %v, remove %v, new territories %t", added, removed, availableInNewTerritories),
+		apply:    set,
+	})
+}
+
+// createSubscriptionGroup creates a subscription group with all of its subscriptions
+func (s *CatalogSync) createSubscriptionGroup(appId string, group CatalogSubscriptionGroup) error {
+	response, err := NewSubscriptionGroupsAPI(s.client).Create(appId, group.ReferenceName)
+	if err != nil {
+		return err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return err
+	}
+	for _, subscription := range group.Subscriptions {
+		if err := s.createSubscription(resourceID(resource), subscription); err != nil {
+			return fmt.Errorf("%s: %w", subscription.ProductID, err)
+		}
+	}
+	return nil
+}
+
+// createSubscription creates a subscription with its localizations,
+// availability, prices, and introductory offers
+func (s *CatalogSync) createSubscription(groupId string, desired CatalogSubscription) error {
+	subscriptionsAPI := NewSubscriptionsAPI(s.client)
+	subscription, err := subscriptionsAPI.Create(groupId, SubscriptionAttributes{
+		Name:               desired.Name,
+		ProductID:          desired.ProductID,
+		SubscriptionPeriod: desired.Period,
+		GroupLevel:         desired.GroupLevel,
+		ReviewNote:         desired.ReviewNote,
+	})
+	if err != nil {
+		return err
+	}
+
+	if desired.FamilySharable && s.AcknowledgeFamilySharing {
+		if _, err := subscriptionsAPI.EnableFamilySharing(subscription.ID, true); err != nil {
+			return fmt.Errorf("failed to enable family sharing: %w", err)
+		}
+	}
+	if len(desired.Localizations) > 0 {
+		if _, err := NewSubscriptionLocalizationsAPI(s.client).Sync(subscription.ID, desired.Localizations); err != nil {
+			return err
+		}
+	}
+	if len(desired.Territories) > 0 {
+		if _, err := NewSubscriptionAvailabilitiesAPI(s.client).Set(subscription.ID, desired.Territories, desired.AvailableInNewTerritories); err != nil {
+			return fmt.Errorf("failed to set availability: %w", err)
+		}
+	}
+	for _, territory := range sortedKeys(desired.Prices) {
+		if err := s.setSubscriptionPrice(subscription.ID, territory, desired.Prices[territory], false); err != nil {
+			return err
+		}
+	}
+	for _, offer := range desired.IntroductoryOffers {
+		if err := s.createIntroductoryOffer(subscription.ID, offer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createInAppPurchase creates an in-app purchase with its localizations and availability
+func (s *CatalogSync) createInAppPurchase(appId string, desired CatalogInAppPurchase) error {
+	iapAPI := NewInAppPurchasesAPI(s.client)
+	iap, err := iapAPI.Create(appId, InAppPurchaseAttributes{
+		Name:              desired.Name,
+		ProductID:         desired.ProductID,
+		InAppPurchaseType: desired.Type,
+		ReviewNote:        desired.ReviewNote,
+	})
+	if err != nil {
+		return err
+	}
+
+	if desired.FamilySharable && s.AcknowledgeFamilySharing {
+		if _, err := iapAPI.EnableFamilySharing(iap.ID, true); err != nil {
+			return fmt.Errorf("failed to enable family sharing: %w", err)
+		}
+	}
+	localizationsAPI := NewInAppPurchaseLocalizationsAPI(s.client)
+	for _, locale := range sortedKeys(desired.Localizations) {
+		text := desired.Localizations[locale]
+		if _, err := localizationsAPI.Create(iap.ID, locale, text.Name, text.Description); err != nil {
+			return fmt.Errorf("failed to create %s localization: %w", locale, err)
+		}
+	}
+	if len(desired.Territories) > 0 {
+		if _, err := NewInAppPurchaseAvailabilitiesAPI(s.client).Set(iap.ID, desired.Territories, desired.AvailableInNewTerritories); err != nil {
+			return fmt.Errorf("failed to set availability: %w", err)
+		}
+	}
+	return nil
+}
+
+// setSubscriptionPrice schedules the price point matching customerPrice in a territory
+func (s *CatalogSync) setSubscriptionPrice(subscriptionId, territory, customerPrice string, preserveCurrentPrice bool) error {
+	pricesAPI := NewSubscriptionPricesAPI(s.client)
+	pricePointId, err := pricesAPI.FindPricePoint(subscriptionId, territory, customerPrice)
+	if err != nil {
+		return err
+	}
+	_, err = pricesAPI.CreatePrice(subscriptionId, SubscriptionPriceChange{
+		Territory:            territory,
+		PricePointID:         pricePointId,
+		PreserveCurrentPrice: preserveCurrentPrice,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set %s price: %w", territory, err)
+	}
+	return nil
+}
+
+// createIntroductoryOffer creates an introductory offer, resolving its customer price to a price point
+func (s *CatalogSync) createIntroductoryOffer(subscriptionId string, offer CatalogIntroductoryOffer) error {
+	introductoryOffer := IntroductoryOffer{
+		Territory:       offer.Territory,
+		OfferMode:       offer.OfferMode,
+		Duration:        offer.Duration,
+		NumberOfPeriods: offer.NumberOfPeriods,
+	}
+	if offer.CustomerPrice != "" {
+		pricePointId, err := NewSubscriptionPricesAPI(s.client).FindPricePoint(subscriptionId, offer.Territory, offer.CustomerPrice)
+		if err != nil {
+			return err
+		}
+		introductoryOffer.PricePointID = pricePointId
+	}
+
+	if _, err := NewSubscriptionIntroductoryOffersAPI(s.client).Create(subscriptionId, introductoryOffer); err != nil {
+		return fmt.Errorf("failed to create %s introductory offer: %w", offer.Territory, err)
+	}
+	return nil
+}
+
+// introductoryOfferTerritories returns the territories in which a subscription has an introductory offer
+func (s *CatalogSync) introductoryOfferTerritories(subscriptionId string) (map[string]bool, error) {
+	offersAPI := NewSubscriptionIntroductoryOffersAPI(s.client)
+	territories := make(map[string]bool)
+	query := map[string]string{"include": "territory", "limit": "200"}
+	for query != nil {
+		response, err := offersAPI.All(subscriptionId, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, offer := range resourceList(response) {
+			territories[relationshipID(offer, "territory")] = true
+		}
+		query = nextPageParams(response)
+	}
+	return territories, nil
+}
+
+// samePrice reports whether two customer prices are equal, ignoring formatting such as trailing zeros
+func samePrice(a, b string) bool {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return x == y
+}
+
+// sortedKeys returns the keys of a string-keyed map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
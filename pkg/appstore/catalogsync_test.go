@@ -0,0 +1,155 @@
+package appstore_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// catalogResources returns an app with a consumable that has a review note
+// and an en-US localization
+func catalogResources() []appstoretest.Resource {
+	return []appstoretest.Resource{
+		{
+			Type: "apps",
+			ID:   "APP0001",
+			Relationships: map[string]jsonapi.Relationship{
+				"inAppPurchasesV2": jsonapi.ToMany("inAppPurchases", []string{"IAP0001"}),
+			},
+		},
+		{
+			Type: "inAppPurchases",
+			ID:   "IAP0001",
+			Attributes: map[string]interface{}{
+				"name":              "Coins",
+				"productId":         "com.example.coins",
+				"inAppPurchaseType": "CONSUMABLE",
+				"reviewNote":        "Buy in the shop tab",
+			},
+			Relationships: map[string]jsonapi.Relationship{
+				"inAppPurchaseLocalizations": jsonapi.ToMany("inAppPurchaseLocalizations", []string{"LOC0001"}),
+			},
+		},
+		{
+			Type: "inAppPurchaseLocalizations",
+			ID:   "LOC0001",
+			Attributes: map[string]interface{}{
+				"locale":      "en-US",
+				"name":        "Coins",
+				"description": "A pile of coins",
+			},
+		},
+	}
+}
+
+// requestAttributes returns the attributes of the JSON:API body of a request
+func requestAttributes(t *testing.T, request appstoretest.Request) map[string]interface{} {
+	t.Helper()
+	var body struct {
+		Data struct {
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(request.Body, &body); err != nil {
+		t.Fatalf("%s %s: invalid body: %v", request.Method, request.Path, err)
+	}
+	return body.Data.Attributes
+}
+
+func TestCatalogSyncInSync(t *testing.T) {
+	server := appstoretest.NewServer(catalogResources()...)
+	defer server.Close()
+	client := newClient(t, server, nil)
+
+	// The review note and description are omitted, which leaves them unmanaged
+	plan, err := appstore.NewCatalogSync(client).Plan(&appstore.Catalog{
+		AppID: "APP0001",
+		InAppPurchases: []appstore.CatalogInAppPurchase{{
+			ProductID:     "com.example.coins",
+			Name:          "Coins",
+			Type:          appstore.InAppPurchaseTypeConsumable,
+			Localizations: map[string]appstore.LocalizedText{"en-US": {Name: "Coins"}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	if plan.HasChanges() {
+		t.Errorf("got changes for a catalog in sync:\n%s", plan)
+	}
+}
+
+func TestCatalogSync(t *testing.T) {
+	server := appstoretest.NewServer(catalogResources()...)
+	defer server.Close()
+	client := newClient(t, server, nil)
+
+	plan, result, err := appstore.NewCatalogSync(client).Sync(&appstore.Catalog{
+		AppID: "APP0001",
+		InAppPurchases: []appstore.CatalogInAppPurchase{
+			{
+				ProductID: "com.example.coins",
+				Name:      "Gold Coins",
+				Type:      appstore.InAppPurchaseTypeConsumable,
+				Localizations: map[string]appstore.LocalizedText{
+					"en-US": {Name: "Gold Coins"},
+					"de-DE": {Name: "Goldmünzen", Description: "Ein Haufen Münzen"},
+				},
+			},
+			{
+				ProductID: "com.example.pro",
+				Name:      "Pro",
+				Type:      appstore.InAppPurchaseTypeNonConsumable,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	want := []string{
+		`~ inAppPurchase com.example.coins: name "Coins" -> "Gold Coins"`,
+		"+ localization com.example.coins: de-DE",
+		"~ localization com.example.coins: en-US",
+		"+ inAppPurchase com.example.pro: create NON_CONSUMABLE",
+	}
+	if got := plan.String(); got != strings.Join(want, "\n") {
+		t.Errorf("got plan:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+	if len(result.Applied) != len(want) {
+		t.Errorf("got %d applied changes, want %d", len(result.Applied), len(want))
+	}
+
+	// Updates only send the attributes the catalog manages
+	updates := map[string]string{
+		"/inAppPurchases/IAP0001":             `{"name":"Gold Coins"}`,
+		"/inAppPurchaseLocalizations/LOC0001": `{"name":"Gold Coins"}`,
+	}
+	for _, request := range server.Requests() {
+		if request.Method != http.MethodPatch {
+			continue
+		}
+		want, ok := updates[request.Path]
+		if !ok {
+			t.Errorf("unexpected update of %s", request.Path)
+			continue
+		}
+		got, _ := json.Marshal(requestAttributes(t, request))
+		if string(got) != want {
+			t.Errorf("%s: got attributes %s, want %s", request.Path, got, want)
+		}
+		delete(updates, request.Path)
+	}
+	for path := range updates {
+		t.Errorf("%s was not updated", path)
+	}
+
+	if created := server.Resources("inAppPurchases"); len(created) != 2 {
+		t.Errorf("got %d in-app purchases, want the new one created", len(created))
+	}
+}
@@ -1,16 +1,86 @@
 package appstore
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
-	"math/big"
+	"slices"
+	"strings"
 	"time"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// CertificateType is the kind of a certificate
+type CertificateType string
+
+// Certificate types
+const (
+	CertificateTypeDevelopment              CertificateType = "DEVELOPMENT"
+	CertificateTypeDistribution             CertificateType = "DISTRIBUTION"
+	CertificateTypeIOSDevelopment           CertificateType = "IOS_DEVELOPMENT"
+	CertificateTypeIOSDistribution          CertificateType = "IOS_DISTRIBUTION"
+	CertificateTypeMacAppDevelopment        CertificateType = "MAC_APP_DEVELOPMENT"
+	CertificateTypeMacAppDistribution       CertificateType = "MAC_APP_DISTRIBUTION"
+	CertificateTypeMacInstallerDistribution CertificateType = "MAC_INSTALLER_DISTRIBUTION"
+	CertificateTypeDeveloperIDApplication   CertificateType = "DEVELOPER_ID_APPLICATION"
+	CertificateTypeDeveloperIDApplicationG2 CertificateType = "DEVELOPER_ID_APPLICATION_G2"
+	CertificateTypeDeveloperIDKext          CertificateType = "DEVELOPER_ID_KEXT"
+	CertificateTypeDeveloperIDKextG2        CertificateType = "DEVELOPER_ID_KEXT_G2"
+	CertificateTypePassTypeID               CertificateType = "PASS_TYPE_ID"
+	CertificateTypePassTypeIDWithNFC        CertificateType = "PASS_TYPE_ID_WITH_NFC"
+	CertificateTypeApplePay                 CertificateType = "APPLE_PAY"
+	CertificateTypeApplePayMerchantIdentity CertificateType = "APPLE_PAY_MERCHANT_IDENTITY"
+	CertificateTypeApplePayPSPIdentity      CertificateType = "APPLE_PAY_PSP_IDENTITY"
+	CertificateTypeApplePayRSA              CertificateType = "APPLE_PAY_RSA"
+	CertificateTypeIdentityAccess           CertificateType = "IDENTITY_ACCESS"
 )
 
+// certificateTypes lists every known certificate type
+var certificateTypes = []CertificateType{
+	CertificateTypeDevelopment,
+	CertificateTypeDistribution,
+	CertificateTypeIOSDevelopment,
+	CertificateTypeIOSDistribution,
+	CertificateTypeMacAppDevelopment,
+	CertificateTypeMacAppDistribution,
+	CertificateTypeMacInstallerDistribution,
+	CertificateTypeDeveloperIDApplication,
+	CertificateTypeDeveloperIDApplicationG2,
+	CertificateTypeDeveloperIDKext,
+	CertificateTypeDeveloperIDKextG2,
+	CertificateTypePassTypeID,
+	CertificateTypePassTypeIDWithNFC,
+	CertificateTypeApplePay,
+	CertificateTypeApplePayMerchantIdentity,
+	CertificateTypeApplePayPSPIdentity,
+	CertificateTypeApplePayRSA,
+	CertificateTypeIdentityAccess,
+}
+
+// IsValid reports whether the type is a known certificate type
+func (t CertificateType) IsValid() bool {
+	return slices.Contains(certificateTypes, t)
+}
+
+// Certificate represents a signing certificate
+type Certificate struct {
+	ID              string `json:"-"`
+	Name            string `json:"name"`
+	DisplayName     string `json:"displayName"`
+	CertificateType string `json:"certificateType"`
+	Platform        string `json:"platform"`
+	SerialNumber    string `json:"serialNumber"`
+	ExpirationDate  string `json:"expirationDate"`
+	// CertificateContent is the base64 encoded DER certificate
+	CertificateContent string        `json:"certificateContent"`
+	Links              jsonapi.Links `json:"-"`
+}
+
 // CertificatesAPI handles certificate-related operations
 type CertificatesAPI struct {
 	client *Client
@@ -21,6 +91,11 @@ func NewCertificatesAPI(client *Client) *CertificatesAPI {
 	return &CertificatesAPI{client: client}
 }
 
+// WithContext returns a copy of the API whose requests are sent with ctx
+func (c *CertificatesAPI) WithContext(ctx context.Context) *CertificatesAPI {
+	return NewCertificatesAPI(c.client.WithContext(ctx))
+}
+
 // All retrieves all certificates
 func (c *CertificatesAPI) All(params map[string]string) (map[string]interface{}, error) {
 	if err := c.client.EnsureAuth(); err != nil {
@@ -29,6 +104,64 @@ func (c *CertificatesAPI) All(params map[string]string) (map[string]interface{},
 	return c.client.GetHTTPClient().Get("/certificates", params)
 }
 
+// AllPages retrieves the certificates of every page in a single response, following pagination
+func (c *CertificatesAPI) AllPages(params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().GetAllPages("/certificates", params)
+}
+
+// List retrieves every certificate matching params, following pagination
+func (c *CertificatesAPI) List(params map[string]string) ([]Certificate, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	resources, err := c.client.listResources("/certificates", params)
+	if err != nil {
+		return nil, err
+	}
+	certificates := make([]Certificate, len(resources))
+	for i, resource := range resources {
+		if err := jsonapi.Unmarshal(resource, &certificates[i]); err != nil {
+			return nil, err
+		}
+	}
+	return certificates, nil
+}
+
+// ListPage retrieves a single page of certificates matching params with its paging
+// metadata. Pass PageInfo.NextParams as params to request the next page.
+func (c *CertificatesAPI) ListPage(params map[string]string) ([]Certificate, jsonapi.PageInfo, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, jsonapi.PageInfo{}, err
+	}
+	resources, info, err := c.client.listPage("/certificates", params)
+	if err != nil {
+		return nil, jsonapi.PageInfo{}, err
+	}
+	certificates, err := unmarshalResources[Certificate](resources)
+	return certificates, info, err
+}
+
+// Get retrieves a certificate by ID
+func (c *CertificatesAPI) Get(id string) (Certificate, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return Certificate{}, err
+	}
+	response, err := c.client.GetHTTPClient().Get("/certificates/"+id, nil)
+	if err != nil {
+		return Certificate{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return Certificate{}, err
+	}
+	var certificate Certificate
+	err = jsonapi.Unmarshal(resource, &certificate)
+	return certificate, err
+}
+
 // Delete deletes a certificate by ID
 func (c *CertificatesAPI) Delete(id string) (map[string]interface{}, error) {
 	if err := c.client.EnsureAuth(); err != nil {
@@ -92,7 +225,7 @@ func (c *CertificatesAPI) Create() (map[string]interface{}, error) {
 		"data": map[string]interface{}{
 			"type": "certificates",
 			"attributes": map[string]string{
-				"certificateType": "IOS_DISTRIBUTION",
+				"certificateType": string(CertificateTypeIOSDistribution),
 				"csrContent":      csrContent,
 			},
 		},
@@ -101,6 +234,35 @@ func (c *CertificatesAPI) Create() (map[string]interface{}, error) {
 	return c.client.GetHTTPClient().PostJSON("/certificates", data)
 }
 
+// CreateFromCSR creates a certificate of the given type, such as
+// IOS_DISTRIBUTION or DEVELOPMENT, from a PEM or base64 encoded CSR
+func (c *CertificatesAPI) CreateFromCSR(certificateType CertificateType, csrContent string) (map[string]interface{}, error) {
+	if certificateType == "" {
+		return nil, fmt.Errorf("certificate type is required")
+	}
+	if !certificateType.IsValid() {
+		return nil, fmt.Errorf("invalid certificate type: %s", certificateType)
+	}
+	if csrContent == "" {
+		return nil, fmt.Errorf("csr content is required")
+	}
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "certificates",
+			"attributes": map[string]string{
+				"certificateType": string(certificateType),
+				"csrContent":      strings.TrimSpace(pemHeadersToContent(strings.TrimSpace(csrContent))),
+			},
+		},
+	}
+
+	return c.client.GetHTTPClient().PostJSON("/certificates", data)
+}
+
 // pemHeadersToContent removes PEM headers and footers from a PEM string
 func pemHeadersToContent(pemString string) string {
 	content := pemString
@@ -0,0 +1,132 @@
+package appstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// CiArtifact represents a file produced by an Xcode Cloud build action, such as an archive or log bundle
+type CiArtifact struct {
+	ID          string `json:"-"`
+	FileType    string `json:"fileType"`
+	FileName    string `json:"fileName"`
+	FileSize    int64  `json:"fileSize"`
+	DownloadURL string `json:"downloadUrl"`
+}
+
+// CiArtifactsAPI handles Xcode Cloud artifact operations
+type CiArtifactsAPI struct {
+	client *Client
+}
+
+// NewCiArtifactsAPI creates a new CiArtifacts API client
+func NewCiArtifactsAPI(client *Client) *CiArtifactsAPI {
+	return &CiArtifactsAPI{client: client}
+}
+
+// All retrieves the artifacts of a build action
+func (c *CiArtifactsAPI) All(buildActionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciBuildActions/"+buildActionId+"/artifacts", params)
+}
+
+// List retrieves every artifact of a build action, following pagination
+func (c *CiArtifactsAPI) List(buildActionId string) ([]CiArtifact, error) {
+	var artifacts []CiArtifact
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := c.All(buildActionId, query)
+		if err != nil {
+			return artifacts, err
+		}
+		for _, resource := range resourceList(response) {
+			artifact, err := parseCiArtifact(resource)
+			if err != nil {
+				return artifacts, err
+			}
+			artifacts = append(artifacts, artifact)
+		}
+		query = nextPageParams(response)
+	}
+	return artifacts, nil
+}
+
+// Get retrieves an artifact by ID, including a freshly signed download URL
+func (c *CiArtifactsAPI) Get(artifactId string) (CiArtifact, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return CiArtifact{}, err
+	}
+	response, err := c.client.GetHTTPClient().Get("/ciArtifacts/"+artifactId, nil)
+	if err != nil {
+		return CiArtifact{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return CiArtifact{}, err
+	}
+	return parseCiArtifact(resource)
+}
+
+// Download downloads the content of an artifact from its signed URL. The
+// artifact is fetched again first because signed URLs expire.
+func (c *CiArtifactsAPI) Download(artifactId string) ([]byte, error) {
+	artifact, err := c.Get(artifactId)
+	if err != nil {
+		return nil, err
+	}
+	if artifact.DownloadURL == "" {
+		return nil, fmt.Errorf("artifact %s has no download url", artifactId)
+	}
+	return c.client.GetHTTPClient().DownloadURL(artifact.DownloadURL)
+}
+
+// DownloadTo downloads an artifact to path with parallel range requests,
+// resuming an interrupted download of the same path
+func (c *CiArtifactsAPI) DownloadTo(ctx context.Context, artifactId, path string, options httpclient.DownloadOptions) error {
+	artifact, err := c.Get(artifactId)
+	if err != nil {
+		return err
+	}
+	if artifact.DownloadURL == "" {
+		return fmt.Errorf("artifact %s has no download url", artifactId)
+	}
+	return c.client.GetHTTPClient().DownloadFile(ctx, artifact.DownloadURL, path, options)
+}
+
+// DownloadAll downloads every artifact of a build action into dir, named
+// after their file names, and returns the written paths
+func (c *CiArtifactsAPI) DownloadAll(buildActionId, dir string) ([]string, error) {
+	artifacts, err := c.List(buildActionId)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	var paths []string
+	for _, artifact := range artifacts {
+		path := filepath.Join(dir, filepath.Base(artifact.FileName))
+		if err := c.DownloadTo(context.Background(), artifact.ID, path, httpclient.DownloadOptions{}); err != nil {
+			return paths, fmt.Errorf("failed to download %s: %w", artifact.FileName, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// parseCiArtifact converts a ciArtifacts resource object to a CiArtifact
+func parseCiArtifact(resource map[string]interface{}) (CiArtifact, error) {
+	var artifact CiArtifact
+	if err := decodeAttributes(resource, &artifact); err != nil {
+		return CiArtifact{}, err
+	}
+	artifact.ID = resourceID(resource)
+	return artifact, nil
+}
@@ -0,0 +1,235 @@
+package appstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const defaultCiBuildRunPollInterval = 30 * time.Second
+
+// CiExecutionProgress represents the progress of an Xcode Cloud build run
+type CiExecutionProgress string
+
+// Xcode Cloud execution progress values
+const (
+	CiExecutionProgressPending  CiExecutionProgress = "PENDING"
+	CiExecutionProgressRunning  CiExecutionProgress = "RUNNING"
+	CiExecutionProgressComplete CiExecutionProgress = "COMPLETE"
+)
+
+// CiCompletionStatus represents the outcome of a completed Xcode Cloud build run
+type CiCompletionStatus string
+
+// Xcode Cloud completion statuses
+const (
+	CiCompletionStatusSucceeded CiCompletionStatus = "SUCCEEDED"
+	CiCompletionStatusFailed    CiCompletionStatus = "FAILED"
+	CiCompletionStatusErrored   CiCompletionStatus = "ERRORED"
+	CiCompletionStatusCanceled  CiCompletionStatus = "CANCELED"
+	CiCompletionStatusSkipped   CiCompletionStatus = "SKIPPED"
+)
+
+// CiIssueCounts holds the number of issues found by a build run
+type CiIssueCounts struct {
+	AnalyzerWarnings int `json:"analyzerWarnings"`
+	Errors           int `json:"errors"`
+	TestFailures     int `json:"testFailures"`
+	Warnings         int `json:"warnings"`
+}
+
+// CiBuildRun represents an Xcode Cloud build run
+type CiBuildRun struct {
+	ID                 string              `json:"-"`
+	Number             int                 `json:"number"`
+	CreatedDate        string              `json:"createdDate"`
+	StartedDate        string              `json:"startedDate"`
+	FinishedDate       string              `json:"finishedDate"`
+	ExecutionProgress  CiExecutionProgress `json:"executionProgress"`
+	CompletionStatus   CiCompletionStatus  `json:"completionStatus"`
+	StartReason        string              `json:"startReason"`
+	CancelReason       string              `json:"cancelReason"`
+	IsPullRequestBuild bool                `json:"isPullRequestBuild"`
+	IssueCounts        CiIssueCounts       `json:"issueCounts"`
+}
+
+// Complete reports whether the build run has finished
+func (r CiBuildRun) Complete() bool {
+	return r.ExecutionProgress == CiExecutionProgressComplete
+}
+
+// Succeeded reports whether the build run finished successfully
+func (r CiBuildRun) Succeeded() bool {
+	return r.Complete() && r.CompletionStatus == CiCompletionStatusSucceeded
+}
+
+// CiBuildRunsAPI handles Xcode Cloud build run operations. The App Store
+// Connect API offers no way to cancel a running build, which can only be
+// done from Xcode or App Store Connect.
+type CiBuildRunsAPI struct {
+	client *Client
+}
+
+// NewCiBuildRunsAPI creates a new CiBuildRuns API client
+func NewCiBuildRunsAPI(client *Client) *CiBuildRunsAPI {
+	return &CiBuildRunsAPI{client: client}
+}
+
+// All retrieves the build runs of a workflow
+func (c *CiBuildRunsAPI) All(workflowId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciWorkflows/"+workflowId+"/buildRuns", params)
+}
+
+// Get retrieves a build run by ID
+func (c *CiBuildRunsAPI) Get(buildRunId string) (CiBuildRun, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return CiBuildRun{}, err
+	}
+	response, err := c.client.GetHTTPClient().Get("/ciBuildRuns/"+buildRunId, nil)
+	if err != nil {
+		return CiBuildRun{}, err
+	}
+	return parseCiBuildRunResponse(response)
+}
+
+// Actions retrieves the actions of a build run, such as build, test, and archive
+func (c *CiBuildRunsAPI) Actions(buildRunId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciBuildRuns/"+buildRunId+"/actions", params)
+}
+
+// Builds retrieves the App Store Connect builds produced by a build run
+func (c *CiBuildRunsAPI) Builds(buildRunId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciBuildRuns/"+buildRunId+"/builds", params)
+}
+
+// Start starts a build run of a workflow for a branch or tag, identified by
+// its git reference ID. A clean build discards the derived data cache.
+func (c *CiBuildRunsAPI) Start(workflowId, gitReferenceId string, clean bool) (CiBuildRun, error) {
+	if workflowId == "" {
+		return CiBuildRun{}, fmt.Errorf("workflow id is required")
+	}
+	if gitReferenceId == "" {
+		return CiBuildRun{}, fmt.Errorf("git reference id is required")
+	}
+	return c.start(clean, map[string]interface{}{
+		"workflow": map[string]interface{}{
+			"data": map[string]string{
+				"type": "ciWorkflows",
+				"id":   workflowId,
+			},
+		},
+		"sourceBranchOrTag": map[string]interface{}{
+			"data": map[string]string{
+				"type": "scmGitReferences",
+				"id":   gitReferenceId,
+			},
+		},
+	})
+}
+
+// StartForPullRequest starts a build run of a workflow for a pull request
+func (c *CiBuildRunsAPI) StartForPullRequest(workflowId, pullRequestId string, clean bool) (CiBuildRun, error) {
+	if workflowId == "" {
+		return CiBuildRun{}, fmt.Errorf("workflow id is required")
+	}
+	if pullRequestId == "" {
+		return CiBuildRun{}, fmt.Errorf("pull request id is required")
+	}
+	return c.start(clean, map[string]interface{}{
+		"workflow": map[string]interface{}{
+			"data": map[string]string{
+				"type": "ciWorkflows",
+				"id":   workflowId,
+			},
+		},
+		"pullRequest": map[string]interface{}{
+			"data": map[string]string{
+				"type": "scmPullRequests",
+				"id":   pullRequestId,
+			},
+		},
+	})
+}
+
+// Rerun starts a new build run with the same workflow and source as an earlier one
+func (c *CiBuildRunsAPI) Rerun(buildRunId string, clean bool) (CiBuildRun, error) {
+	if buildRunId == "" {
+		return CiBuildRun{}, fmt.Errorf("build run id is required")
+	}
+	return c.start(clean, map[string]interface{}{
+		"buildRun": map[string]interface{}{
+			"data": map[string]string{
+				"type": "ciBuildRuns",
+				"id":   buildRunId,
+			},
+		},
+	})
+}
+
+// start creates a build run with the given relationships
+func (c *CiBuildRunsAPI) start(clean bool, relationships map[string]interface{}) (CiBuildRun, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return CiBuildRun{}, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "ciBuildRuns",
+			"attributes": map[string]bool{
+				"clean": clean,
+			},
+			"relationships": relationships,
+		},
+	}
+
+	response, err := c.client.GetHTTPClient().PostJSON("/ciBuildRuns", data)
+	if err != nil {
+		return CiBuildRun{}, err
+	}
+	return parseCiBuildRunResponse(response)
+}
+
+// WaitForCompletion polls a build run every interval until it completes,
+// calling progress, if set, whenever its execution progress changes. A zero
+// interval polls every 30 seconds.
+func (c *CiBuildRunsAPI) WaitForCompletion(ctx context.Context, buildRunId string, interval time.Duration, progress func(run CiBuildRun)) (CiBuildRun, error) {
+	if interval <= 0 {
+		interval = defaultCiBuildRunPollInterval
+	}
+
+	var last CiExecutionProgress
+	return WaitFor(ctx, WaitOptions{Interval: interval}, func(ctx context.Context) (CiBuildRun, bool, error) {
+		run, err := NewCiBuildRunsAPI(c.client.WithContext(ctx)).Get(buildRunId)
+		if err != nil {
+			return run, false, err
+		}
+		if progress != nil && run.ExecutionProgress != last {
+			progress(run)
+		}
+		last = run.ExecutionProgress
+		return run, run.Complete(), nil
+	})
+}
+
+// parseCiBuildRunResponse converts a single build run response to a CiBuildRun
+func parseCiBuildRunResponse(response map[string]interface{}) (CiBuildRun, error) {
+	resource, err := responseResource(response)
+	if err != nil {
+		return CiBuildRun{}, err
+	}
+	var run CiBuildRun
+	if err := decodeAttributes(resource, &run); err != nil {
+		return CiBuildRun{}, err
+	}
+	run.ID = resourceID(resource)
+	return run, nil
+}
@@ -0,0 +1,69 @@
+package appstore
+
+// CiIssueType represents the kind of an Xcode Cloud issue
+type CiIssueType string
+
+// Xcode Cloud issue types
+const (
+	CiIssueTypeAnalyzerWarning CiIssueType = "ANALYZER_WARNING"
+	CiIssueTypeError           CiIssueType = "ERROR"
+	CiIssueTypeTestFailure     CiIssueType = "TEST_FAILURE"
+	CiIssueTypeWarning         CiIssueType = "WARNING"
+)
+
+// CiIssue represents an error, warning, or test failure reported by an Xcode Cloud build action
+type CiIssue struct {
+	ID         string         `json:"-"`
+	IssueType  CiIssueType    `json:"issueType"`
+	Message    string         `json:"message"`
+	Category   string         `json:"category"`
+	FileSource CiFileLocation `json:"fileSource"`
+}
+
+// CiIssuesAPI handles Xcode Cloud issue operations
+type CiIssuesAPI struct {
+	client *Client
+}
+
+// NewCiIssuesAPI creates a new CiIssues API client
+func NewCiIssuesAPI(client *Client) *CiIssuesAPI {
+	return &CiIssuesAPI{client: client}
+}
+
+// All retrieves the issues of a build action
+func (c *CiIssuesAPI) All(buildActionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciBuildActions/"+buildActionId+"/issues", params)
+}
+
+// List retrieves every issue of a build action, following pagination
+func (c *CiIssuesAPI) List(buildActionId string) ([]CiIssue, error) {
+	var issues []CiIssue
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := c.All(buildActionId, query)
+		if err != nil {
+			return issues, err
+		}
+		for _, resource := range resourceList(response) {
+			var issue CiIssue
+			if err := decodeAttributes(resource, &issue); err != nil {
+				return issues, err
+			}
+			issue.ID = resourceID(resource)
+			issues = append(issues, issue)
+		}
+		query = nextPageParams(response)
+	}
+	return issues, nil
+}
+
+// Get retrieves an issue by ID
+func (c *CiIssuesAPI) Get(issueId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciIssues/"+issueId, params)
+}
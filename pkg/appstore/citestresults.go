@@ -0,0 +1,102 @@
+package appstore
+
+// CiTestStatus represents the outcome of an Xcode Cloud test
+type CiTestStatus string
+
+// Xcode Cloud test statuses
+const (
+	CiTestStatusSuccess         CiTestStatus = "SUCCESS"
+	CiTestStatusFailure         CiTestStatus = "FAILURE"
+	CiTestStatusMixed           CiTestStatus = "MIXED"
+	CiTestStatusSkipped         CiTestStatus = "SKIPPED"
+	CiTestStatusExpectedFailure CiTestStatus = "EXPECTED_FAILURE"
+)
+
+// CiFileLocation is the source location of a test result or issue
+type CiFileLocation struct {
+	Path       string `json:"path"`
+	LineNumber int    `json:"lineNumber"`
+}
+
+// CiTestDestinationResult is the outcome of a test on one destination device
+type CiTestDestinationResult struct {
+	UUID       string       `json:"uuid"`
+	DeviceName string       `json:"deviceName"`
+	OSVersion  string       `json:"osVersion"`
+	Status     CiTestStatus `json:"status"`
+	Duration   float64      `json:"duration"`
+}
+
+// CiTestResult represents the result of a test run by an Xcode Cloud build action
+type CiTestResult struct {
+	ID                     string                    `json:"-"`
+	ClassName              string                    `json:"className"`
+	Name                   string                    `json:"name"`
+	Status                 CiTestStatus              `json:"status"`
+	Message                string                    `json:"message"`
+	FileSource             CiFileLocation            `json:"fileSource"`
+	DestinationTestResults []CiTestDestinationResult `json:"destinationTestResults"`
+}
+
+// CiTestResultsAPI handles Xcode Cloud test result operations
+type CiTestResultsAPI struct {
+	client *Client
+}
+
+// NewCiTestResultsAPI creates a new CiTestResults API client
+func NewCiTestResultsAPI(client *Client) *CiTestResultsAPI {
+	return &CiTestResultsAPI{client: client}
+}
+
+// All retrieves the test results of a build action
+func (c *CiTestResultsAPI) All(buildActionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciBuildActions/"+buildActionId+"/testResults", params)
+}
+
+// List retrieves every test result of a build action, following pagination
+func (c *CiTestResultsAPI) List(buildActionId string) ([]CiTestResult, error) {
+	var results []CiTestResult
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := c.All(buildActionId, query)
+		if err != nil {
+			return results, err
+		}
+		for _, resource := range resourceList(response) {
+			var result CiTestResult
+			if err := decodeAttributes(resource, &result); err != nil {
+				return results, err
+			}
+			result.ID = resourceID(resource)
+			results = append(results, result)
+		}
+		query = nextPageParams(response)
+	}
+	return results, nil
+}
+
+// Failures retrieves the failed test results of a build action
+func (c *CiTestResultsAPI) Failures(buildActionId string) ([]CiTestResult, error) {
+	results, err := c.List(buildActionId)
+	if err != nil {
+		return nil, err
+	}
+	var failures []CiTestResult
+	for _, result := range results {
+		if result.Status == CiTestStatusFailure || result.Status == CiTestStatusMixed {
+			failures = append(failures, result)
+		}
+	}
+	return failures, nil
+}
+
+// Get retrieves a test result by ID
+func (c *CiTestResultsAPI) Get(testResultId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciTestResults/"+testResultId, params)
+}
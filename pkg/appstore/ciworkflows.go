@@ -0,0 +1,311 @@
+package appstore
+
+import "fmt"
+
+// CiActionType represents the kind of an Xcode Cloud workflow action
+type CiActionType string
+
+// Xcode Cloud action types
+const (
+	CiActionTypeBuild   CiActionType = "BUILD"
+	CiActionTypeAnalyze CiActionType = "ANALYZE"
+	CiActionTypeTest    CiActionType = "TEST"
+	CiActionTypeArchive CiActionType = "ARCHIVE"
+)
+
+// CiPlatform represents the platform an Xcode Cloud action builds for
+type CiPlatform string
+
+// Xcode Cloud platforms
+const (
+	CiPlatformIOS      CiPlatform = "IOS"
+	CiPlatformMacOS    CiPlatform = "MACOS"
+	CiPlatformTvOS     CiPlatform = "TVOS"
+	CiPlatformWatchOS  CiPlatform = "WATCHOS"
+	CiPlatformVisionOS CiPlatform = "VISIONOS"
+)
+
+// CiStartConditionPattern matches branch or tag names, exactly or by prefix
+type CiStartConditionPattern struct {
+	Pattern  string `json:"pattern"`
+	IsPrefix bool   `json:"isPrefix"`
+}
+
+// CiBranchPatterns selects branches or tags, either all of them or those matching patterns
+type CiBranchPatterns struct {
+	IsAllMatch bool                      `json:"isAllMatch"`
+	Patterns   []CiStartConditionPattern `json:"patterns,omitempty"`
+}
+
+// CiFilesAndFoldersMatcher matches changed files by directory, extension, or name
+type CiFilesAndFoldersMatcher struct {
+	Directory     string `json:"directory,omitempty"`
+	FileExtension string `json:"fileExtension,omitempty"`
+	FileName      string `json:"fileName,omitempty"`
+}
+
+// CiFilesAndFoldersRule starts or skips builds depending on the changed files
+type CiFilesAndFoldersRule struct {
+	// Mode is START_IF_ANY_FILE_MATCHES or DO_NOT_START_IF_ALL_FILES_MATCH
+	Mode     string                     `json:"mode"`
+	Matchers []CiFilesAndFoldersMatcher `json:"matchers"`
+}
+
+// CiBranchStartCondition starts builds on changes to branches or tags
+type CiBranchStartCondition struct {
+	Source              CiBranchPatterns       `json:"source"`
+	FilesAndFoldersRule *CiFilesAndFoldersRule `json:"filesAndFoldersRule,omitempty"`
+	AutoCancel          bool                   `json:"autoCancel"`
+}
+
+// CiPullRequestStartCondition starts builds on changes to pull requests
+type CiPullRequestStartCondition struct {
+	Source              CiBranchPatterns       `json:"source"`
+	Destination         CiBranchPatterns       `json:"destination"`
+	FilesAndFoldersRule *CiFilesAndFoldersRule `json:"filesAndFoldersRule,omitempty"`
+	AutoCancel          bool                   `json:"autoCancel"`
+}
+
+// CiSchedule describes when scheduled builds start
+type CiSchedule struct {
+	// Frequency is WEEKLY, DAILY, or HOURLY
+	Frequency string   `json:"frequency"`
+	Days      []string `json:"days,omitempty"`
+	Hour      int      `json:"hour"`
+	Minute    int      `json:"minute"`
+	Timezone  string   `json:"timezone,omitempty"`
+}
+
+// CiScheduledStartCondition starts builds of branches on a schedule
+type CiScheduledStartCondition struct {
+	Source   CiBranchPatterns `json:"source"`
+	Schedule CiSchedule       `json:"schedule"`
+}
+
+// CiTestDestination is a simulator or device a test action runs on
+type CiTestDestination struct {
+	DeviceTypeName       string `json:"deviceTypeName"`
+	DeviceTypeIdentifier string `json:"deviceTypeIdentifier"`
+	RuntimeName          string `json:"runtimeName"`
+	RuntimeIdentifier    string `json:"runtimeIdentifier"`
+	// Kind is SIMULATOR or MAC
+	Kind string `json:"kind"`
+}
+
+// CiTestConfiguration selects the test plan and destinations of a test action
+type CiTestConfiguration struct {
+	// Kind is USE_SCHEME_SETTINGS or SPECIFIC_TEST_PLANS
+	Kind             string              `json:"kind"`
+	TestPlanName     string              `json:"testPlanName,omitempty"`
+	TestDestinations []CiTestDestination `json:"testDestinations,omitempty"`
+}
+
+// CiAction is a build, analyze, test, or archive action of a workflow
+type CiAction struct {
+	Name       string       `json:"name"`
+	ActionType CiActionType `json:"actionType"`
+	Scheme     string       `json:"scheme"`
+	Platform   CiPlatform   `json:"platform"`
+	// Destination is e.g. ANY_IOS_DEVICE or ANY_MAC
+	Destination string `json:"destination,omitempty"`
+	// BuildDistributionAudience is INTERNAL_ONLY or APP_STORE_ELIGIBLE for archive actions
+	BuildDistributionAudience string               `json:"buildDistributionAudience,omitempty"`
+	TestConfiguration         *CiTestConfiguration `json:"testConfiguration,omitempty"`
+	IsRequiredToPass          bool                 `json:"isRequiredToPass"`
+}
+
+// CiWorkflowAttributes holds the attributes of a workflow, empty start conditions are not set
+type CiWorkflowAttributes struct {
+	Name                      string                       `json:"name,omitempty"`
+	Description               string                       `json:"description,omitempty"`
+	BranchStartCondition      *CiBranchStartCondition      `json:"branchStartCondition,omitempty"`
+	TagStartCondition         *CiBranchStartCondition      `json:"tagStartCondition,omitempty"`
+	PullRequestStartCondition *CiPullRequestStartCondition `json:"pullRequestStartCondition,omitempty"`
+	ScheduledStartCondition   *CiScheduledStartCondition   `json:"scheduledStartCondition,omitempty"`
+	Actions                   []CiAction                   `json:"actions,omitempty"`
+	IsEnabled                 bool                         `json:"isEnabled"`
+	IsLockedForEditing        bool                         `json:"isLockedForEditing"`
+	Clean                     bool                         `json:"clean"`
+	ContainerFilePath         string                       `json:"containerFilePath,omitempty"`
+}
+
+// CiWorkflowEnvironment selects the Xcode and macOS versions a workflow runs on
+type CiWorkflowEnvironment struct {
+	XcodeVersionID string
+	MacOsVersionID string
+}
+
+// relationships builds the relationship data of the environment, leaving out unset versions
+func (e CiWorkflowEnvironment) relationships() map[string]interface{} {
+	relationships := make(map[string]interface{})
+	if e.XcodeVersionID != "" {
+		relationships["xcodeVersion"] = map[string]interface{}{
+			"data": map[string]string{
+				"type": "ciXcodeVersions",
+				"id":   e.XcodeVersionID,
+			},
+		}
+	}
+	if e.MacOsVersionID != "" {
+		relationships["macOsVersion"] = map[string]interface{}{
+			"data": map[string]string{
+				"type": "ciMacOsVersions",
+				"id":   e.MacOsVersionID,
+			},
+		}
+	}
+	return relationships
+}
+
+// StandardCiWorkflow returns the attributes of a workflow that tests a
+// scheme on every change to branch and archives it for App Store distribution
+func StandardCiWorkflow(name, scheme string, platform CiPlatform, branch string) CiWorkflowAttributes {
+	return CiWorkflowAttributes{
+		Name:        name,
+		Description: "Tests and archives " + scheme + " on changes to " + branch,
+		BranchStartCondition: &CiBranchStartCondition{
+			Source: CiBranchPatterns{
+				Patterns: []CiStartConditionPattern{{Pattern: branch}},
+			},
+			AutoCancel: true,
+		},
+		Actions: []CiAction{
+			{
+				Name:              "Test - " + scheme,
+				ActionType:        CiActionTypeTest,
+				Scheme:            scheme,
+				Platform:          platform,
+				TestConfiguration: &CiTestConfiguration{Kind: "USE_SCHEME_SETTINGS"},
+				IsRequiredToPass:  true,
+			},
+			{
+				Name:                      "Archive - " + scheme,
+				ActionType:                CiActionTypeArchive,
+				Scheme:                    scheme,
+				Platform:                  platform,
+				BuildDistributionAudience: "APP_STORE_ELIGIBLE",
+				IsRequiredToPass:          true,
+			},
+		},
+		IsEnabled: true,
+	}
+}
+
+// CiWorkflowsAPI handles Xcode Cloud product and workflow operations
+type CiWorkflowsAPI struct {
+	client *Client
+}
+
+// NewCiWorkflowsAPI creates a new CiWorkflows API client
+func NewCiWorkflowsAPI(client *Client) *CiWorkflowsAPI {
+	return &CiWorkflowsAPI{client: client}
+}
+
+// Products retrieves the Xcode Cloud products, one per app or framework
+func (c *CiWorkflowsAPI) Products(params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciProducts", params)
+}
+
+// XcodeVersions retrieves the Xcode versions available to workflows
+func (c *CiWorkflowsAPI) XcodeVersions(params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciXcodeVersions", params)
+}
+
+// MacOsVersions retrieves the macOS versions available to workflows
+func (c *CiWorkflowsAPI) MacOsVersions(params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciMacOsVersions", params)
+}
+
+// All retrieves the workflows of a product
+func (c *CiWorkflowsAPI) All(productId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciProducts/"+productId+"/workflows", params)
+}
+
+// Get retrieves a workflow by ID
+func (c *CiWorkflowsAPI) Get(workflowId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/ciWorkflows/"+workflowId, params)
+}
+
+// Create creates a workflow for a product building from a repository
+func (c *CiWorkflowsAPI) Create(productId, repositoryId string, environment CiWorkflowEnvironment, attributes CiWorkflowAttributes) (map[string]interface{}, error) {
+	if attributes.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if len(attributes.Actions) == 0 {
+		return nil, fmt.Errorf("at least one action is required")
+	}
+	if environment.XcodeVersionID == "" || environment.MacOsVersionID == "" {
+		return nil, fmt.Errorf("xcode version and macos version are required")
+	}
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	relationships := environment.relationships()
+	relationships["product"] = map[string]interface{}{
+		"data": map[string]string{
+			"type": "ciProducts",
+			"id":   productId,
+		},
+	}
+	relationships["repository"] = map[string]interface{}{
+		"data": map[string]string{
+			"type": "scmRepositories",
+			"id":   repositoryId,
+		},
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":          "ciWorkflows",
+			"attributes":    attributes,
+			"relationships": relationships,
+		},
+	}
+
+	return c.client.GetHTTPClient().PostJSON("/ciWorkflows", data)
+}
+
+// Update replaces the attributes of a workflow and, when set, its environment
+func (c *CiWorkflowsAPI) Update(workflowId string, environment CiWorkflowEnvironment, attributes CiWorkflowAttributes) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	workflow := map[string]interface{}{
+		"type":       "ciWorkflows",
+		"id":         workflowId,
+		"attributes": attributes,
+	}
+	if relationships := environment.relationships(); len(relationships) > 0 {
+		workflow["relationships"] = relationships
+	}
+	data := map[string]interface{}{
+		"data": workflow,
+	}
+
+	return c.client.GetHTTPClient().PatchJSON("/ciWorkflows/"+workflowId, data)
+}
+
+// Delete deletes a workflow by ID
+func (c *CiWorkflowsAPI) Delete(workflowId string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Delete("/ciWorkflows/"+workflowId, nil)
+}
@@ -1,44 +1,154 @@
 package appstore
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
 
 	"appstore-connect-api/pkg/httpclient"
 	"appstore-connect-api/pkg/jwtutil"
 )
 
 const (
-	baseURI    = "https://api.appstoreconnect.apple.com"
+	baseURI           = "https://api.appstoreconnect.apple.com"
+	enterpriseBaseURI = "https://api.enterprise.developer.apple.com"
 	defaultAPIVersion = "v1"
 )
 
+// ProgramType is the Apple Developer Program of the account of an API key
+type ProgramType string
+
+const (
+	// ProgramStandard is the Apple Developer Program, served by the App Store Connect API
+	ProgramStandard ProgramType = "standard"
+	// ProgramEnterprise is the Apple Developer Enterprise Program for in-house
+	// distribution, served by the Enterprise Program API
+	ProgramEnterprise ProgramType = "enterprise"
+)
+
+// enterpriseAudience is the "aud" claim of Enterprise Program API tokens
+const enterpriseAudience = "apple-developer-enterprise-v1"
+
 // Config holds the client configuration
 type Config struct {
-	Issuer    string
-	KeyID     string
-	Secret    string // Can be a file path or the private key content
+	Issuer     string // Not used by individual keys
+	KeyID      string
+	Secret     string // Can be a file path, the private key content, or a secret reference, see SecretResolver
 	APIVersion string
+	// KeyType is the kind of key, jwtutil.KeyTypeTeam when empty. Individual
+	// keys of a user have no issuer ID.
+	KeyType jwtutil.KeyType
+	// TokenProvider optionally supplies the tokens of the primary key instead
+	// of signing them with Secret, which is then not required, nor are Issuer
+	// and KeyID. See TokenProvider.
+	TokenProvider TokenProvider
+	// Scope restricts tokens to the listed requests, such as "GET /v1/apps",
+	// for least-privilege tokens, see jwtutil.JWTConfig.Scope. It applies to
+	// the fallback key as well.
+	Scope []string
+	// ProgramType selects the API of the account, ProgramStandard when empty.
+	// The Enterprise Program API has the same resources, so the device,
+	// certificate, bundle ID, and profile APIs work unchanged.
+	ProgramType ProgramType
+	// BaseURL overrides the API host of the program type, such as
+	// "https://api.enterprise.developer.apple.com"
+	BaseURL string
+	// SecretResolvers resolve Secret references by scheme, such as
+	// "vault" for "vault://asc/api-key", in addition to the
+	// DefaultSecretResolvers, which they override
+	SecretResolvers map[string]SecretResolver
+	// Fallback is a secondary key used when App Store Connect rejects the
+	// current key, so keys can be rotated without synchronized deploys
+	Fallback *KeyConfig
+	// KeyChanged is called with the key ID the client switches to after a
+	// key has been rejected
+	KeyChanged func(keyID string)
+	// Cache optionally caches GET responses, see httpclient.CacheConfig
+	Cache *httpclient.CacheConfig
+	// DefaultLimits sets the page size of list requests without a limit by
+	// the last path segment, such as {"devices": 200}, see
+	// httpclient.Config.DefaultLimits
+	DefaultLimits map[string]int
+	// Retry optionally retries throttled and failed requests, see httpclient.RetryConfig
+	Retry *httpclient.RetryConfig
+	// HTTPClient optionally replaces the HTTP client that sends requests,
+	// for example to use a proxy, pin certificates, or tune connection pooling
+	HTTPClient *http.Client
+	// Transport optionally sends requests through a proxy or with custom TLS
+	// settings, see httpclient.TransportConfig. It is ignored when
+	// HTTPClient is set.
+	Transport *httpclient.TransportConfig
+	// UserAgent is the User-Agent header of every request, such as
+	// "release-bot/2.3", so Apple-side request logs identify the tool
+	UserAgent string
+	// Headers are default headers of every request, for example to route
+	// requests through an internal gateway
+	Headers map[string]string
+	// Observer is optionally notified of every request, to emit traces and
+	// metrics, see httpclient.Observer
+	Observer httpclient.Observer
+	// Breaker optionally stops sending requests during App Store Connect
+	// outages, see httpclient.BreakerConfig
+	Breaker *httpclient.BreakerConfig
+	// DryRun logs write requests to DryRunOutput, os.Stderr by default,
+	// instead of sending them and answers them with a synthetic success, to
+	// preview provisioning changes
+	DryRun       bool
+	DryRunOutput io.Writer
+	// Logger optionally logs retries, rate limit waits, token renewals, key
+	// switches, and failed requests, such as
+	// httpclient.NewSlogLogger(slog.Default())
+	Logger httpclient.Logger
+}
+
+// KeyConfig identifies an API key
+type KeyConfig struct {
+	Issuer string
+	KeyID  string
+	Secret string // Can be a file path, the private key content, or a secret reference
+	// KeyType is the kind of key, see Config.KeyType
+	KeyType jwtutil.KeyType
 }
 
 // Client represents the App Store Connect API client
 type Client struct {
 	config     Config
 	httpClient *httpclient.Client
-	jwtGenerator *jwtutil.Generator
+	// keys holds the primary key and the optional fallback, the active key signs requests
+	keys   []clientKey
+	active *activeKey
+}
+
+// activeKey is the index of the key that signs requests, shared by copies of a client
+type activeKey struct {
+	mu    sync.Mutex
+	index int
+}
+
+// clientKey is an API key with its cached tokens
+type clientKey struct {
+	issuer string
+	keyID  string
+	tokens TokenProvider
 }
 
 // NewClient creates a new App Store Connect API client
 func NewClient(config Config) (*Client, error) {
 	// Validate required fields
-	if config.Issuer == "" {
-		return nil, fmt.Errorf("issuer is required")
-	}
-	if config.KeyID == "" {
-		return nil, fmt.Errorf("key id is required")
-	}
-	if config.Secret == "" {
-		return nil, fmt.Errorf("secret is required")
+	if config.TokenProvider == nil {
+		if config.Issuer == "" && config.KeyType != jwtutil.KeyTypeIndividual {
+			return nil, fmt.Errorf("issuer is required")
+		}
+		if config.KeyID == "" {
+			return nil, fmt.Errorf("key id is required")
+		}
+		if config.Secret == "" {
+			return nil, fmt.Errorf("secret is required")
+		}
 	}
 
 	// Set default API version
@@ -46,61 +156,222 @@ func NewClient(config Config) (*Client, error) {
 		config.APIVersion = defaultAPIVersion
 	}
 
-	// Read secret from file if it's a file path
-	privateKey := config.Secret
-	if _, err := os.Stat(config.Secret); err == nil {
-		content, err := os.ReadFile(config.Secret)
+	// Resolve the API host and token audience of the program
+	baseURL, audience, err := programEndpoint(config.ProgramType)
+	if err != nil {
+		return nil, err
+	}
+	if config.BaseURL != "" {
+		baseURL = strings.TrimSuffix(config.BaseURL, "/")
+	}
+
+	// Create JWT generators for the primary and fallback keys
+	primary := clientKey{issuer: config.Issuer, keyID: config.KeyID, tokens: config.TokenProvider}
+	if config.TokenProvider == nil {
+		primary, err = newClientKey(KeyConfig{Issuer: config.Issuer, KeyID: config.KeyID, Secret: config.Secret, KeyType: config.KeyType}, audience, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+	keys := []clientKey{primary}
+	if config.Fallback != nil {
+		fallbackKey := *config.Fallback
+		if fallbackKey.Issuer == "" && fallbackKey.KeyType == "" {
+			fallbackKey.Issuer, fallbackKey.KeyType = config.Issuer, config.KeyType
+		}
+		fallback, err := newClientKey(fallbackKey, audience, config)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read secret file: %w", err)
+			return nil, fmt.Errorf("fallback key: %w", err)
 		}
-		privateKey = string(content)
+		keys = append(keys, fallback)
+	}
+
+	// Create HTTP client
+	httpClient := httpclient.NewClient(httpclient.Config{
+		BaseURL:       baseURL,
+		APIVersion:    config.APIVersion,
+		Cache:         config.Cache,
+		DefaultLimits: config.DefaultLimits,
+		Retry:         config.Retry,
+		HTTPClient:    config.HTTPClient,
+		Transport:     config.Transport,
+		UserAgent:     config.UserAgent,
+		Headers:       config.Headers,
+		Observer:      config.Observer,
+		Breaker:       config.Breaker,
+		DryRun:        config.DryRun,
+		DryRunOutput:  config.DryRunOutput,
+		Logger:        config.Logger,
+	})
+
+	client := &Client{
+		config:     config,
+		httpClient: httpClient,
+		keys:       keys,
+		active:     &activeKey{},
+	}
+	httpClient.SetUnauthorizedHandler(client.reauthorize)
+	return client, nil
+}
+
+// newClientKey creates the token generator of a key, signing tokens for
+// audience, or the App Store Connect API when it is empty, restricted to the
+// scope of the configuration
+func newClientKey(key KeyConfig, audience string, config Config) (clientKey, error) {
+	// Resolve the secret from a file or secret manager
+	privateKey, err := resolveSecret(key.Secret, config.SecretResolvers)
+	if err != nil {
+		return clientKey{}, err
 	}
 
-	// Create JWT generator
 	jwtGenerator, err := jwtutil.NewGenerator(jwtutil.JWTConfig{
-		Issuer:    config.Issuer,
-		KeyID:     config.KeyID,
+		Issuer:     key.Issuer,
+		KeyID:      key.KeyID,
 		PrivateKey: privateKey,
+		Audience:   audience,
+		KeyType:    key.KeyType,
+		Scope:      config.Scope,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create JWT generator: %w", err)
+		return clientKey{}, fmt.Errorf("failed to create JWT generator: %w", err)
 	}
+	return clientKey{issuer: key.Issuer, keyID: key.KeyID, tokens: jwtutil.NewTokenCache(jwtGenerator)}, nil
+}
 
-	// Create HTTP client
-	httpClient := httpclient.NewClient(httpclient.Config{
-		BaseURL:    baseURI,
-		APIVersion: config.APIVersion,
-	})
+// programEndpoint returns the API host and token audience of a program type
+func programEndpoint(program ProgramType) (string, string, error) {
+	switch program {
+	case "", ProgramStandard:
+		return baseURI, "", nil
+	case ProgramEnterprise:
+		return enterpriseBaseURI, enterpriseAudience, nil
+	default:
+		return "", "", fmt.Errorf("unknown program type %q", program)
+	}
+}
+
+// Do performs an authenticated request against an API path that this
+// package does not wrap yet, such as
+//
+//	resp, err := client.Do("GET", "/appClips/"+clipId, nil, nil)
+//
+// See httpclient.Client.Do for how the body is sent and errors returned.
+func (c *Client) Do(method, path string, body interface{}, params map[string]string) (*http.Response, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(method, path, body, params)
+}
+
+// Download performs an authenticated GET request and streams the response
+// body, decompressing gzip content, see httpclient.Client.Download. The
+// caller must close the body.
+func (c *Client) Download(path string, params map[string]string) (io.ReadCloser, http.Header, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return nil, nil, err
+	}
+	return c.httpClient.Download(path, params)
+}
 
-	return &Client{
-		config:      config,
-		httpClient:  httpClient,
-		jwtGenerator: jwtGenerator,
-	}, nil
+// RateLimit returns the hourly request budget App Store Connect reported
+// with the last response, and false before any response reported one
+func (c *Client) RateLimit() (httpclient.RateLimit, bool) {
+	return c.httpClient.RateLimit()
 }
 
-// GetToken generates and returns a JWT token
+// GetToken returns a JWT token of the current key. Tokens are cached and
+// replaced with a new one shortly before they expire.
 func (c *Client) GetToken() (string, error) {
-	token, err := c.jwtGenerator.GenerateToken()
+	token, err := c.keys[c.activeIndex()].tokens.Token()
 	if err != nil {
 		return "", fmt.Errorf("failed to generate token: %w", err)
 	}
 	return token, nil
 }
 
-// EnsureAuth ensures the client has an auth header with JWT token
+// EnsureAuth ensures the client has an auth header with a JWT token that is
+// not about to expire, so long-running processes keep authenticating
 func (c *Client) EnsureAuth() error {
-	if c.httpClient.GetHeaders()["Authorization"] == "" {
-		token, err := c.GetToken()
-		if err != nil {
-			return err
-		}
+	token, err := c.GetToken()
+	if err != nil {
+		return err
+	}
+	if c.httpClient.GetHeaders()["Authorization"] != "Bearer "+token {
 		c.httpClient.SetToken(token)
+		c.log(slog.LevelDebug, "using new token", "key_id", c.KeyID())
 	}
 	return nil
 }
 
+// KeyID returns the ID of the key that signs requests, which changes to the
+// fallback key after the primary key has been rejected
+func (c *Client) KeyID() string {
+	return c.keys[c.activeIndex()].keyID
+}
+
+// activeIndex returns the index of the key that signs requests
+func (c *Client) activeIndex() int {
+	c.active.mu.Lock()
+	defer c.active.mu.Unlock()
+	return c.active.index
+}
+
+// reauthorize returns the token to send a request rejected with 401 again
+// with: a newly signed token of the current key, which helps when the
+// rejected token expired early, or when that was rejected as well, a token
+// of the fallback key
+func (c *Client) reauthorize(rejected string) (string, bool) {
+	index := c.activeIndex()
+	token, ok, err := c.keys[index].tokens.Renew(rejected)
+	if err != nil {
+		c.log(slog.LevelError, "failed to renew rejected token", "key_id", c.keys[index].keyID, "error", err)
+		return "", false
+	}
+	if ok {
+		return token, true
+	}
+	if len(c.keys) == 1 {
+		return "", false
+	}
+	return c.switchKey(index)
+}
+
+// switchKey signs further requests with the key after keys[index], unless
+// another request switched keys already, and returns the token to retry the
+// rejected request with
+func (c *Client) switchKey(index int) (string, bool) {
+	c.active.mu.Lock()
+	switched := c.active.index == index
+	if switched {
+		c.active.index = (index + 1) % len(c.keys)
+	}
+	c.active.mu.Unlock()
+
+	token, err := c.GetToken()
+	if err != nil {
+		c.log(slog.LevelError, "failed to sign token of fallback key", "key_id", c.KeyID(), "error", err)
+		return "", false
+	}
+	if switched {
+		c.log(slog.LevelWarn, "key rejected, switching keys", "rejected_key_id", c.keys[index].keyID, "key_id", c.KeyID())
+		if c.config.KeyChanged != nil {
+			c.config.KeyChanged(c.KeyID())
+		}
+	}
+	return token, true
+}
+
+// log writes a record to the configured logger, if there is one
+func (c *Client) log(level slog.Level, msg string, args ...any) {
+	if c.config.Logger != nil {
+		c.config.Logger.Log(c.httpClient.Context(), level, msg, args...)
+	}
+}
+
 // API returns an API client for the specified name
+//
+// Deprecated: Use the typed accessors, such as Devices and Profiles, which
+// need no type assertion.
 func (c *Client) API(name string) (interface{}, error) {
 	switch name {
 	case "device":
@@ -113,11 +384,103 @@ func (c *Client) API(name string) (interface{}, error) {
 		return NewProfilesAPI(c), nil
 	case "certificates":
 		return NewCertificatesAPI(c), nil
+	case "builds":
+		return NewBuildsAPI(c), nil
+	case "sandboxTesters":
+		return NewSandboxTestersAPI(c), nil
+	case "users":
+		return NewUsersAPI(c), nil
+	case "userInvitations":
+		return NewUserInvitationsAPI(c), nil
+	case "reports":
+		return NewReportsAPI(c), nil
+	case "diagnostics":
+		return NewDiagnosticsAPI(c), nil
+	case "analyticsReports":
+		return NewAnalyticsReportsAPI(c), nil
+	case "customerReviews":
+		return NewCustomerReviewsAPI(c), nil
+	case "perfPowerMetrics":
+		return NewPerfPowerMetricsAPI(c), nil
+	case "inAppPurchases":
+		return NewInAppPurchasesAPI(c), nil
+	case "inAppPurchaseLocalizations":
+		return NewInAppPurchaseLocalizationsAPI(c), nil
+	case "subscriptionGroups":
+		return NewSubscriptionGroupsAPI(c), nil
+	case "subscriptions":
+		return NewSubscriptionsAPI(c), nil
+	case "subscriptionLocalizations":
+		return NewSubscriptionLocalizationsAPI(c), nil
+	case "subscriptionPrices":
+		return NewSubscriptionPricesAPI(c), nil
+	case "subscriptionIntroductoryOffers":
+		return NewSubscriptionIntroductoryOffersAPI(c), nil
+	case "subscriptionPromotionalOffers":
+		return NewSubscriptionPromotionalOffersAPI(c), nil
+	case "subscriptionOfferCodes":
+		return NewSubscriptionOfferCodesAPI(c), nil
+	case "subscriptionAvailabilities":
+		return NewSubscriptionAvailabilitiesAPI(c), nil
+	case "inAppPurchaseAvailabilities":
+		return NewInAppPurchaseAvailabilitiesAPI(c), nil
+	case "priceEqualization":
+		return NewPriceEqualizationAPI(c), nil
+	case "ciBuildRuns":
+		return NewCiBuildRunsAPI(c), nil
+	case "ciWorkflows":
+		return NewCiWorkflowsAPI(c), nil
+	case "ciArtifacts":
+		return NewCiArtifactsAPI(c), nil
+	case "ciTestResults":
+		return NewCiTestResultsAPI(c), nil
+	case "ciIssues":
+		return NewCiIssuesAPI(c), nil
+	case "scmProviders":
+		return NewScmProvidersAPI(c), nil
+	case "scmRepositories":
+		return NewScmRepositoriesAPI(c), nil
+	case "gameCenterLeaderboards":
+		return NewGameCenterLeaderboardsAPI(c), nil
+	case "gameCenterLeaderboardSets":
+		return NewGameCenterLeaderboardSetsAPI(c), nil
+	case "gameCenterMatchmakingRuleSets":
+		return NewGameCenterMatchmakingRuleSetsAPI(c), nil
+	case "gameCenterMatchmakingQueues":
+		return NewGameCenterMatchmakingQueuesAPI(c), nil
+	case "alternativeDistributionPackages":
+		return NewAlternativeDistributionPackagesAPI(c), nil
+	case "marketplaceSearchDetails":
+		return NewMarketplaceSearchDetailsAPI(c), nil
+	case "marketplaceWebhooks":
+		return NewMarketplaceWebhooksAPI(c), nil
+	case "appStoreVersionLocalizations":
+		return NewAppStoreVersionLocalizationsAPI(c), nil
+	case "betaBuildLocalizations":
+		return NewBetaBuildLocalizationsAPI(c), nil
+	case "appStoreVersions":
+		return NewAppStoreVersionsAPI(c), nil
+	case "reviewSubmissions":
+		return NewReviewSubmissionsAPI(c), nil
+	case "webhooks":
+		return NewWebhooksAPI(c), nil
 	default:
 		return nil, fmt.Errorf("undefined API: %s", name)
 	}
 }
 
+// WithContext returns a copy of the client whose requests are sent with ctx,
+// so every API created from it can be cancelled or given a deadline:
+//
+//	devices, err := appstore.NewDeviceAPI(client.WithContext(ctx)).List(nil)
+//
+// The copy shares the keys and the underlying HTTP client.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.httpClient = c.httpClient.WithContext(ctx)
+	return &clone
+}
+
 // GetHTTPClient returns the underlying HTTP client
 func (c *Client) GetHTTPClient() *httpclient.Client {
 	return c.httpClient
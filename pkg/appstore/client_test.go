@@ -0,0 +1,142 @@
+package appstore_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// authorizations records the Authorization headers of requests and
+// rejects those that reject returns true for with 401
+type authorizations struct {
+	mu      sync.Mutex
+	headers []string
+	reject  func(r *http.Request, request int) bool
+}
+
+// serveHTTP records and answers a request
+func (a *authorizations) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	a.headers = append(a.headers, r.Header.Get("Authorization"))
+	reject := a.reject(r, len(a.headers))
+	a.mu.Unlock()
+	if reject {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"data": []}`))
+}
+
+// keyIDs returns the key IDs of the recorded requests and clears them
+func (a *authorizations) keyIDs() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	keyIDs := make([]string, len(a.headers))
+	for i, header := range a.headers {
+		keyIDs[i] = tokenKeyID(header)
+	}
+	a.headers = nil
+	return keyIDs
+}
+
+// tokenKeyID returns the key ID in the header of a bearer token
+func tokenKeyID(authorization string) string {
+	token := strings.TrimPrefix(authorization, "Bearer ")
+	header, _, _ := strings.Cut(token, ".")
+	content, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return ""
+	}
+	var fields struct {
+		Kid string `json:"kid"`
+	}
+	json.Unmarshal(content, &fields)
+	return fields.Kid
+}
+
+func TestReauthorizeWithNewToken(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+
+	handler := &authorizations{reject: func(r *http.Request, request int) bool {
+		return request == 1
+	}}
+	server.Handle(http.MethodGet, "/apps", handler.serveHTTP)
+	client := newClient(t, server, nil)
+
+	if _, err := client.Do(http.MethodGet, "/apps", nil, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	handler.mu.Lock()
+	tokens := handler.headers
+	handler.mu.Unlock()
+	if len(tokens) != 2 {
+		t.Fatalf("got %d requests, want 2", len(tokens))
+	}
+	if tokens[0] == tokens[1] {
+		t.Error("rejected request was sent again with the same token")
+	}
+	if got := client.KeyID(); got != appstoretest.KeyID {
+		t.Errorf("got key %s after a renewed token was accepted, want %s", got, appstoretest.KeyID)
+	}
+}
+
+func TestReauthorizeWithFallbackKey(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+
+	handler := &authorizations{reject: func(r *http.Request, request int) bool {
+		return tokenKeyID(r.Header.Get("Authorization")) == appstoretest.KeyID
+	}}
+	server.Handle(http.MethodGet, "/apps", handler.serveHTTP)
+	var changed []string
+	client := newClient(t, server, func(config *appstore.Config) {
+		config.Fallback = &appstore.KeyConfig{KeyID: "FALLBACK", Secret: config.Secret}
+		config.KeyChanged = func(keyID string) {
+			changed = append(changed, keyID)
+		}
+	})
+
+	if _, err := client.Do(http.MethodGet, "/apps", nil, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	want := []string{appstoretest.KeyID, appstoretest.KeyID, "FALLBACK"}
+	if keyIDs := handler.keyIDs(); strings.Join(keyIDs, ",") != strings.Join(want, ",") {
+		t.Errorf("got requests signed by %v, want %v", keyIDs, want)
+	}
+	if client.KeyID() != "FALLBACK" || len(changed) != 1 || changed[0] != "FALLBACK" {
+		t.Errorf("got key %s and KeyChanged calls %v, want a switch to FALLBACK", client.KeyID(), changed)
+	}
+
+	// Later requests are signed by the fallback key right away
+	if _, err := client.Do(http.MethodGet, "/apps", nil, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if keyIDs := handler.keyIDs(); len(keyIDs) != 1 || keyIDs[0] != "FALLBACK" {
+		t.Errorf("got requests signed by %v, want FALLBACK only", keyIDs)
+	}
+}
+
+func TestUnauthorizedWithoutFallback(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	server.Handle(http.MethodGet, "/apps", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	_, err := newClient(t, server, nil).Do(http.MethodGet, "/apps", nil, nil)
+	if !httpclient.IsUnauthorized(err) {
+		t.Fatalf("got %v, want 401", err)
+	}
+	if got := len(server.Requests()); got != 2 {
+		t.Errorf("got %d requests, want the request and one retry with a renewed token", got)
+	}
+}
@@ -0,0 +1,94 @@
+package appstore
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateProvider supplies exchange rates, returning how many units of the to
+// currency one unit of the from currency is worth on a date
+type RateProvider interface {
+	Rate(from, to string, date time.Time) (float64, error)
+}
+
+// StaticRateProvider provides fixed exchange rates relative to a base currency
+type StaticRateProvider struct {
+	Base string
+	// Rates holds the units of each currency worth one unit of Base
+	Rates map[string]float64
+}
+
+// Rate returns the exchange rate between two currencies, ignoring the date
+func (s StaticRateProvider) Rate(from, to string, date time.Time) (float64, error) {
+	fromRate, err := s.baseRate(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := s.baseRate(to)
+	if err != nil {
+		return 0, err
+	}
+	return toRate / fromRate, nil
+}
+
+func (s StaticRateProvider) baseRate(currency string) (float64, error) {
+	if currency == s.Base {
+		return 1, nil
+	}
+	rate, ok := s.Rates[currency]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("no exchange rate for %s", currency)
+	}
+	return rate, nil
+}
+
+// NormalizedSalesRow is a sales report row with its proceeds converted into
+// a single reporting currency
+type NormalizedSalesRow struct {
+	SalesReportRow
+	ReportingCurrency string  `json:"reportingCurrency"`
+	ExchangeRate      float64 `json:"exchangeRate"`
+	// Proceeds is the total developer proceeds of the row (units times
+	// per-unit proceeds) in the reporting currency
+	Proceeds float64 `json:"proceeds"`
+}
+
+// NormalizeSalesProceeds converts the proceeds of every row into currency,
+// using the rate of each row's begin date
+func NormalizeSalesProceeds(rows []SalesReportRow, currency string, provider RateProvider) ([]NormalizedSalesRow, error) {
+	if currency == "" {
+		return nil, fmt.Errorf("reporting currency is required")
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("rate provider is required")
+	}
+
+	// Rows share few currency and date combinations, look each up once
+	rates := make(map[string]float64)
+	normalized := make([]NormalizedSalesRow, len(rows))
+	for i, row := range rows {
+		rate := 1.0
+		if row.CurrencyOfProceeds != "" && row.CurrencyOfProceeds != currency {
+			key := row.CurrencyOfProceeds + "/" + row.BeginDate.Format("2006-01-02")
+			cached, ok := rates[key]
+			if !ok {
+				var err error
+				cached, err = provider.Rate(row.CurrencyOfProceeds, currency, row.BeginDate)
+				if err != nil {
+					return nil, fmt.Errorf("failed to convert %s to %s: %w", row.CurrencyOfProceeds, currency, err)
+				}
+				rates[key] = cached
+			}
+			rate = cached
+		}
+
+		normalized[i] = NormalizedSalesRow{
+			SalesReportRow:    row,
+			ReportingCurrency: currency,
+			ExchangeRate:      rate,
+			Proceeds:          row.DeveloperProceeds * float64(row.Units) * rate,
+		}
+	}
+
+	return normalized, nil
+}
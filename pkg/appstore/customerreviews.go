@@ -0,0 +1,151 @@
+package appstore
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// CustomerReview represents a customer review of an app
+type CustomerReview struct {
+	ID               string    `json:"id"`
+	Rating           int       `json:"rating"`
+	Title            string    `json:"title"`
+	Body             string    `json:"body"`
+	ReviewerNickname string    `json:"reviewerNickname"`
+	CreatedDate      time.Time `json:"createdDate"`
+	Territory        string    `json:"territory"`
+}
+
+// CustomerReviewsAPI handles customer review and review response operations
+type CustomerReviewsAPI struct {
+	client *Client
+}
+
+// NewCustomerReviewsAPI creates a new CustomerReviews API client
+func NewCustomerReviewsAPI(client *Client) *CustomerReviewsAPI {
+	return &CustomerReviewsAPI{client: client}
+}
+
+// All retrieves the customer reviews of an app
+func (c *CustomerReviewsAPI) All(appId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/apps/"+appId+"/customerReviews", params)
+}
+
+// Get retrieves a customer review by ID
+func (c *CustomerReviewsAPI) Get(reviewId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/customerReviews/"+reviewId, params)
+}
+
+// Response retrieves the developer response to a customer review
+func (c *CustomerReviewsAPI) Response(reviewId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Get("/customerReviews/"+reviewId+"/response", params)
+}
+
+// Respond creates or replaces the developer response to a customer review
+func (c *CustomerReviewsAPI) Respond(reviewId, responseBody string) (map[string]interface{}, error) {
+	if responseBody == "" {
+		return nil, fmt.Errorf("response body is required")
+	}
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "customerReviewResponses",
+			"attributes": map[string]string{
+				"responseBody": responseBody,
+			},
+			"relationships": map[string]interface{}{
+				"review": map[string]interface{}{
+					"data": map[string]string{
+						"type": "customerReviews",
+						"id":   reviewId,
+					},
+				},
+			},
+		},
+	}
+
+	return c.client.GetHTTPClient().PostJSON("/customerReviewResponses", data)
+}
+
+// DeleteResponse deletes a customer review response by ID
+func (c *CustomerReviewsAPI) DeleteResponse(responseId string) (map[string]interface{}, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.client.GetHTTPClient().Delete("/customerReviewResponses/"+responseId, nil)
+}
+
+// Reviews retrieves the customer reviews of an app as CustomerReview values
+func (c *CustomerReviewsAPI) Reviews(appId string, params map[string]string) ([]CustomerReview, error) {
+	response, err := c.All(appId, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviews []CustomerReview
+	for _, resource := range resourceList(response) {
+		reviews = append(reviews, parseCustomerReview(resource))
+	}
+	return reviews, nil
+}
+
+// AllReviews retrieves every customer review of an app, following pagination
+func (c *CustomerReviewsAPI) AllReviews(appId string, params map[string]string) ([]CustomerReview, error) {
+	query := map[string]string{"limit": "200"}
+	for k, v := range params {
+		query[k] = v
+	}
+
+	var reviews []CustomerReview
+	for query != nil {
+		response, err := c.All(appId, query)
+		if err != nil {
+			return reviews, err
+		}
+		for _, resource := range resourceList(response) {
+			reviews = append(reviews, parseCustomerReview(resource))
+		}
+		query = nextPageParams(response)
+	}
+	return reviews, nil
+}
+
+// parseCustomerReview converts a customerReviews resource object to a CustomerReview
+func parseCustomerReview(resource map[string]interface{}) CustomerReview {
+	review := CustomerReview{
+		ID:               resourceID(resource),
+		Title:            stringAttribute(resource, "title"),
+		Body:             stringAttribute(resource, "body"),
+		ReviewerNickname: stringAttribute(resource, "reviewerNickname"),
+		Territory:        stringAttribute(resource, "territory"),
+	}
+
+	if attributes, ok := resource["attributes"].(map[string]interface{}); ok {
+		if rating, ok := attributes["rating"].(float64); ok {
+			review.Rating = int(rating)
+		}
+	}
+	if created, err := time.Parse(time.RFC3339, stringAttribute(resource, "createdDate")); err == nil {
+		review.CreatedDate = created
+	}
+
+	return review
+}
+
+// fingerprint identifies the content of a review so edits can be detected
+func (r CustomerReview) fingerprint() string {
+	return strconv.Itoa(r.Rating) + "\x00" + r.Title + "\x00" + r.Body
+}
@@ -1,8 +1,12 @@
 package appstore
 
 import (
+	"context"
 	"fmt"
 	"strings"
+
+	"appstore-connect-api/pkg/httpclient"
+	"appstore-connect-api/pkg/jsonapi"
 )
 
 // DeviceAPI handles device-related operations
@@ -15,6 +19,11 @@ func NewDeviceAPI(client *Client) *DeviceAPI {
 	return &DeviceAPI{client: client}
 }
 
+// WithContext returns a copy of the API whose requests are sent with ctx
+func (d *DeviceAPI) WithContext(ctx context.Context) *DeviceAPI {
+	return NewDeviceAPI(d.client.WithContext(ctx))
+}
+
 // All retrieves all devices
 func (d *DeviceAPI) All(params map[string]string) (map[string]interface{}, error) {
 	if err := d.client.EnsureAuth(); err != nil {
@@ -23,23 +32,33 @@ func (d *DeviceAPI) All(params map[string]string) (map[string]interface{}, error
 	return d.client.GetHTTPClient().Get("/devices", params)
 }
 
-// Register registers a new device
-func (d *DeviceAPI) Register(name, platform, udid string) (map[string]interface{}, error) {
+// AllPages retrieves the devices of every page in a single response, following pagination
+func (d *DeviceAPI) AllPages(params map[string]string) (map[string]interface{}, error) {
 	if err := d.client.EnsureAuth(); err != nil {
 		return nil, err
 	}
+	return d.client.GetHTTPClient().GetAllPages("/devices", params)
+}
 
-	data := map[string]interface{}{
-		"data": map[string]interface{}{
-			"type": "devices",
-			"attributes": map[string]string{
-				"name":     name,
-				"platform": strings.ToUpper(platform),
-				"udid":     udid,
-			},
-		},
+// Register registers a new device on a platform, IOS or MAC_OS
+func (d *DeviceAPI) Register(name string, platform BundleIdPlatform, udid string) (map[string]interface{}, error) {
+	platform = BundleIdPlatform(strings.ToUpper(string(platform)))
+	if !platform.IsValid() {
+		return nil, fmt.Errorf("invalid platform: %s", platform)
+	}
+	if err := d.client.EnsureAuth(); err != nil {
+		return nil, err
 	}
 
+	data := jsonapi.NewDocument(jsonapi.Resource{
+		Type: "devices",
+		Attributes: map[string]string{
+			"name":     name,
+			"platform": string(platform),
+			"udid":     udid,
+		},
+	})
+
 	return d.client.GetHTTPClient().PostJSON("/devices", data)
 }
 
@@ -58,138 +77,56 @@ type DeviceType struct {
 
 // GetDeviceType retrieves device type information for a given UDID
 func (d *DeviceAPI) GetDeviceType(udid string) (DeviceType, error) {
-	params := map[string]string{
-		"filter[udid]":      udid,
-		"fields[devices]":   "deviceClass,model,platform,status",
-	}
-
-	response, err := d.All(params)
+	devices, err := d.List(map[string]string{
+		"filter[udid]":    udid,
+		"fields[devices]": "deviceClass,model,platform,status",
+	})
 	if err != nil {
 		return DeviceType{Success: false, Error: err.Error()}, nil
 	}
-
-	// Check for API errors
-	if errors, ok := response["errors"].([]interface{}); ok && len(errors) > 0 {
-		if errorDetail, ok := errors[0].(map[string]interface{})["detail"].(string); ok {
-			return DeviceType{Success: false, Error: errorDetail}, nil
-		}
-	}
-
-	// Check if device data exists
-	data, ok := response["data"].([]interface{})
-	if !ok || len(data) == 0 {
+	if len(devices) == 0 {
 		return DeviceType{Success: false, Error: "Device not found"}, nil
 	}
-
-	// Parse device data
-	device, ok := data[0].(map[string]interface{})
-	if !ok {
-		return DeviceType{Success: false, Error: "Invalid device data"}, nil
-	}
-
-	attributes, ok := device["attributes"].(map[string]interface{})
-	if !ok {
-		return DeviceType{Success: false, Error: "Invalid device attributes"}, nil
-	}
-
-	deviceClass := ""
-	if v, ok := attributes["deviceClass"].(string); ok {
-		deviceClass = v
-	}
-
-	model := ""
-	if v, ok := attributes["model"].(string); ok {
-		model = v
-	}
-
-	platform := ""
-	if v, ok := attributes["platform"].(string); ok {
-		platform = v
-	}
-
-	status := ""
-	if v, ok := attributes["status"].(string); ok {
-		status = v
-	}
-
-	return DeviceType{
-		Success:     true,
-		DeviceClass: deviceClass,
-		Model:       model,
-		Platform:    platform,
-		Status:      status,
-		IsIPhone:    deviceClass == "IPHONE",
-		IsIPad:      deviceClass == "IPAD",
-		IsMac:       deviceClass == "MAC",
-	}, nil
+	return newDeviceType(devices[0]), nil
 }
 
 // RegisterAndGetType attempts to register a device and returns device type
 // If device already exists, it queries existing device information
-func (d *DeviceAPI) RegisterAndGetType(name, platform, udid string) (DeviceType, error) {
+func (d *DeviceAPI) RegisterAndGetType(name string, platform BundleIdPlatform, udid string) (DeviceType, error) {
 	// Try to register device first
 	registration, err := d.Register(name, platform, udid)
 	if err != nil {
-		return DeviceType{Success: false, Error: err.Error()}, nil
-	}
-
-	// Check for errors
-	if errors, ok := registration["errors"].([]interface{}); ok && len(errors) > 0 {
-		if errorDetail, ok := errors[0].(map[string]interface{})["detail"].(string); ok {
-			// If device already exists, query existing device information
-			if strings.Contains(errorDetail, "already exists on this team") {
-				return d.GetDeviceType(udid)
-			}
-			return DeviceType{Success: false, Error: errorDetail}, nil
+		// If device already exists, query existing device information
+		if httpclient.IsAlreadyExists(err) {
+			return d.GetDeviceType(udid)
 		}
+		return DeviceType{Success: false, Error: err.Error()}, nil
 	}
 
 	// Registration successful, return device information
-	data, ok := registration["data"].(map[string]interface{})
-	if !ok {
+	resource, err := responseResource(registration)
+	if err != nil {
 		return DeviceType{Success: false, Error: "Invalid registration data"}, nil
 	}
-
-	attributes, ok := data["attributes"].(map[string]interface{})
-	if !ok {
+	var device Device
+	if err := jsonapi.Unmarshal(resource, &device); err != nil {
 		return DeviceType{Success: false, Error: "Invalid device attributes"}, nil
 	}
+	return newDeviceType(device), nil
+}
 
-	deviceClass := ""
-	if v, ok := attributes["deviceClass"].(string); ok {
-		deviceClass = v
-	}
-
-	model := ""
-	if v, ok := attributes["model"].(string); ok {
-		model = v
-	}
-
-	platformResult := ""
-	if v, ok := attributes["platform"].(string); ok {
-		platformResult = v
-	}
-
-	status := ""
-	if v, ok := attributes["status"].(string); ok {
-		status = v
-	}
-
-	deviceID := ""
-	if v, ok := data["id"].(string); ok {
-		deviceID = v
-	}
-
+// newDeviceType returns the device type information of a device
+func newDeviceType(device Device) DeviceType {
 	return DeviceType{
 		Success:     true,
-		DeviceClass: deviceClass,
-		Model:       model,
-		Platform:    platformResult,
-		Status:      status,
-		IsIPhone:    deviceClass == "IPHONE",
-		IsIPad:      deviceClass == "IPAD",
-		IsMac:       deviceClass == "MAC",
-	}, nil
+		DeviceClass: device.DeviceClass,
+		Model:       device.Model,
+		Platform:    device.Platform,
+		Status:      device.Status,
+		IsIPhone:    device.DeviceClass == "IPHONE",
+		IsIPad:      device.DeviceClass == "IPAD",
+		IsMac:       device.DeviceClass == "MAC",
+	}
 }
 
 // DeviceSortResult represents the result of device sorting
@@ -303,3 +240,26 @@ func (d *DeviceAPI) DeviceSort() (DeviceSortResult, error) {
 		},
 	}, nil
 }
+
+// Update renames, enables, or disables a device. Empty values are left unchanged.
+func (d *DeviceAPI) Update(deviceId, name, status string) (map[string]interface{}, error) {
+	if err := d.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := make(map[string]string)
+	if name != "" {
+		attributes["name"] = name
+	}
+	if status != "" {
+		attributes["status"] = status
+	}
+
+	data := jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "devices",
+		ID:         deviceId,
+		Attributes: attributes,
+	})
+
+	return d.client.GetHTTPClient().PatchJSON("/devices/"+deviceId, data)
+}
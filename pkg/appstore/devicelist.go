@@ -0,0 +1,66 @@
+package appstore
+
+import "appstore-connect-api/pkg/jsonapi"
+
+// Device represents a registered device
+type Device struct {
+	ID          string        `json:"-"`
+	Name        string        `json:"name"`
+	Platform    string        `json:"platform"`
+	UDID        string        `json:"udid"`
+	DeviceClass string        `json:"deviceClass"`
+	Model       string        `json:"model"`
+	Status      string        `json:"status"`
+	AddedDate   string        `json:"addedDate"`
+	Links       jsonapi.Links `json:"-"`
+}
+
+// List retrieves every device matching params, following pagination
+func (d *DeviceAPI) List(params map[string]string) ([]Device, error) {
+	if err := d.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	resources, err := d.client.listResources("/devices", params)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]Device, len(resources))
+	for i, resource := range resources {
+		if err := jsonapi.Unmarshal(resource, &devices[i]); err != nil {
+			return nil, err
+		}
+	}
+	return devices, nil
+}
+
+// ListPage retrieves a single page of devices matching params with its paging
+// metadata. Pass PageInfo.NextParams as params to request the next page.
+func (d *DeviceAPI) ListPage(params map[string]string) ([]Device, jsonapi.PageInfo, error) {
+	if err := d.client.EnsureAuth(); err != nil {
+		return nil, jsonapi.PageInfo{}, err
+	}
+	resources, info, err := d.client.listPage("/devices", params)
+	if err != nil {
+		return nil, jsonapi.PageInfo{}, err
+	}
+	devices, err := unmarshalResources[Device](resources)
+	return devices, info, err
+}
+
+// Get retrieves a device by ID
+func (d *DeviceAPI) Get(deviceId string) (Device, error) {
+	if err := d.client.EnsureAuth(); err != nil {
+		return Device{}, err
+	}
+	response, err := d.client.GetHTTPClient().Get("/devices/"+deviceId, nil)
+	if err != nil {
+		return Device{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return Device{}, err
+	}
+	var device Device
+	err = jsonapi.Unmarshal(resource, &device)
+	return device, err
+}
@@ -0,0 +1,57 @@
+package appstore
+
+// DiagnosticType represents the kind of diagnostic collected for a build
+type DiagnosticType string
+
+// Diagnostic types reported by App Store Connect
+const (
+	DiagnosticTypeDiskWrites DiagnosticType = "DISK_WRITES"
+	DiagnosticTypeHangs      DiagnosticType = "HANGS"
+	DiagnosticTypeLaunches   DiagnosticType = "LAUNCHES"
+)
+
+// diagnosticLogsContentType is the media type of diagnostic log payloads
+const diagnosticLogsContentType = "application/vnd.apple.diagnostic-logs+json"
+
+// DiagnosticsAPI handles diagnostic signature and log operations
+type DiagnosticsAPI struct {
+	client *Client
+}
+
+// NewDiagnosticsAPI creates a new Diagnostics API client
+func NewDiagnosticsAPI(client *Client) *DiagnosticsAPI {
+	return &DiagnosticsAPI{client: client}
+}
+
+// Signatures lists the diagnostic signatures of a build, optionally filtered by type
+func (d *DiagnosticsAPI) Signatures(buildId string, diagnosticType DiagnosticType, params map[string]string) (map[string]interface{}, error) {
+	if err := d.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	query := make(map[string]string, len(params)+1)
+	for k, v := range params {
+		query[k] = v
+	}
+	if diagnosticType != "" {
+		query["filter[diagnosticType]"] = string(diagnosticType)
+	}
+
+	return d.client.GetHTTPClient().Get("/builds/"+buildId+"/diagnosticSignatures", query)
+}
+
+// Logs retrieves the diagnostic logs of a diagnostic signature
+func (d *DiagnosticsAPI) Logs(signatureId string, params map[string]string) (map[string]interface{}, error) {
+	if err := d.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return d.client.GetHTTPClient().Get("/diagnosticSignatures/"+signatureId+"/logs", params)
+}
+
+// DownloadLogs downloads the raw diagnostic log payload of a diagnostic signature
+func (d *DiagnosticsAPI) DownloadLogs(signatureId string) ([]byte, error) {
+	if err := d.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return d.client.GetHTTPClient().GetRaw("/diagnosticSignatures/"+signatureId+"/logs", nil, diagnosticLogsContentType)
+}
@@ -0,0 +1,65 @@
+package appstore_test
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+func TestDryRun(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	var output bytes.Buffer
+	client := newClient(t, server, func(config *appstore.Config) {
+		config.DryRun = true
+		config.DryRunOutput = &output
+	})
+	devicesAPI := appstore.NewDeviceAPI(client)
+
+	// Reads are sent
+	devices, err := devicesAPI.List(nil)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(devices) != 3 {
+		t.Errorf("got %d devices, want 3", len(devices))
+	}
+
+	// Writes are answered with a synthetic success
+	registered, err := devicesAPI.Register("New iPhone", "IOS", "00008110-000D4E5F60718293")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if resource, _ := jsonapi.Data(registered); jsonapi.ResourceID(resource) != "DRY-RUN" {
+		t.Errorf("got registered device %v, want ID DRY-RUN", registered)
+	}
+	updated, err := devicesAPI.Update("DEVICE0001", "Renamed iPhone", "")
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if resource, _ := jsonapi.Data(updated); jsonapi.ResourceID(resource) != "DEVICE0001" {
+		t.Errorf("got updated device %v, want DEVICE0001", updated)
+	}
+	if _, err := client.Do(http.MethodDelete, "/profiles/PROFILE0001", nil, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	for _, request := range server.Requests() {
+		if request.Method != http.MethodGet {
+			t.Errorf("dry run sent %s %s", request.Method, request.Path)
+		}
+	}
+	if got := len(server.Resources("devices")); got != 3 {
+		t.Errorf("got %d devices on the server, want 3", got)
+	}
+	for _, want := range []string{"dry run: POST ", "dry run: PATCH ", `"Renamed iPhone"`, "dry run: DELETE "} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("dry run output %q does not contain %q", output.String(), want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package appstore
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"appstore-connect-api/pkg/jwtutil"
+)
+
+// Environment variables read by ConfigFromEnv
+const (
+	EnvIssuerID       = "ASC_ISSUER_ID"
+	EnvKeyID          = "ASC_KEY_ID"
+	EnvPrivateKeyPath = "ASC_PRIVATE_KEY_PATH"
+	EnvPrivateKey     = "ASC_PRIVATE_KEY"
+	// EnvKeyType is "individual" for individual keys, which have no issuer ID
+	EnvKeyType = "ASC_KEY_TYPE"
+	// EnvKeyFile is the path of a fastlane API key JSON file
+	EnvKeyFile = "ASC_KEY_FILE"
+	// EnvFastlaneKeyFile is fastlane's variable for the path of its API key
+	// JSON file, read when EnvKeyFile is not set
+	EnvFastlaneKeyFile = "APP_STORE_CONNECT_API_KEY_PATH"
+)
+
+// FastlaneKey is an API key file in the JSON format of fastlane's
+// app_store_connect_api_key action
+type FastlaneKey struct {
+	KeyID    string `json:"key_id"`
+	IssuerID string `json:"issuer_id"`
+	// Key is the content of the .p8 private key, base64 encoded when
+	// IsKeyContentBase64 is set
+	Key                string `json:"key"`
+	IsKeyContentBase64 bool   `json:"is_key_content_base64"`
+	// InHouse is set for keys of Apple Developer Enterprise Program accounts
+	InHouse bool `json:"in_house"`
+}
+
+// NewClientFromEnv creates a client configured by environment variables,
+// see ConfigFromEnv
+func NewClientFromEnv() (*Client, error) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(config)
+}
+
+// ConfigFromEnv returns the key configuration of the environment: a
+// fastlane key file named by ASC_KEY_FILE or APP_STORE_CONNECT_API_KEY_PATH,
+// overridden by ASC_ISSUER_ID, ASC_KEY_ID, ASC_KEY_TYPE, and
+// ASC_PRIVATE_KEY_PATH or ASC_PRIVATE_KEY. ASC_PRIVATE_KEY holds the PEM
+// content of the key, optionally base64 encoded.
+func ConfigFromEnv() (Config, error) {
+	var config Config
+	if path := firstEnv(EnvKeyFile, EnvFastlaneKeyFile); path != "" {
+		var err error
+		config, err = LoadFastlaneKey(path)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	if issuer := os.Getenv(EnvIssuerID); issuer != "" {
+		config.Issuer = issuer
+	}
+	if keyID := os.Getenv(EnvKeyID); keyID != "" {
+		config.KeyID = keyID
+	}
+	if keyType := os.Getenv(EnvKeyType); keyType != "" {
+		config.KeyType = jwtutil.KeyType(keyType)
+	}
+	if path := os.Getenv(EnvPrivateKeyPath); path != "" {
+		config.Secret = path
+	} else if key := os.Getenv(EnvPrivateKey); key != "" {
+		secret, err := decodeKeyContent(key)
+		if err != nil {
+			return Config{}, fmt.Errorf("%s: %w", EnvPrivateKey, err)
+		}
+		config.Secret = secret
+	}
+
+	if config.KeyID == "" && config.Secret == "" {
+		return Config{}, fmt.Errorf("no API key configured, set %s and %s or %s", EnvKeyID, EnvPrivateKeyPath, EnvKeyFile)
+	}
+	return config, nil
+}
+
+// LoadFastlaneKey returns the key configuration of a fastlane API key JSON
+// file. Keys without an issuer ID are individual keys.
+func LoadFastlaneKey(path string) (Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read key file: %w", err)
+	}
+	var key FastlaneKey
+	if err := json.Unmarshal(content, &key); err != nil {
+		return Config{}, fmt.Errorf("failed to parse key file: %w", err)
+	}
+	if key.KeyID == "" {
+		return Config{}, fmt.Errorf("key file has no key_id")
+	}
+	if key.Key == "" {
+		return Config{}, fmt.Errorf("key file has no key")
+	}
+
+	secret := key.Key
+	if key.IsKeyContentBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(key.Key)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to decode key: %w", err)
+		}
+		secret = string(decoded)
+	}
+
+	config := Config{Issuer: key.IssuerID, KeyID: key.KeyID, Secret: secret}
+	if key.IssuerID == "" {
+		config.KeyType = jwtutil.KeyTypeIndividual
+	}
+	if key.InHouse {
+		config.ProgramType = ProgramEnterprise
+	}
+	return config, nil
+}
+
+// decodeKeyContent returns the PEM content of a private key given as PEM or
+// as base64 encoded PEM, which is easier to store in CI secrets
+func decodeKeyContent(key string) (string, error) {
+	if strings.Contains(key, "-----BEGIN") {
+		// Secrets stored on one line often have escaped newlines
+		return strings.ReplaceAll(key, `\n`, "\n"), nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return "", fmt.Errorf("private key is neither PEM nor base64 encoded PEM")
+	}
+	return string(decoded), nil
+}
+
+// firstEnv returns the first non-empty environment variable of names
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
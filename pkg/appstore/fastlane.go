@@ -0,0 +1,130 @@
+package appstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// FastlaneObject converts a JSON:API resource object to the flat shape of
+// fastlane's Spaceship::ConnectAPI models: the ID, the type, the attributes
+// in snake_case, and related resources as {"id": ...} objects, e.g.
+// {"id": "...", "type": "devices", "device_class": "IPHONE", "udid": "..."}
+func FastlaneObject(resource map[string]interface{}) map[string]interface{} {
+	object := map[string]interface{}{
+		"id":   resourceID(resource),
+		"type": resource["type"],
+	}
+	if attributes, ok := resource["attributes"].(map[string]interface{}); ok {
+		for name, value := range attributes {
+			object[snakeCase(name)] = fastlaneValue(value)
+		}
+	}
+
+	relationships, _ := resource["relationships"].(map[string]interface{})
+	for name, relationship := range relationships {
+		relationship, ok := relationship.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch data := relationship["data"].(type) {
+		case map[string]interface{}:
+			object[snakeCase(name)] = map[string]interface{}{"id": resourceID(data)}
+		case []interface{}:
+			related := make([]map[string]interface{}, 0, len(data))
+			for _, item := range data {
+				if item, ok := item.(map[string]interface{}); ok {
+					related = append(related, map[string]interface{}{"id": resourceID(item)})
+				}
+			}
+			object[snakeCase(name)] = related
+		}
+	}
+	return object
+}
+
+// FastlaneObjects converts the resource objects of a single or list response
+// to fastlane model shapes, see FastlaneObject
+func FastlaneObjects(response map[string]interface{}) []map[string]interface{} {
+	if resource, ok := response["data"].(map[string]interface{}); ok {
+		return []map[string]interface{}{FastlaneObject(resource)}
+	}
+	objects := []map[string]interface{}{}
+	for _, resource := range resourceList(response) {
+		objects = append(objects, FastlaneObject(resource))
+	}
+	return objects
+}
+
+// FastlaneModel converts a typed model such as Device or Build to a fastlane
+// model shape, adding the ID that typed models leave out of their JSON
+func FastlaneModel(id string, model interface{}) (map[string]interface{}, error) {
+	converted, err := FastlaneJSON(model)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := converted.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("model is not a JSON object")
+	}
+	object["id"] = id
+	return object, nil
+}
+
+// FastlaneJSON converts any JSON value to fastlane shapes: resource objects
+// become FastlaneObject shapes and the keys of other objects become snake_case
+func FastlaneJSON(value interface{}) (interface{}, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+	return fastlaneValue(decoded), nil
+}
+
+// fastlaneValue converts a decoded JSON value to fastlane shapes
+func fastlaneValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		_, hasType := v["type"].(string)
+		_, hasAttributes := v["attributes"].(map[string]interface{})
+		if hasType && hasAttributes {
+			return FastlaneObject(v)
+		}
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			object[snakeCase(key)] = fastlaneValue(item)
+		}
+		return object
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = fastlaneValue(item)
+		}
+		return items
+	}
+	return value
+}
+
+// snakeCase converts a camelCase name to snake_case, keeping runs of upper
+// case letters together, e.g. "minOsVersion" to "min_os_version"
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			previousLower := i > 0 && !unicode.IsUpper(runes[i-1]) && runes[i-1] != '_'
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if previousLower || nextLower {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,291 @@
+package appstore
+
+import (
+	"fmt"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// GameCenterSubmissionType represents which score of a player a leaderboard keeps
+type GameCenterSubmissionType string
+
+// Game Center submission types
+const (
+	GameCenterSubmissionBestScore       GameCenterSubmissionType = "BEST_SCORE"
+	GameCenterSubmissionMostRecentScore GameCenterSubmissionType = "MOST_RECENT_SCORE"
+)
+
+// GameCenterScoreSortType represents how a leaderboard ranks scores
+type GameCenterScoreSortType string
+
+// Game Center score sort types
+const (
+	GameCenterScoreSortAscending  GameCenterScoreSortType = "ASC"
+	GameCenterScoreSortDescending GameCenterScoreSortType = "DESC"
+)
+
+// GameCenterLeaderboardAttributes holds the attributes of a new leaderboard
+type GameCenterLeaderboardAttributes struct {
+	ReferenceName    string                   `json:"referenceName"`
+	VendorIdentifier string                   `json:"vendorIdentifier"`
+	DefaultFormatter string                   `json:"defaultFormatter"`
+	SubmissionType   GameCenterSubmissionType `json:"submissionType"`
+	ScoreSortType    GameCenterScoreSortType  `json:"scoreSortType"`
+	ScoreRangeStart  string                   `json:"scoreRangeStart,omitempty"`
+	ScoreRangeEnd    string                   `json:"scoreRangeEnd,omitempty"`
+	// RecurrenceStartDate, RecurrenceDuration and RecurrenceRule make the leaderboard recurring
+	RecurrenceStartDate string `json:"recurrenceStartDate,omitempty"`
+	RecurrenceDuration  string `json:"recurrenceDuration,omitempty"`
+	RecurrenceRule      string `json:"recurrenceRule,omitempty"`
+}
+
+// GameCenterLeaderboardLocalization holds the localized name and score format of a leaderboard
+type GameCenterLeaderboardLocalization struct {
+	Locale                  string `json:"locale,omitempty"`
+	Name                    string `json:"name"`
+	FormatterOverride       string `json:"formatterOverride,omitempty"`
+	FormatterSuffix         string `json:"formatterSuffix,omitempty"`
+	FormatterSuffixSingular string `json:"formatterSuffixSingular,omitempty"`
+}
+
+// GameCenterLeaderboardsAPI handles Game Center leaderboard, localization, image, and release operations
+type GameCenterLeaderboardsAPI struct {
+	client *Client
+}
+
+// NewGameCenterLeaderboardsAPI creates a new GameCenterLeaderboards API client
+func NewGameCenterLeaderboardsAPI(client *Client) *GameCenterLeaderboardsAPI {
+	return &GameCenterLeaderboardsAPI{client: client}
+}
+
+// GameCenterDetailID returns the ID of the Game Center detail of an app,
+// which owns its leaderboards and leaderboard sets
+func (g *GameCenterLeaderboardsAPI) GameCenterDetailID(appId string) (string, error) {
+	return g.client.gameCenterDetailID(appId)
+}
+
+// All retrieves the leaderboards of a Game Center detail
+func (g *GameCenterLeaderboardsAPI) All(detailId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterDetails/"+detailId+"/gameCenterLeaderboards", params)
+}
+
+// Get retrieves a leaderboard by ID
+func (g *GameCenterLeaderboardsAPI) Get(leaderboardId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterLeaderboards/"+leaderboardId, params)
+}
+
+// Create creates a leaderboard for a Game Center detail
+func (g *GameCenterLeaderboardsAPI) Create(detailId string, attributes GameCenterLeaderboardAttributes) (map[string]interface{}, error) {
+	if attributes.ReferenceName == "" {
+		return nil, fmt.Errorf("reference name is required")
+	}
+	if attributes.VendorIdentifier == "" {
+		return nil, fmt.Errorf("vendor identifier is required")
+	}
+	if attributes.DefaultFormatter == "" {
+		return nil, fmt.Errorf("default formatter is required")
+	}
+	if attributes.SubmissionType == "" {
+		return nil, fmt.Errorf("submission type is required")
+	}
+	if attributes.ScoreSortType == "" {
+		return nil, fmt.Errorf("score sort type is required")
+	}
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "gameCenterLeaderboards",
+			"attributes": attributes,
+			"relationships": map[string]interface{}{
+				"gameCenterDetail": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterDetails",
+						"id":   detailId,
+					},
+				},
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PostJSON("/gameCenterLeaderboards", data)
+}
+
+// Update changes the attributes of a leaderboard, only non-empty values are sent
+func (g *GameCenterLeaderboardsAPI) Update(leaderboardId string, attributes map[string]interface{}) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "gameCenterLeaderboards",
+			"id":         leaderboardId,
+			"attributes": attributes,
+		},
+	}
+
+	return g.client.GetHTTPClient().PatchJSON("/gameCenterLeaderboards/"+leaderboardId, data)
+}
+
+// Archive archives a leaderboard so it is hidden from players, or unarchives it
+func (g *GameCenterLeaderboardsAPI) Archive(leaderboardId string, archived bool) (map[string]interface{}, error) {
+	return g.Update(leaderboardId, map[string]interface{}{"archived": archived})
+}
+
+// Delete deletes a leaderboard by ID
+func (g *GameCenterLeaderboardsAPI) Delete(leaderboardId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterLeaderboards/"+leaderboardId, nil)
+}
+
+// Localizations retrieves the localizations of a leaderboard
+func (g *GameCenterLeaderboardsAPI) Localizations(leaderboardId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterLeaderboards/"+leaderboardId+"/localizations", params)
+}
+
+// CreateLocalization adds a localized name and score format to a leaderboard
+func (g *GameCenterLeaderboardsAPI) CreateLocalization(leaderboardId string, localization GameCenterLeaderboardLocalization) (map[string]interface{}, error) {
+	if localization.Locale == "" {
+		return nil, fmt.Errorf("locale is required")
+	}
+	if localization.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "gameCenterLeaderboardLocalizations",
+			"attributes": localization,
+			"relationships": map[string]interface{}{
+				"gameCenterLeaderboard": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterLeaderboards",
+						"id":   leaderboardId,
+					},
+				},
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PostJSON("/gameCenterLeaderboardLocalizations", data)
+}
+
+// UpdateLocalization changes the name and score format of a leaderboard localization
+func (g *GameCenterLeaderboardsAPI) UpdateLocalization(localizationId string, localization GameCenterLeaderboardLocalization) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	// The locale of an existing localization cannot change
+	localization.Locale = ""
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "gameCenterLeaderboardLocalizations",
+			"id":         localizationId,
+			"attributes": localization,
+		},
+	}
+
+	return g.client.GetHTTPClient().PatchJSON("/gameCenterLeaderboardLocalizations/"+localizationId, data)
+}
+
+// DeleteLocalization deletes a leaderboard localization by ID
+func (g *GameCenterLeaderboardsAPI) DeleteLocalization(localizationId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterLeaderboardLocalizations/"+localizationId, nil)
+}
+
+// UploadImage uploads the image file of a leaderboard localization
+func (g *GameCenterLeaderboardsAPI) UploadImage(localizationId, path string) (map[string]interface{}, error) {
+	return g.client.reserveAndUpload("gameCenterLeaderboardImages", path, map[string]jsonapi.Relationship{
+		"gameCenterLeaderboardLocalization": jsonapi.ToOne("gameCenterLeaderboardLocalizations", localizationId),
+	})
+}
+
+// DeleteImage deletes a leaderboard image by ID
+func (g *GameCenterLeaderboardsAPI) DeleteImage(imageId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterLeaderboardImages/"+imageId, nil)
+}
+
+// Releases retrieves the releases of a leaderboard
+func (g *GameCenterLeaderboardsAPI) Releases(leaderboardId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterLeaderboards/"+leaderboardId+"/releases", params)
+}
+
+// Release makes a leaderboard live for players of the app
+func (g *GameCenterLeaderboardsAPI) Release(detailId, leaderboardId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "gameCenterLeaderboardReleases",
+			"relationships": map[string]interface{}{
+				"gameCenterDetail": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterDetails",
+						"id":   detailId,
+					},
+				},
+				"gameCenterLeaderboard": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterLeaderboards",
+						"id":   leaderboardId,
+					},
+				},
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PostJSON("/gameCenterLeaderboardReleases", data)
+}
+
+// DeleteRelease removes a leaderboard release by ID
+func (g *GameCenterLeaderboardsAPI) DeleteRelease(releaseId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterLeaderboardReleases/"+releaseId, nil)
+}
+
+// gameCenterDetailID returns the ID of the Game Center detail of an app
+func (c *Client) gameCenterDetailID(appId string) (string, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return "", err
+	}
+	response, err := c.GetHTTPClient().Get("/apps/"+appId+"/gameCenterDetail", nil)
+	if err != nil {
+		return "", err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return "", fmt.Errorf("app %s has no game center detail", appId)
+	}
+	return resourceID(resource), nil
+}
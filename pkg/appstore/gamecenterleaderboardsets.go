@@ -0,0 +1,247 @@
+package appstore
+
+import (
+	"fmt"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// GameCenterLeaderboardSetsAPI handles Game Center leaderboard set,
+// membership, localization, image, and release operations
+type GameCenterLeaderboardSetsAPI struct {
+	client *Client
+}
+
+// NewGameCenterLeaderboardSetsAPI creates a new GameCenterLeaderboardSets API client
+func NewGameCenterLeaderboardSetsAPI(client *Client) *GameCenterLeaderboardSetsAPI {
+	return &GameCenterLeaderboardSetsAPI{client: client}
+}
+
+// GameCenterDetailID returns the ID of the Game Center detail of an app
+func (g *GameCenterLeaderboardSetsAPI) GameCenterDetailID(appId string) (string, error) {
+	return g.client.gameCenterDetailID(appId)
+}
+
+// All retrieves the leaderboard sets of a Game Center detail
+func (g *GameCenterLeaderboardSetsAPI) All(detailId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterDetails/"+detailId+"/gameCenterLeaderboardSets", params)
+}
+
+// Get retrieves a leaderboard set by ID
+func (g *GameCenterLeaderboardSetsAPI) Get(setId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterLeaderboardSets/"+setId, params)
+}
+
+// Create creates a leaderboard set for a Game Center detail
+func (g *GameCenterLeaderboardSetsAPI) Create(detailId, referenceName, vendorIdentifier string) (map[string]interface{}, error) {
+	if referenceName == "" {
+		return nil, fmt.Errorf("reference name is required")
+	}
+	if vendorIdentifier == "" {
+		return nil, fmt.Errorf("vendor identifier is required")
+	}
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "gameCenterLeaderboardSets",
+			"attributes": map[string]string{
+				"referenceName":    referenceName,
+				"vendorIdentifier": vendorIdentifier,
+			},
+			"relationships": map[string]interface{}{
+				"gameCenterDetail": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterDetails",
+						"id":   detailId,
+					},
+				},
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PostJSON("/gameCenterLeaderboardSets", data)
+}
+
+// Update renames a leaderboard set
+func (g *GameCenterLeaderboardSetsAPI) Update(setId, referenceName string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "gameCenterLeaderboardSets",
+			"id":   setId,
+			"attributes": map[string]string{
+				"referenceName": referenceName,
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PatchJSON("/gameCenterLeaderboardSets/"+setId, data)
+}
+
+// Delete deletes a leaderboard set by ID
+func (g *GameCenterLeaderboardSetsAPI) Delete(setId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterLeaderboardSets/"+setId, nil)
+}
+
+// Leaderboards retrieves the leaderboards of a set
+func (g *GameCenterLeaderboardSetsAPI) Leaderboards(setId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterLeaderboardSets/"+setId+"/gameCenterLeaderboards", params)
+}
+
+// SetLeaderboards replaces the leaderboards of a set, in display order
+func (g *GameCenterLeaderboardSetsAPI) SetLeaderboards(setId string, leaderboardIds []string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	leaderboardsData := make([]map[string]string, len(leaderboardIds))
+	for i, id := range leaderboardIds {
+		leaderboardsData[i] = map[string]string{
+			"type": "gameCenterLeaderboards",
+			"id":   id,
+		}
+	}
+	data := map[string]interface{}{
+		"data": leaderboardsData,
+	}
+
+	return g.client.GetHTTPClient().PatchJSON("/gameCenterLeaderboardSets/"+setId+"/relationships/gameCenterLeaderboards", data)
+}
+
+// Localizations retrieves the localizations of a leaderboard set
+func (g *GameCenterLeaderboardSetsAPI) Localizations(setId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterLeaderboardSets/"+setId+"/localizations", params)
+}
+
+// CreateLocalization adds a localized name to a leaderboard set
+func (g *GameCenterLeaderboardSetsAPI) CreateLocalization(setId, locale, name string) (map[string]interface{}, error) {
+	if locale == "" {
+		return nil, fmt.Errorf("locale is required")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "gameCenterLeaderboardSetLocalizations",
+			"attributes": map[string]string{
+				"locale": locale,
+				"name":   name,
+			},
+			"relationships": map[string]interface{}{
+				"gameCenterLeaderboardSet": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterLeaderboardSets",
+						"id":   setId,
+					},
+				},
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PostJSON("/gameCenterLeaderboardSetLocalizations", data)
+}
+
+// UpdateLocalization renames a leaderboard set localization
+func (g *GameCenterLeaderboardSetsAPI) UpdateLocalization(localizationId, name string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "gameCenterLeaderboardSetLocalizations",
+			"id":   localizationId,
+			"attributes": map[string]string{
+				"name": name,
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PatchJSON("/gameCenterLeaderboardSetLocalizations/"+localizationId, data)
+}
+
+// DeleteLocalization deletes a leaderboard set localization by ID
+func (g *GameCenterLeaderboardSetsAPI) DeleteLocalization(localizationId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterLeaderboardSetLocalizations/"+localizationId, nil)
+}
+
+// UploadImage uploads the image file of a leaderboard set localization
+func (g *GameCenterLeaderboardSetsAPI) UploadImage(localizationId, path string) (map[string]interface{}, error) {
+	return g.client.reserveAndUpload("gameCenterLeaderboardSetImages", path, map[string]jsonapi.Relationship{
+		"gameCenterLeaderboardSetLocalization": jsonapi.ToOne("gameCenterLeaderboardSetLocalizations", localizationId),
+	})
+}
+
+// DeleteImage deletes a leaderboard set image by ID
+func (g *GameCenterLeaderboardSetsAPI) DeleteImage(imageId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterLeaderboardSetImages/"+imageId, nil)
+}
+
+// Release makes a leaderboard set live for players of the app
+func (g *GameCenterLeaderboardSetsAPI) Release(detailId, setId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "gameCenterLeaderboardSetReleases",
+			"relationships": map[string]interface{}{
+				"gameCenterDetail": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterDetails",
+						"id":   detailId,
+					},
+				},
+				"gameCenterLeaderboardSet": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterLeaderboardSets",
+						"id":   setId,
+					},
+				},
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PostJSON("/gameCenterLeaderboardSetReleases", data)
+}
+
+// DeleteRelease removes a leaderboard set release by ID
+func (g *GameCenterLeaderboardSetsAPI) DeleteRelease(releaseId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterLeaderboardSetReleases/"+releaseId, nil)
+}
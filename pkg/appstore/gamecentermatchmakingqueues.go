@@ -0,0 +1,108 @@
+package appstore
+
+import "fmt"
+
+// GameCenterMatchmakingQueuesAPI handles Game Center matchmaking queue operations
+type GameCenterMatchmakingQueuesAPI struct {
+	client *Client
+}
+
+// NewGameCenterMatchmakingQueuesAPI creates a new GameCenterMatchmakingQueues API client
+func NewGameCenterMatchmakingQueuesAPI(client *Client) *GameCenterMatchmakingQueuesAPI {
+	return &GameCenterMatchmakingQueuesAPI{client: client}
+}
+
+// All retrieves all matchmaking queues
+func (g *GameCenterMatchmakingQueuesAPI) All(params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterMatchmakingQueues", params)
+}
+
+// Get retrieves a matchmaking queue by ID
+func (g *GameCenterMatchmakingQueuesAPI) Get(queueId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterMatchmakingQueues/"+queueId, params)
+}
+
+// Create creates a matchmaking queue using a rule set. The bundle IDs of
+// apps using classic matchmaking are optional.
+func (g *GameCenterMatchmakingQueuesAPI) Create(referenceName, ruleSetId string, classicMatchmakingBundleIds []string) (map[string]interface{}, error) {
+	if referenceName == "" {
+		return nil, fmt.Errorf("reference name is required")
+	}
+	if ruleSetId == "" {
+		return nil, fmt.Errorf("rule set id is required")
+	}
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]interface{}{
+		"referenceName": referenceName,
+	}
+	if len(classicMatchmakingBundleIds) > 0 {
+		attributes["classicMatchmakingBundleIds"] = classicMatchmakingBundleIds
+	}
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "gameCenterMatchmakingQueues",
+			"attributes": attributes,
+			"relationships": map[string]interface{}{
+				"ruleSet": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterMatchmakingRuleSets",
+						"id":   ruleSetId,
+					},
+				},
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PostJSON("/gameCenterMatchmakingQueues", data)
+}
+
+// SetRuleSet switches a queue to another rule set, and optionally an
+// experiment rule set that receives a share of the requests
+func (g *GameCenterMatchmakingQueuesAPI) SetRuleSet(queueId, ruleSetId, experimentRuleSetId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	relationships := map[string]interface{}{
+		"ruleSet": map[string]interface{}{
+			"data": map[string]string{
+				"type": "gameCenterMatchmakingRuleSets",
+				"id":   ruleSetId,
+			},
+		},
+	}
+	if experimentRuleSetId != "" {
+		relationships["experimentRuleSet"] = map[string]interface{}{
+			"data": map[string]string{
+				"type": "gameCenterMatchmakingRuleSets",
+				"id":   experimentRuleSetId,
+			},
+		}
+	}
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":          "gameCenterMatchmakingQueues",
+			"id":            queueId,
+			"relationships": relationships,
+		},
+	}
+
+	return g.client.GetHTTPClient().PatchJSON("/gameCenterMatchmakingQueues/"+queueId, data)
+}
+
+// Delete deletes a matchmaking queue by ID
+func (g *GameCenterMatchmakingQueuesAPI) Delete(queueId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterMatchmakingQueues/"+queueId, nil)
+}
@@ -0,0 +1,327 @@
+package appstore
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// GameCenterMatchmakingRuleType represents how a matchmaking rule is evaluated
+type GameCenterMatchmakingRuleType string
+
+// Game Center matchmaking rule types
+const (
+	GameCenterMatchmakingRuleCompatible GameCenterMatchmakingRuleType = "COMPATIBLE"
+	GameCenterMatchmakingRuleDistance   GameCenterMatchmakingRuleType = "DISTANCE"
+	GameCenterMatchmakingRuleMatch      GameCenterMatchmakingRuleType = "MATCH"
+	GameCenterMatchmakingRuleTeam       GameCenterMatchmakingRuleType = "TEAM"
+)
+
+// GameCenterMatchmakingRule describes a rule of a matchmaking rule set,
+// identified within its set by reference name
+type GameCenterMatchmakingRule struct {
+	ID            string                        `json:"-"`
+	ReferenceName string                        `json:"referenceName"`
+	Description   string                        `json:"description"`
+	Type          GameCenterMatchmakingRuleType `json:"type"`
+	Expression    string                        `json:"expression"`
+	Weight        float64                       `json:"weight,omitempty"`
+}
+
+// GameCenterMatchmakingTestRequest is a simulated match request evaluated by a rule set test
+type GameCenterMatchmakingTestRequest struct {
+	RequestName    string                 `json:"requestName"`
+	SecondsInQueue int                    `json:"secondsInQueue"`
+	BundleID       string                 `json:"bundleId"`
+	Platform       string                 `json:"platform"`
+	Locale         string                 `json:"locale,omitempty"`
+	PlayerCount    int                    `json:"playerCount,omitempty"`
+	MinPlayers     int                    `json:"minPlayers,omitempty"`
+	MaxPlayers     int                    `json:"maxPlayers,omitempty"`
+	Properties     map[string]interface{} `json:"properties,omitempty"`
+}
+
+// GameCenterMatchmakingRuleSetsAPI handles Game Center matchmaking rule set, rule, and rule test operations
+type GameCenterMatchmakingRuleSetsAPI struct {
+	client *Client
+}
+
+// NewGameCenterMatchmakingRuleSetsAPI creates a new GameCenterMatchmakingRuleSets API client
+func NewGameCenterMatchmakingRuleSetsAPI(client *Client) *GameCenterMatchmakingRuleSetsAPI {
+	return &GameCenterMatchmakingRuleSetsAPI{client: client}
+}
+
+// All retrieves all matchmaking rule sets
+func (g *GameCenterMatchmakingRuleSetsAPI) All(params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterMatchmakingRuleSets", params)
+}
+
+// Get retrieves a matchmaking rule set by ID
+func (g *GameCenterMatchmakingRuleSetsAPI) Get(ruleSetId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterMatchmakingRuleSets/"+ruleSetId, params)
+}
+
+// Create creates a matchmaking rule set for matches of minPlayers to maxPlayers players
+func (g *GameCenterMatchmakingRuleSetsAPI) Create(referenceName string, ruleLanguageVersion, minPlayers, maxPlayers int) (map[string]interface{}, error) {
+	if referenceName == "" {
+		return nil, fmt.Errorf("reference name is required")
+	}
+	if minPlayers <= 0 || maxPlayers < minPlayers {
+		return nil, fmt.Errorf("invalid player range %d-%d", minPlayers, maxPlayers)
+	}
+	if ruleLanguageVersion <= 0 {
+		ruleLanguageVersion = 1
+	}
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "gameCenterMatchmakingRuleSets",
+			"attributes": map[string]interface{}{
+				"referenceName":       referenceName,
+				"ruleLanguageVersion": ruleLanguageVersion,
+				"minPlayers":          minPlayers,
+				"maxPlayers":          maxPlayers,
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PostJSON("/gameCenterMatchmakingRuleSets", data)
+}
+
+// Update changes the player range of a matchmaking rule set
+func (g *GameCenterMatchmakingRuleSetsAPI) Update(ruleSetId string, minPlayers, maxPlayers int) (map[string]interface{}, error) {
+	if minPlayers <= 0 || maxPlayers < minPlayers {
+		return nil, fmt.Errorf("invalid player range %d-%d", minPlayers, maxPlayers)
+	}
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "gameCenterMatchmakingRuleSets",
+			"id":   ruleSetId,
+			"attributes": map[string]int{
+				"minPlayers": minPlayers,
+				"maxPlayers": maxPlayers,
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PatchJSON("/gameCenterMatchmakingRuleSets/"+ruleSetId, data)
+}
+
+// Delete deletes a matchmaking rule set by ID
+func (g *GameCenterMatchmakingRuleSetsAPI) Delete(ruleSetId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterMatchmakingRuleSets/"+ruleSetId, nil)
+}
+
+// Queues retrieves the matchmaking queues using a rule set
+func (g *GameCenterMatchmakingRuleSetsAPI) Queues(ruleSetId string, params map[string]string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Get("/gameCenterMatchmakingRuleSets/"+ruleSetId+"/matchmakingQueues", params)
+}
+
+// Rules retrieves every rule of a rule set, following pagination
+func (g *GameCenterMatchmakingRuleSetsAPI) Rules(ruleSetId string) ([]GameCenterMatchmakingRule, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	var rules []GameCenterMatchmakingRule
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := g.client.GetHTTPClient().Get("/gameCenterMatchmakingRuleSets/"+ruleSetId+"/rules", query)
+		if err != nil {
+			return rules, err
+		}
+		for _, resource := range resourceList(response) {
+			var rule GameCenterMatchmakingRule
+			if err := decodeAttributes(resource, &rule); err != nil {
+				return rules, err
+			}
+			rule.ID = resourceID(resource)
+			rules = append(rules, rule)
+		}
+		query = nextPageParams(response)
+	}
+	return rules, nil
+}
+
+// CreateRule adds a rule to a rule set
+func (g *GameCenterMatchmakingRuleSetsAPI) CreateRule(ruleSetId string, rule GameCenterMatchmakingRule) (map[string]interface{}, error) {
+	if rule.ReferenceName == "" {
+		return nil, fmt.Errorf("reference name is required")
+	}
+	if rule.Type == "" {
+		return nil, fmt.Errorf("rule type is required")
+	}
+	if rule.Expression == "" {
+		return nil, fmt.Errorf("expression is required")
+	}
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "gameCenterMatchmakingRules",
+			"attributes": rule,
+			"relationships": map[string]interface{}{
+				"ruleSet": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterMatchmakingRuleSets",
+						"id":   ruleSetId,
+					},
+				},
+			},
+		},
+	}
+
+	return g.client.GetHTTPClient().PostJSON("/gameCenterMatchmakingRules", data)
+}
+
+// UpdateRule changes the description, expression, and weight of a rule
+func (g *GameCenterMatchmakingRuleSetsAPI) UpdateRule(ruleId string, rule GameCenterMatchmakingRule) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]interface{}{
+		"description": rule.Description,
+		"expression":  rule.Expression,
+	}
+	if rule.Weight != 0 {
+		attributes["weight"] = rule.Weight
+	}
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "gameCenterMatchmakingRules",
+			"id":         ruleId,
+			"attributes": attributes,
+		},
+	}
+
+	return g.client.GetHTTPClient().PatchJSON("/gameCenterMatchmakingRules/"+ruleId, data)
+}
+
+// DeleteRule deletes a rule by ID
+func (g *GameCenterMatchmakingRuleSetsAPI) DeleteRule(ruleId string) (map[string]interface{}, error) {
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return g.client.GetHTTPClient().Delete("/gameCenterMatchmakingRules/"+ruleId, nil)
+}
+
+// SyncRules makes the rules of a rule set match desired, matched by
+// reference name: missing rules are created, changed ones updated, and rules
+// absent from desired deleted. Rules whose type changed are recreated. It
+// returns the reference names of the rules that changed.
+func (g *GameCenterMatchmakingRuleSetsAPI) SyncRules(ruleSetId string, desired []GameCenterMatchmakingRule) ([]string, error) {
+	existing, err := g.Rules(ruleSetId)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]GameCenterMatchmakingRule, len(existing))
+	for _, rule := range existing {
+		byName[rule.ReferenceName] = rule
+	}
+
+	var changed []string
+	wanted := make(map[string]bool, len(desired))
+	for _, rule := range desired {
+		wanted[rule.ReferenceName] = true
+		current, ok := byName[rule.ReferenceName]
+		switch {
+		case !ok:
+			if _, err := g.CreateRule(ruleSetId, rule); err != nil {
+				return changed, fmt.Errorf("failed to create rule %s: %w", rule.ReferenceName, err)
+			}
+		case current.Type != rule.Type:
+			if _, err := g.DeleteRule(current.ID); err != nil {
+				return changed, fmt.Errorf("failed to delete rule %s: %w", rule.ReferenceName, err)
+			}
+			if _, err := g.CreateRule(ruleSetId, rule); err != nil {
+				return changed, fmt.Errorf("failed to create rule %s: %w", rule.ReferenceName, err)
+			}
+		case current.Description != rule.Description || current.Expression != rule.Expression || current.Weight != rule.Weight:
+			if _, err := g.UpdateRule(current.ID, rule); err != nil {
+				return changed, fmt.Errorf("failed to update rule %s: %w", rule.ReferenceName, err)
+			}
+		default:
+			continue
+		}
+		changed = append(changed, rule.ReferenceName)
+	}
+
+	for _, rule := range existing {
+		if wanted[rule.ReferenceName] {
+			continue
+		}
+		if _, err := g.DeleteRule(rule.ID); err != nil {
+			return changed, fmt.Errorf("failed to delete rule %s: %w", rule.ReferenceName, err)
+		}
+		changed = append(changed, rule.ReferenceName)
+	}
+
+	return changed, nil
+}
+
+// Test evaluates simulated match requests against a rule set and returns
+// the matches the rule set would make, without affecting live matchmaking
+func (g *GameCenterMatchmakingRuleSetsAPI) Test(ruleSetId string, requests []GameCenterMatchmakingTestRequest) (map[string]interface{}, error) {
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("at least one test request is required")
+	}
+	if err := g.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	linkages := make([]map[string]string, len(requests))
+	included := make([]map[string]interface{}, len(requests))
+	for i, request := range requests {
+		localId := "${request-" + strconv.Itoa(i) + "}"
+		linkages[i] = map[string]string{
+			"type": "gameCenterMatchmakingTestRequests",
+			"id":   localId,
+		}
+		included[i] = map[string]interface{}{
+			"type":       "gameCenterMatchmakingTestRequests",
+			"id":         localId,
+			"attributes": request,
+		}
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "gameCenterMatchmakingRuleSetTests",
+			"relationships": map[string]interface{}{
+				"matchmakingRuleSet": map[string]interface{}{
+					"data": map[string]string{
+						"type": "gameCenterMatchmakingRuleSets",
+						"id":   ruleSetId,
+					},
+				},
+				"matchmakingRequests": map[string]interface{}{
+					"data": linkages,
+				},
+			},
+		},
+		"included": included,
+	}
+
+	return g.client.GetHTTPClient().PostJSON("/gameCenterMatchmakingRuleSetTests", data)
+}
@@ -0,0 +1,22 @@
+package appstore_test
+
+import (
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+)
+
+// newClient creates a client of a server with a configuration changed by configure
+func newClient(t *testing.T, server *appstoretest.Server, configure func(config *appstore.Config)) *appstore.Client {
+	t.Helper()
+	config := server.Config()
+	if configure != nil {
+		configure(&config)
+	}
+	client, err := appstore.NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
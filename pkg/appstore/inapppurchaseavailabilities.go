@@ -0,0 +1,90 @@
+package appstore
+
+import (
+	"fmt"
+
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// InAppPurchaseAvailabilitiesAPI handles in-app purchase availability operations
+type InAppPurchaseAvailabilitiesAPI struct {
+	client *Client
+}
+
+// NewInAppPurchaseAvailabilitiesAPI creates a new InAppPurchaseAvailabilities API client
+func NewInAppPurchaseAvailabilitiesAPI(client *Client) *InAppPurchaseAvailabilitiesAPI {
+	return &InAppPurchaseAvailabilitiesAPI{client: client}
+}
+
+// Get retrieves the availability of an in-app purchase with all of its
+// territories. An in-app purchase whose availability was never configured
+// returns an empty ProductAvailability.
+func (i *InAppPurchaseAvailabilitiesAPI) Get(iapId string) (ProductAvailability, error) {
+	if err := i.client.EnsureAuth(); err != nil {
+		return ProductAvailability{}, err
+	}
+
+	response, err := i.client.GetHTTPClient().WithAPIVersion(inAppPurchasesAPIVersion).Get("/inAppPurchases/"+iapId+"/inAppPurchaseAvailability", nil)
+	if err != nil {
+		if httpclient.IsNotFound(err) {
+			return ProductAvailability{}, nil
+		}
+		return ProductAvailability{}, err
+	}
+	availability, ok := parseProductAvailability(response)
+	if !ok {
+		return ProductAvailability{}, nil
+	}
+
+	availability.Territories, err = i.client.availableTerritories("/inAppPurchaseAvailabilities/" + availability.ID + "/availableTerritories")
+	return availability, err
+}
+
+// Set replaces the territories an in-app purchase is available in
+func (i *InAppPurchaseAvailabilitiesAPI) Set(iapId string, territories []string, availableInNewTerritories bool) (map[string]interface{}, error) {
+	if len(territories) == 0 {
+		return nil, fmt.Errorf("at least one territory is required")
+	}
+	if err := i.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "inAppPurchaseAvailabilities",
+			"attributes": map[string]bool{
+				"availableInNewTerritories": availableInNewTerritories,
+			},
+			"relationships": map[string]interface{}{
+				"inAppPurchase": map[string]interface{}{
+					"data": map[string]string{
+						"type": "inAppPurchases",
+						"id":   iapId,
+					},
+				},
+				"availableTerritories": territoryLinkages(territories),
+			},
+		},
+	}
+
+	return i.client.GetHTTPClient().PostJSON("/inAppPurchaseAvailabilities", data)
+}
+
+// Expand makes an in-app purchase available in additional territories,
+// keeping its current territories and new territory setting
+func (i *InAppPurchaseAvailabilitiesAPI) Expand(iapId string, territories []string) (map[string]interface{}, error) {
+	current, err := i.Get(iapId)
+	if err != nil {
+		return nil, err
+	}
+	return i.Set(iapId, mergeTerritories(current.Territories, territories, nil), current.AvailableInNewTerritories)
+}
+
+// Restrict removes territories from the availability of an in-app purchase
+func (i *InAppPurchaseAvailabilitiesAPI) Restrict(iapId string, territories []string) (map[string]interface{}, error) {
+	current, err := i.Get(iapId)
+	if err != nil {
+		return nil, err
+	}
+	return i.Set(iapId, mergeTerritories(current.Territories, nil, territories), current.AvailableInNewTerritories)
+}
@@ -0,0 +1,131 @@
+package appstore
+
+import "fmt"
+
+// inAppPurchasesAPIVersion is the API version serving in-app purchase resources
+const inAppPurchasesAPIVersion = "v2"
+
+// InAppPurchaseLocalization represents the localized metadata of an in-app purchase
+type InAppPurchaseLocalization struct {
+	ID          string `json:"-"`
+	Locale      string `json:"locale"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+}
+
+// InAppPurchaseLocalizationsAPI handles in-app purchase localization operations
+type InAppPurchaseLocalizationsAPI struct {
+	client *Client
+}
+
+// NewInAppPurchaseLocalizationsAPI creates a new InAppPurchaseLocalizations API client
+func NewInAppPurchaseLocalizationsAPI(client *Client) *InAppPurchaseLocalizationsAPI {
+	return &InAppPurchaseLocalizationsAPI{client: client}
+}
+
+// All retrieves the localizations of an in-app purchase
+func (i *InAppPurchaseLocalizationsAPI) All(iapId string, params map[string]string) (map[string]interface{}, error) {
+	if err := i.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return i.client.GetHTTPClient().WithAPIVersion(inAppPurchasesAPIVersion).Get("/inAppPurchases/"+iapId+"/inAppPurchaseLocalizations", params)
+}
+
+// List retrieves every localization of an in-app purchase
+func (i *InAppPurchaseLocalizationsAPI) List(iapId string) ([]InAppPurchaseLocalization, error) {
+	var localizations []InAppPurchaseLocalization
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := i.All(iapId, query)
+		if err != nil {
+			return localizations, err
+		}
+		for _, resource := range resourceList(response) {
+			var localization InAppPurchaseLocalization
+			if err := decodeAttributes(resource, &localization); err != nil {
+				return localizations, err
+			}
+			localization.ID = resourceID(resource)
+			localizations = append(localizations, localization)
+		}
+		query = nextPageParams(response)
+	}
+	return localizations, nil
+}
+
+// Get retrieves an in-app purchase localization by ID
+func (i *InAppPurchaseLocalizationsAPI) Get(localizationId string, params map[string]string) (map[string]interface{}, error) {
+	if err := i.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return i.client.GetHTTPClient().Get("/inAppPurchaseLocalizations/"+localizationId, params)
+}
+
+// Create adds a localized display name and description to an in-app purchase
+func (i *InAppPurchaseLocalizationsAPI) Create(iapId, locale, name, description string) (map[string]interface{}, error) {
+	if locale == "" {
+		return nil, fmt.Errorf("locale is required")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := i.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "inAppPurchaseLocalizations",
+			"attributes": map[string]string{
+				"locale":      locale,
+				"name":        name,
+				"description": description,
+			},
+			"relationships": map[string]interface{}{
+				"inAppPurchaseV2": map[string]interface{}{
+					"data": map[string]string{
+						"type": "inAppPurchases",
+						"id":   iapId,
+					},
+				},
+			},
+		},
+	}
+
+	return i.client.GetHTTPClient().PostJSON("/inAppPurchaseLocalizations", data)
+}
+
+// Update changes the display name or description of an in-app purchase localization.
+// Empty values are left unchanged.
+func (i *InAppPurchaseLocalizationsAPI) Update(localizationId, name, description string) (map[string]interface{}, error) {
+	if err := i.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]string{}
+	if name != "" {
+		attributes["name"] = name
+	}
+	if description != "" {
+		attributes["description"] = description
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "inAppPurchaseLocalizations",
+			"id":         localizationId,
+			"attributes": attributes,
+		},
+	}
+
+	return i.client.GetHTTPClient().PatchJSON("/inAppPurchaseLocalizations/"+localizationId, data)
+}
+
+// Delete deletes an in-app purchase localization by ID
+func (i *InAppPurchaseLocalizationsAPI) Delete(localizationId string) (map[string]interface{}, error) {
+	if err := i.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return i.client.GetHTTPClient().Delete("/inAppPurchaseLocalizations/"+localizationId, nil)
+}
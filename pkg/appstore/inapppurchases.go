@@ -0,0 +1,200 @@
+package appstore
+
+import "fmt"
+
+// InAppPurchaseType represents the kind of an in-app purchase
+type InAppPurchaseType string
+
+// In-app purchase types
+const (
+	InAppPurchaseTypeConsumable              InAppPurchaseType = "CONSUMABLE"
+	InAppPurchaseTypeNonConsumable           InAppPurchaseType = "NON_CONSUMABLE"
+	InAppPurchaseTypeNonRenewingSubscription InAppPurchaseType = "NON_RENEWING_SUBSCRIPTION"
+)
+
+// InAppPurchase represents an in-app purchase
+type InAppPurchase struct {
+	ID                string            `json:"-"`
+	Name              string            `json:"name"`
+	ProductID         string            `json:"productId"`
+	InAppPurchaseType InAppPurchaseType `json:"inAppPurchaseType"`
+	State             string            `json:"state"`
+	ReviewNote        string            `json:"reviewNote"`
+	FamilySharable    bool              `json:"familySharable"`
+}
+
+// InAppPurchaseAttributes holds the attributes of a new in-app purchase
+type InAppPurchaseAttributes struct {
+	Name              string            `json:"name"`
+	ProductID         string            `json:"productId"`
+	InAppPurchaseType InAppPurchaseType `json:"inAppPurchaseType"`
+	ReviewNote        string            `json:"reviewNote,omitempty"`
+}
+
+// InAppPurchaseUpdate holds the in-app purchase attributes to change, nil fields are left unchanged
+type InAppPurchaseUpdate struct {
+	Name       *string `json:"name,omitempty"`
+	ReviewNote *string `json:"reviewNote,omitempty"`
+}
+
+// InAppPurchasesAPI handles in-app purchase operations
+type InAppPurchasesAPI struct {
+	client *Client
+}
+
+// NewInAppPurchasesAPI creates a new InAppPurchases API client
+func NewInAppPurchasesAPI(client *Client) *InAppPurchasesAPI {
+	return &InAppPurchasesAPI{client: client}
+}
+
+// All retrieves the in-app purchases of an app
+func (i *InAppPurchasesAPI) All(appId string, params map[string]string) (map[string]interface{}, error) {
+	if err := i.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return i.client.GetHTTPClient().Get("/apps/"+appId+"/inAppPurchasesV2", params)
+}
+
+// List retrieves every in-app purchase of an app, following pagination
+func (i *InAppPurchasesAPI) List(appId string) ([]InAppPurchase, error) {
+	var iaps []InAppPurchase
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := i.All(appId, query)
+		if err != nil {
+			return iaps, err
+		}
+		for _, resource := range resourceList(response) {
+			iap, err := parseInAppPurchase(resource)
+			if err != nil {
+				return iaps, err
+			}
+			iaps = append(iaps, iap)
+		}
+		query = nextPageParams(response)
+	}
+	return iaps, nil
+}
+
+// Get retrieves an in-app purchase by ID
+func (i *InAppPurchasesAPI) Get(iapId string) (InAppPurchase, error) {
+	if err := i.client.EnsureAuth(); err != nil {
+		return InAppPurchase{}, err
+	}
+	response, err := i.client.GetHTTPClient().WithAPIVersion(inAppPurchasesAPIVersion).Get("/inAppPurchases/"+iapId, nil)
+	if err != nil {
+		return InAppPurchase{}, err
+	}
+	return parseInAppPurchaseResponse(response)
+}
+
+// Create creates a new in-app purchase for an app
+func (i *InAppPurchasesAPI) Create(appId string, attributes InAppPurchaseAttributes) (InAppPurchase, error) {
+	if attributes.Name == "" {
+		return InAppPurchase{}, fmt.Errorf("name is required")
+	}
+	if attributes.ProductID == "" {
+		return InAppPurchase{}, fmt.Errorf("product id is required")
+	}
+	if attributes.InAppPurchaseType == "" {
+		return InAppPurchase{}, fmt.Errorf("in-app purchase type is required")
+	}
+	if err := i.client.EnsureAuth(); err != nil {
+		return InAppPurchase{}, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "inAppPurchases",
+			"attributes": attributes,
+			"relationships": map[string]interface{}{
+				"app": map[string]interface{}{
+					"data": map[string]string{
+						"type": "apps",
+						"id":   appId,
+					},
+				},
+			},
+		},
+	}
+
+	response, err := i.client.GetHTTPClient().WithAPIVersion(inAppPurchasesAPIVersion).PostJSON("/inAppPurchases", data)
+	if err != nil {
+		return InAppPurchase{}, err
+	}
+	return parseInAppPurchaseResponse(response)
+}
+
+// Update changes the attributes of an in-app purchase
+func (i *InAppPurchasesAPI) Update(iapId string, update InAppPurchaseUpdate) (InAppPurchase, error) {
+	if err := i.client.EnsureAuth(); err != nil {
+		return InAppPurchase{}, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "inAppPurchases",
+			"id":         iapId,
+			"attributes": update,
+		},
+	}
+
+	response, err := i.client.GetHTTPClient().WithAPIVersion(inAppPurchasesAPIVersion).PatchJSON("/inAppPurchases/"+iapId, data)
+	if err != nil {
+		return InAppPurchase{}, err
+	}
+	return parseInAppPurchaseResponse(response)
+}
+
+// EnableFamilySharing turns on Family Sharing for a non-consumable or
+// non-renewing subscription in-app purchase. Apple does not allow turning it
+// off again, so the call fails with ErrFamilySharingIrreversible unless
+// acknowledgeIrreversible is set. Purchases that already have Family Sharing
+// are returned unchanged.
+func (i *InAppPurchasesAPI) EnableFamilySharing(iapId string, acknowledgeIrreversible bool) (InAppPurchase, error) {
+	iap, err := i.Get(iapId)
+	if err != nil {
+		return InAppPurchase{}, err
+	}
+	if iap.FamilySharable {
+		return iap, nil
+	}
+	if !acknowledgeIrreversible {
+		return iap, ErrFamilySharingIrreversible
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "inAppPurchases",
+			"id":   iapId,
+			"attributes": map[string]bool{
+				"familySharable": true,
+			},
+		},
+	}
+
+	response, err := i.client.GetHTTPClient().WithAPIVersion(inAppPurchasesAPIVersion).PatchJSON("/inAppPurchases/"+iapId, data)
+	if err != nil {
+		return InAppPurchase{}, err
+	}
+	return parseInAppPurchaseResponse(response)
+}
+
+// parseInAppPurchaseResponse converts a single in-app purchase response to an InAppPurchase
+func parseInAppPurchaseResponse(response map[string]interface{}) (InAppPurchase, error) {
+	resource, err := responseResource(response)
+	if err != nil {
+		return InAppPurchase{}, err
+	}
+	return parseInAppPurchase(resource)
+}
+
+// parseInAppPurchase converts an inAppPurchases resource object to an InAppPurchase
+func parseInAppPurchase(resource map[string]interface{}) (InAppPurchase, error) {
+	var iap InAppPurchase
+	if err := decodeAttributes(resource, &iap); err != nil {
+		return InAppPurchase{}, err
+	}
+	iap.ID = resourceID(resource)
+	return iap, nil
+}
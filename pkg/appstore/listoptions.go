@@ -0,0 +1,161 @@
+package appstore
+
+import "appstore-connect-api/pkg/jsonapi"
+
+// DeviceStatus is the status of a registered device
+type DeviceStatus string
+
+// Device statuses
+const (
+	DeviceStatusEnabled  DeviceStatus = "ENABLED"
+	DeviceStatusDisabled DeviceStatus = "DISABLED"
+)
+
+// ProfileState is the state of a provisioning profile
+type ProfileState string
+
+// Profile states
+const (
+	ProfileStateActive  ProfileState = "ACTIVE"
+	ProfileStateInvalid ProfileState = "INVALID"
+)
+
+// DeviceListOptions filters and sorts the devices of List, ListPage, All, and
+// AllPages:
+//
+//	devices, err := client.Devices().List(appstore.DeviceListOptions{
+//		Platform: appstore.BundleIdPlatformIOS,
+//		Status:   appstore.DeviceStatusEnabled,
+//	}.Query())
+type DeviceListOptions struct {
+	Platform BundleIdPlatform
+	Status   DeviceStatus
+	UDIDs    []string
+	Names    []string
+	IDs      []string
+	// Limit is the number of resources per page, the API default when zero
+	Limit int
+	// Sort lists the fields to sort by, in order, such as "name". A field
+	// prefixed with "-" sorts in descending order.
+	Sort []string
+	// Extra holds raw parameters without a field, such as fields[devices].
+	// They override the parameters of the fields.
+	Extra map[string]string
+}
+
+// Query returns the list parameters of the options
+func (o DeviceListOptions) Query() jsonapi.Query {
+	q := jsonapi.NewQuery()
+	filter(q, "platform", o.Platform)
+	filter(q, "status", o.Status)
+	filter(q, "udid", o.UDIDs...)
+	filter(q, "name", o.Names...)
+	filter(q, "id", o.IDs...)
+	return listQuery(q, o.Limit, o.Sort, o.Extra)
+}
+
+// CertificateListOptions filters and sorts the certificates of List,
+// ListPage, All, and AllPages
+type CertificateListOptions struct {
+	CertificateTypes []CertificateType
+	DisplayNames     []string
+	SerialNumbers    []string
+	IDs              []string
+	// Limit, Sort, and Extra work as in DeviceListOptions
+	Limit int
+	Sort  []string
+	Extra map[string]string
+}
+
+// Query returns the list parameters of the options
+func (o CertificateListOptions) Query() jsonapi.Query {
+	q := jsonapi.NewQuery()
+	filter(q, "certificateType", o.CertificateTypes...)
+	filter(q, "displayName", o.DisplayNames...)
+	filter(q, "serialNumber", o.SerialNumbers...)
+	filter(q, "id", o.IDs...)
+	return listQuery(q, o.Limit, o.Sort, o.Extra)
+}
+
+// BundleIdListOptions filters and sorts the bundle IDs of List, ListPage,
+// All, and AllPages
+type BundleIdListOptions struct {
+	// Identifiers also match longer identifiers that start with them
+	Identifiers []string
+	Names       []string
+	Platforms   []BundleIdPlatform
+	SeedIDs     []string
+	IDs         []string
+	// Include lists related resources to include, such as "profiles" and
+	// "bundleIdCapabilities"
+	Include []string
+	// Limit, Sort, and Extra work as in DeviceListOptions
+	Limit int
+	Sort  []string
+	Extra map[string]string
+}
+
+// Query returns the list parameters of the options
+func (o BundleIdListOptions) Query() jsonapi.Query {
+	q := jsonapi.NewQuery()
+	filter(q, "identifier", o.Identifiers...)
+	filter(q, "name", o.Names...)
+	filter(q, "platform", o.Platforms...)
+	filter(q, "seedId", o.SeedIDs...)
+	filter(q, "id", o.IDs...)
+	q.Include(o.Include...)
+	return listQuery(q, o.Limit, o.Sort, o.Extra)
+}
+
+// ProfileListOptions filters and sorts the profiles of List, ListPage,
+// Query, and AllPages
+type ProfileListOptions struct {
+	Names        []string
+	ProfileTypes []ProfileType
+	States       []ProfileState
+	IDs          []string
+	// Include lists related resources to include, such as "bundleId",
+	// "certificates", and "devices"
+	Include []string
+	// Limit, Sort, and Extra work as in DeviceListOptions
+	Limit int
+	Sort  []string
+	Extra map[string]string
+}
+
+// Query returns the list parameters of the options
+func (o ProfileListOptions) Query() jsonapi.Query {
+	q := jsonapi.NewQuery()
+	filter(q, "name", o.Names...)
+	filter(q, "profileType", o.ProfileTypes...)
+	filter(q, "profileState", o.States...)
+	filter(q, "id", o.IDs...)
+	q.Include(o.Include...)
+	return listQuery(q, o.Limit, o.Sort, o.Extra)
+}
+
+// filter sets filter[field] to the non-empty values, if there are any
+func filter[T ~string](q jsonapi.Query, field string, values ...T) {
+	var set []string
+	for _, value := range values {
+		if value != "" {
+			set = append(set, string(value))
+		}
+	}
+	if len(set) > 0 {
+		q.Filter(field, set...)
+	}
+}
+
+// listQuery adds the limit, sort, and extra parameters shared by the list
+// options to a query
+func listQuery(q jsonapi.Query, limit int, sort []string, extra map[string]string) jsonapi.Query {
+	if limit > 0 {
+		q.Limit(limit)
+	}
+	q.Sort(sort...)
+	for name, value := range extra {
+		q.Set(name, value)
+	}
+	return q
+}
@@ -0,0 +1,347 @@
+package appstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Translations holds translated strings by locale and key
+type Translations map[string]map[string]string
+
+// set stores a translated string
+func (t Translations) set(locale, key, value string) {
+	if t[locale] == nil {
+		t[locale] = make(map[string]string)
+	}
+	t[locale][key] = value
+}
+
+// merge adds the strings of other, replacing existing keys
+func (t Translations) merge(other Translations) {
+	for locale, entries := range other {
+		for key, value := range entries {
+			t.set(locale, key, value)
+		}
+	}
+}
+
+// LoadTranslations reads Xcode localization files: .xliff files and .xcloc
+// bundles from Export Localizations, .strings files inside <locale>.lproj
+// directories, and .xcstrings string catalogs. Directories are searched
+// recursively. Later paths override keys of earlier ones.
+func LoadTranslations(paths ...string) (Translations, error) {
+	translations := make(Translations)
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+
+			var loaded Translations
+			switch filepath.Ext(path) {
+			case ".xliff", ".xlf":
+				loaded, err = loadXLIFF(path)
+			case ".strings":
+				loaded, err = loadStrings(path)
+			case ".xcstrings":
+				loaded, err = loadStringCatalog(path)
+			default:
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to load %s: %w", path, err)
+			}
+			translations.merge(loaded)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return translations, nil
+}
+
+// loadXLIFF reads the target strings of an XLIFF 1.2 file, and the source
+// strings for the source language
+func loadXLIFF(path string) (Translations, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var document struct {
+		Files []struct {
+			SourceLanguage string `xml:"source-language,attr"`
+			TargetLanguage string `xml:"target-language,attr"`
+			Units          []struct {
+				ID     string  `xml:"id,attr"`
+				Source string  `xml:"source"`
+				Target *string `xml:"target"`
+			} `xml:"body>trans-unit"`
+		} `xml:"file"`
+	}
+	if err := xml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("invalid XLIFF: %w", err)
+	}
+
+	translations := make(Translations)
+	for _, file := range document.Files {
+		for _, unit := range file.Units {
+			if file.SourceLanguage != "" {
+				translations.set(file.SourceLanguage, unit.ID, unit.Source)
+			}
+			if file.TargetLanguage != "" && unit.Target != nil {
+				translations.set(file.TargetLanguage, unit.ID, *unit.Target)
+			}
+		}
+	}
+	return translations, nil
+}
+
+// loadStrings reads a .strings file, taking the locale from its
+// <locale>.lproj directory
+func loadStrings(path string) (Translations, error) {
+	locale := strings.TrimSuffix(filepath.Base(filepath.Dir(path)), ".lproj")
+	if locale == filepath.Base(filepath.Dir(path)) || locale == "Base" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := ParseStrings(content)
+	if err != nil {
+		return nil, err
+	}
+	return Translations{locale: entries}, nil
+}
+
+// loadStringCatalog reads the translated strings of an .xcstrings string catalog
+func loadStringCatalog(path string) (Translations, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var catalog struct {
+		Strings map[string]struct {
+			Localizations map[string]struct {
+				StringUnit *struct {
+					State string `json:"state"`
+					Value string `json:"value"`
+				} `json:"stringUnit"`
+			} `json:"localizations"`
+		} `json:"strings"`
+	}
+	if err := json.Unmarshal(content, &catalog); err != nil {
+		return nil, fmt.Errorf("invalid string catalog: %w", err)
+	}
+
+	translations := make(Translations)
+	for key, entry := range catalog.Strings {
+		for locale, localization := range entry.Localizations {
+			if unit := localization.StringUnit; unit != nil && unit.State != "new" {
+				translations.set(locale, key, unit.Value)
+			}
+		}
+	}
+	return translations, nil
+}
+
+// ParseStrings parses the content of a .strings file, in UTF-8 or UTF-16 with
+// a byte order mark, into its key-value pairs
+func ParseStrings(content []byte) (map[string]string, error) {
+	text, err := decodeStringsFile(content)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &stringsParser{text: []rune(text)}
+	entries := make(map[string]string)
+	for {
+		p.skipSpace()
+		if p.done() {
+			return entries, nil
+		}
+		key, err := p.token()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.peek() == ';' {
+			// "key"; is shorthand for "key" = "key";
+			p.pos++
+			entries[key] = key
+			continue
+		}
+		if err := p.expect('='); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		value, err := p.token()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if err := p.expect(';'); err != nil {
+			return nil, err
+		}
+		entries[key] = value
+	}
+}
+
+// decodeStringsFile converts the content of a .strings file to a string
+func decodeStringsFile(content []byte) (string, error) {
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(content, []byte{0xFF, 0xFE}):
+		order = binary.LittleEndian
+	case bytes.HasPrefix(content, []byte{0xFE, 0xFF}):
+		order = binary.BigEndian
+	default:
+		content = bytes.TrimPrefix(content, []byte{0xEF, 0xBB, 0xBF})
+		if !utf8.Valid(content) {
+			return "", fmt.Errorf("strings file is not valid UTF-8")
+		}
+		return string(content), nil
+	}
+
+	content = content[2:]
+	if len(content)%2 != 0 {
+		return "", fmt.Errorf("strings file is not valid UTF-16")
+	}
+	units := make([]uint16, len(content)/2)
+	for i := range units {
+		units[i] = order.Uint16(content[2*i:])
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// stringsParser reads the tokens of a .strings file
+type stringsParser struct {
+	text []rune
+	pos  int
+}
+
+func (p *stringsParser) done() bool {
+	return p.pos >= len(p.text)
+}
+
+func (p *stringsParser) peek() rune {
+	if p.done() {
+		return 0
+	}
+	return p.text[p.pos]
+}
+
+// line returns the line of the current position, for error messages
+func (p *stringsParser) line() int {
+	return strings.Count(string(p.text[:p.pos]), "\n") + 1
+}
+
+func (p *stringsParser) expect(r rune) error {
+	if p.peek() != r {
+		return fmt.Errorf("line %d: expected %q", p.line(), r)
+	}
+	p.pos++
+	return nil
+}
+
+// hasPrefix reports whether the text at the current position starts with prefix
+func (p *stringsParser) hasPrefix(prefix string) bool {
+	end := p.pos + len(prefix)
+	return end <= len(p.text) && string(p.text[p.pos:end]) == prefix
+}
+
+// skipSpace skips whitespace and comments
+func (p *stringsParser) skipSpace() {
+	for !p.done() {
+		switch {
+		case p.peek() == ' ' || p.peek() == '\t' || p.peek() == '\n' || p.peek() == '\r':
+			p.pos++
+		case p.hasPrefix("//"):
+			for !p.done() && p.peek() != '\n' {
+				p.pos++
+			}
+		case p.hasPrefix("/*"):
+			p.pos += 2
+			for !p.done() && !p.hasPrefix("*/") {
+				p.pos++
+			}
+			p.pos = min(p.pos+2, len(p.text))
+		default:
+			return
+		}
+	}
+}
+
+// token reads a quoted string or an unquoted word
+func (p *stringsParser) token() (string, error) {
+	if p.peek() != '"' {
+		start := p.pos
+		for !p.done() && (p.peek() == '_' || p.peek() == '.' || p.peek() == '-' || isAlphanumeric(p.peek())) {
+			p.pos++
+		}
+		if start == p.pos {
+			return "", fmt.Errorf("line %d: expected string", p.line())
+		}
+		return string(p.text[start:p.pos]), nil
+	}
+
+	p.pos++
+	var value strings.Builder
+	for {
+		if p.done() {
+			return "", fmt.Errorf("line %d: unterminated string", p.line())
+		}
+		r := p.text[p.pos]
+		p.pos++
+		switch r {
+		case '"':
+			return value.String(), nil
+		case '\\':
+			if p.done() {
+				return "", fmt.Errorf("line %d: unterminated string", p.line())
+			}
+			escaped := p.text[p.pos]
+			p.pos++
+			switch escaped {
+			case 'n':
+				value.WriteRune('\n')
+			case 't':
+				value.WriteRune('\t')
+			case 'r':
+				value.WriteRune('\r')
+			case 'U', 'u':
+				if p.pos+4 > len(p.text) {
+					return "", fmt.Errorf("line %d: invalid unicode escape", p.line())
+				}
+				code, err := strconv.ParseUint(string(p.text[p.pos:p.pos+4]), 16, 32)
+				if err != nil {
+					return "", fmt.Errorf("line %d: invalid unicode escape", p.line())
+				}
+				p.pos += 4
+				value.WriteRune(rune(code))
+			default:
+				value.WriteRune(escaped)
+			}
+		default:
+			value.WriteRune(r)
+		}
+	}
+}
+
+func isAlphanumeric(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
@@ -0,0 +1,223 @@
+package appstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// storeLocales maps language codes used by Xcode to the App Store Connect
+// locale of the language when they differ
+var storeLocales = map[string]string{
+	"ar":     "ar-SA",
+	"de":     "de-DE",
+	"en":     "en-US",
+	"es":     "es-ES",
+	"es-419": "es-MX",
+	"fr":     "fr-FR",
+	"nb":     "no",
+	"nl":     "nl-NL",
+	"pt":     "pt-PT",
+	"zh-HK":  "zh-Hant",
+	"zh-TW":  "zh-Hant",
+	"zh-CN":  "zh-Hans",
+}
+
+// StoreLocale returns the App Store Connect locale of an Xcode locale, such
+// as de-DE for de, accepting underscores as in pt_BR
+func StoreLocale(locale string) string {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	if storeLocale, ok := storeLocales[locale]; ok {
+		return storeLocale
+	}
+	return locale
+}
+
+// LocalizationMapping maps translation keys to App Store metadata
+type LocalizationMapping struct {
+	// Version maps App Store version localization attributes (description,
+	// keywords, marketingUrl, promotionalText, supportUrl, whatsNew) to the
+	// translation keys holding their text
+	Version map[string]string
+	// WhatToTest is the translation key of the TestFlight "what to test" notes
+	WhatToTest string
+	// Locales maps translation locales to App Store Connect locales, taking
+	// precedence over StoreLocale. Mapping a locale to "" skips it.
+	Locales map[string]string
+}
+
+// DefaultLocalizationMapping returns a mapping reading App Store metadata
+// from keys such as AppStore.description and the TestFlight notes from
+// TestFlight.whatToTest, kept in a dedicated strings table of the app
+func DefaultLocalizationMapping() LocalizationMapping {
+	return LocalizationMapping{
+		Version: map[string]string{
+			"description":     "AppStore.description",
+			"keywords":        "AppStore.keywords",
+			"marketingUrl":    "AppStore.marketingUrl",
+			"promotionalText": "AppStore.promotionalText",
+			"supportUrl":      "AppStore.supportUrl",
+			"whatsNew":        "AppStore.whatsNew",
+		},
+		WhatToTest: "TestFlight.whatToTest",
+	}
+}
+
+// locale returns the App Store Connect locale of a translation locale
+func (m LocalizationMapping) locale(locale string) string {
+	if storeLocale, ok := m.Locales[locale]; ok {
+		return storeLocale
+	}
+	return StoreLocale(locale)
+}
+
+// LocalizationSync pushes translations exported from Xcode to App Store
+// version localizations and TestFlight build localizations, so store text
+// and in-app text share one translation pipeline. Only locales whose text
+// changed are updated.
+type LocalizationSync struct {
+	client  *Client
+	mapping LocalizationMapping
+}
+
+// NewLocalizationSync creates a localization sync with a mapping, see DefaultLocalizationMapping
+func NewLocalizationSync(client *Client, mapping LocalizationMapping) *LocalizationSync {
+	return &LocalizationSync{client: client, mapping: mapping}
+}
+
+// VersionTexts returns the App Store version attributes found in translations
+// by App Store Connect locale. Locales without any mapped key are omitted.
+func (l *LocalizationSync) VersionTexts(translations Translations) map[string]map[string]string {
+	texts := make(map[string]map[string]string)
+	for _, locale := range sortedKeys(translations) {
+		storeLocale := l.mapping.locale(locale)
+		if storeLocale == "" {
+			continue
+		}
+		for attribute, key := range l.mapping.Version {
+			value, ok := translations[locale][key]
+			if !ok {
+				continue
+			}
+			if texts[storeLocale] == nil {
+				texts[storeLocale] = make(map[string]string)
+			}
+			texts[storeLocale][attribute] = value
+		}
+	}
+	return texts
+}
+
+// SyncVersion creates or updates the localizations of an App Store version
+// from translations and returns the locales that were changed. Only changed
+// attributes are sent, and locales without translations are left untouched.
+func (l *LocalizationSync) SyncVersion(appStoreVersionId string, translations Translations) ([]string, error) {
+	api := NewAppStoreVersionLocalizationsAPI(l.client)
+	existing, err := api.List(appStoreVersionId)
+	if err != nil {
+		return nil, err
+	}
+	byLocale := make(map[string]AppStoreVersionLocalization, len(existing))
+	for _, localization := range existing {
+		byLocale[localization.Locale] = localization
+	}
+
+	texts := l.VersionTexts(translations)
+	var changed []string
+	for _, locale := range sortedKeys(texts) {
+		desired := texts[locale]
+		current, ok := byLocale[locale]
+		if !ok {
+			localization := versionLocalization(desired)
+			localization.Locale = locale
+			if _, err := api.Create(appStoreVersionId, localization); err != nil {
+				return changed, fmt.Errorf("failed to create %s localization: %w", locale, err)
+			}
+			changed = append(changed, locale)
+			continue
+		}
+
+		updates := make(map[string]string)
+		currentAttributes := versionAttributes(current)
+		for attribute, value := range desired {
+			if currentAttributes[attribute] != value {
+				updates[attribute] = value
+			}
+		}
+		if len(updates) == 0 {
+			continue
+		}
+		if _, err := api.Update(current.ID, updates); err != nil {
+			return changed, fmt.Errorf("failed to update %s localization: %w", locale, err)
+		}
+		changed = append(changed, locale)
+	}
+	return changed, nil
+}
+
+// SyncBuild creates or updates the TestFlight "what to test" notes of a
+// build from translations and returns the locales that were changed
+func (l *LocalizationSync) SyncBuild(buildId string, translations Translations) ([]string, error) {
+	if l.mapping.WhatToTest == "" {
+		return nil, fmt.Errorf("what to test key is required")
+	}
+	notes := make(map[string]string)
+	for _, locale := range sortedKeys(translations) {
+		storeLocale := l.mapping.locale(locale)
+		if value, ok := translations[locale][l.mapping.WhatToTest]; ok && storeLocale != "" {
+			notes[storeLocale] = value
+		}
+	}
+
+	api := NewBetaBuildLocalizationsAPI(l.client)
+	existing, err := api.List(buildId)
+	if err != nil {
+		return nil, err
+	}
+	byLocale := make(map[string]BetaBuildLocalization, len(existing))
+	for _, localization := range existing {
+		byLocale[localization.Locale] = localization
+	}
+
+	var changed []string
+	for _, locale := range sortedKeys(notes) {
+		current, ok := byLocale[locale]
+		switch {
+		case !ok:
+			if _, err := api.Create(buildId, locale, notes[locale]); err != nil {
+				return changed, fmt.Errorf("failed to create %s localization: %w", locale, err)
+			}
+		case current.WhatsNew != notes[locale]:
+			if _, err := api.Update(current.ID, notes[locale]); err != nil {
+				return changed, fmt.Errorf("failed to update %s localization: %w", locale, err)
+			}
+		default:
+			continue
+		}
+		changed = append(changed, locale)
+	}
+	return changed, nil
+}
+
+// versionAttributes returns the text attributes of a version localization by attribute name
+func versionAttributes(localization AppStoreVersionLocalization) map[string]string {
+	return map[string]string{
+		"description":     localization.Description,
+		"keywords":        localization.Keywords,
+		"marketingUrl":    localization.MarketingURL,
+		"promotionalText": localization.PromotionalText,
+		"supportUrl":      localization.SupportURL,
+		"whatsNew":        localization.WhatsNew,
+	}
+}
+
+// versionLocalization builds a version localization from attributes by name
+func versionLocalization(attributes map[string]string) AppStoreVersionLocalization {
+	return AppStoreVersionLocalization{
+		Description:     attributes["description"],
+		Keywords:        attributes["keywords"],
+		MarketingURL:    attributes["marketingUrl"],
+		PromotionalText: attributes["promotionalText"],
+		SupportURL:      attributes["supportUrl"],
+		WhatsNew:        attributes["whatsNew"],
+	}
+}
@@ -0,0 +1,81 @@
+package appstore
+
+import "fmt"
+
+// MarketplaceSearchDetailsAPI handles the catalog URLs alternative
+// marketplaces use to list an app in their search results
+type MarketplaceSearchDetailsAPI struct {
+	client *Client
+}
+
+// NewMarketplaceSearchDetailsAPI creates a new MarketplaceSearchDetails API client
+func NewMarketplaceSearchDetailsAPI(client *Client) *MarketplaceSearchDetailsAPI {
+	return &MarketplaceSearchDetailsAPI{client: client}
+}
+
+// Get retrieves the marketplace search detail of an app
+func (m *MarketplaceSearchDetailsAPI) Get(appId string, params map[string]string) (map[string]interface{}, error) {
+	if err := m.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return m.client.GetHTTPClient().Get("/apps/"+appId+"/marketplaceSearchDetail", params)
+}
+
+// Create sets the catalog URL of an app
+func (m *MarketplaceSearchDetailsAPI) Create(appId, catalogUrl string) (map[string]interface{}, error) {
+	if catalogUrl == "" {
+		return nil, fmt.Errorf("catalog url is required")
+	}
+	if err := m.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "marketplaceSearchDetails",
+			"attributes": map[string]string{
+				"catalogUrl": catalogUrl,
+			},
+			"relationships": map[string]interface{}{
+				"app": map[string]interface{}{
+					"data": map[string]string{
+						"type": "apps",
+						"id":   appId,
+					},
+				},
+			},
+		},
+	}
+
+	return m.client.GetHTTPClient().PostJSON("/marketplaceSearchDetails", data)
+}
+
+// Update changes the catalog URL of a marketplace search detail
+func (m *MarketplaceSearchDetailsAPI) Update(searchDetailId, catalogUrl string) (map[string]interface{}, error) {
+	if catalogUrl == "" {
+		return nil, fmt.Errorf("catalog url is required")
+	}
+	if err := m.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "marketplaceSearchDetails",
+			"id":   searchDetailId,
+			"attributes": map[string]string{
+				"catalogUrl": catalogUrl,
+			},
+		},
+	}
+
+	return m.client.GetHTTPClient().PatchJSON("/marketplaceSearchDetails/"+searchDetailId, data)
+}
+
+// Delete deletes a marketplace search detail by ID
+func (m *MarketplaceSearchDetailsAPI) Delete(searchDetailId string) (map[string]interface{}, error) {
+	if err := m.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return m.client.GetHTTPClient().Delete("/marketplaceSearchDetails/"+searchDetailId, nil)
+}
@@ -0,0 +1,79 @@
+package appstore
+
+import "fmt"
+
+// MarketplaceWebhooksAPI handles the webhooks through which Apple notifies
+// alternative marketplace operators of app events
+type MarketplaceWebhooksAPI struct {
+	client *Client
+}
+
+// NewMarketplaceWebhooksAPI creates a new MarketplaceWebhooks API client
+func NewMarketplaceWebhooksAPI(client *Client) *MarketplaceWebhooksAPI {
+	return &MarketplaceWebhooksAPI{client: client}
+}
+
+// All retrieves all marketplace webhooks
+func (m *MarketplaceWebhooksAPI) All(params map[string]string) (map[string]interface{}, error) {
+	if err := m.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return m.client.GetHTTPClient().Get("/marketplaceWebhooks", params)
+}
+
+// Create registers a webhook endpoint with the secret used to sign its events
+func (m *MarketplaceWebhooksAPI) Create(endpointUrl, secret string) (map[string]interface{}, error) {
+	if endpointUrl == "" {
+		return nil, fmt.Errorf("endpoint url is required")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("secret is required")
+	}
+	if err := m.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "marketplaceWebhooks",
+			"attributes": map[string]string{
+				"endpointUrl": endpointUrl,
+				"secret":      secret,
+			},
+		},
+	}
+
+	return m.client.GetHTTPClient().PostJSON("/marketplaceWebhooks", data)
+}
+
+// Update changes the endpoint or rotates the secret of a webhook, empty values are left unchanged
+func (m *MarketplaceWebhooksAPI) Update(webhookId, endpointUrl, secret string) (map[string]interface{}, error) {
+	if err := m.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := make(map[string]string)
+	if endpointUrl != "" {
+		attributes["endpointUrl"] = endpointUrl
+	}
+	if secret != "" {
+		attributes["secret"] = secret
+	}
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "marketplaceWebhooks",
+			"id":         webhookId,
+			"attributes": attributes,
+		},
+	}
+
+	return m.client.GetHTTPClient().PatchJSON("/marketplaceWebhooks/"+webhookId, data)
+}
+
+// Delete deletes a marketplace webhook by ID
+func (m *MarketplaceWebhooksAPI) Delete(webhookId string) (map[string]interface{}, error) {
+	if err := m.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return m.client.GetHTTPClient().Delete("/marketplaceWebhooks/"+webhookId, nil)
+}
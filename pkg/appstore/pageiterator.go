@@ -0,0 +1,142 @@
+package appstore
+
+import (
+	"context"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// pageResult is a fetched page or the error fetching it
+type pageResult struct {
+	response map[string]interface{}
+	err      error
+}
+
+// PageIterator walks the pages of a list endpoint by following links.next.
+// With Prefetch set, the next page is requested while the caller processes
+// the current one.
+//
+//	it := appstore.NewPageIterator(client, "/devices", nil)
+//	it.Prefetch = true
+//	for it.Next() {
+//		for _, device := range it.Resources() { ... }
+//	}
+//	if err := it.Err(); err != nil { ... }
+type PageIterator struct {
+	// Prefetch requests the next page in the background when a page is returned
+	Prefetch bool
+
+	client   *Client
+	path     string
+	query    map[string]string
+	pending  chan pageResult
+	response map[string]interface{}
+	err      error
+}
+
+// NewPageIterator creates an iterator over the pages of a list endpoint,
+// requesting 200 resources per page unless params set a limit
+func NewPageIterator(client *Client, path string, params map[string]string) *PageIterator {
+	query := map[string]string{"limit": "200"}
+	for k, v := range params {
+		query[k] = v
+	}
+	return &PageIterator{client: client, path: path, query: query}
+}
+
+// Next fetches the next page and reports whether there is one. It returns
+// false when all pages have been read or a request failed, see Err.
+func (it *PageIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	var result pageResult
+	switch {
+	case it.pending != nil:
+		result = <-it.pending
+		it.pending = nil
+	case it.query != nil:
+		result = it.fetch(it.query)
+	default:
+		return false
+	}
+	if result.err != nil {
+		it.err = result.err
+		it.response = nil
+		return false
+	}
+
+	it.response = result.response
+	it.query = nextPageParams(result.response)
+	if it.Prefetch && it.query != nil {
+		query := it.query
+		it.query = nil
+		it.pending = make(chan pageResult, 1)
+		go func(pending chan<- pageResult) {
+			pending <- it.fetch(query)
+		}(it.pending)
+	}
+	return true
+}
+
+// Response returns the current page response, including its included resources and paging metadata
+func (it *PageIterator) Response() map[string]interface{} {
+	return it.response
+}
+
+// PageInfo returns the paging metadata of the current page, such as the
+// total number of resources for a progress bar
+func (it *PageIterator) PageInfo() jsonapi.PageInfo {
+	return jsonapi.Paging(it.response)
+}
+
+// Resources returns the resource objects of the current page
+func (it *PageIterator) Resources() []map[string]interface{} {
+	return resourceList(it.response)
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// Stream delivers the resource objects of every page over a channel,
+// prefetching the next page while the current one is consumed. The resource
+// channel is closed when all pages have been read, the context is cancelled,
+// or a request failed; the error channel then receives the error, if any,
+// and is closed.
+func (it *PageIterator) Stream(ctx context.Context) (<-chan map[string]interface{}, <-chan error) {
+	resources := make(chan map[string]interface{})
+	errs := make(chan error, 1)
+	it.Prefetch = true
+	it.client = it.client.WithContext(ctx)
+
+	go func() {
+		defer close(errs)
+		defer close(resources)
+		for it.Next() {
+			for _, resource := range it.Resources() {
+				select {
+				case resources <- resource:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+		}
+		if err := it.Err(); err != nil {
+			errs <- err
+		}
+	}()
+	return resources, errs
+}
+
+// fetch requests a page
+func (it *PageIterator) fetch(query map[string]string) pageResult {
+	if err := it.client.EnsureAuth(); err != nil {
+		return pageResult{err: err}
+	}
+	response, err := it.client.GetHTTPClient().Get(it.path, query)
+	return pageResult{response: response, err: err}
+}
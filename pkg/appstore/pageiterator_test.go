@@ -0,0 +1,111 @@
+package appstore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// newDeviceServer starts a server holding count devices
+func newDeviceServer(count int) *appstoretest.Server {
+	devices := make([]appstoretest.Resource, count)
+	for i := range devices {
+		devices[i] = appstoretest.Device(fmt.Sprintf("DEVICE%04d", i), fmt.Sprintf("Device %d", i), fmt.Sprintf("UDID-%04d", i), "IPHONE")
+	}
+	return appstoretest.NewServer(devices...)
+}
+
+func TestPageIterator(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("prefetch %v", prefetch), func(t *testing.T) {
+			server := newDeviceServer(5)
+			defer server.Close()
+
+			it := appstore.NewPageIterator(newClient(t, server, nil), "/devices", map[string]string{"limit": "2"})
+			it.Prefetch = prefetch
+			var pages, devices int
+			for it.Next() {
+				pages++
+				devices += len(it.Resources())
+				if total := it.PageInfo().Total; total != 5 {
+					t.Errorf("page %d reports a total of %d, want 5", pages, total)
+				}
+			}
+			if err := it.Err(); err != nil {
+				t.Fatalf("Err: %v", err)
+			}
+			if pages != 3 || devices != 5 {
+				t.Errorf("got %d devices on %d pages, want 5 on 3", devices, pages)
+			}
+			if it.Next() {
+				t.Error("Next reported a page after the last one")
+			}
+		})
+	}
+}
+
+func TestPageIteratorDefaultLimit(t *testing.T) {
+	server := newDeviceServer(1)
+	defer server.Close()
+
+	it := appstore.NewPageIterator(newClient(t, server, nil), "/devices", nil)
+	for it.Next() {
+	}
+	if got := server.Requests()[0].Query.Get("limit"); got != "200" {
+		t.Errorf("got limit %q, want 200", got)
+	}
+}
+
+func TestPageIteratorError(t *testing.T) {
+	server := newDeviceServer(1)
+	defer server.Close()
+	server.Handle(http.MethodGet, "/devices", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	it := appstore.NewPageIterator(newClient(t, server, nil), "/devices", nil)
+	if it.Next() {
+		t.Fatal("Next reported a page of a failed request")
+	}
+	if !httpclient.IsServerError(it.Err()) {
+		t.Errorf("got %v, want the 500 of the request", it.Err())
+	}
+}
+
+func TestPageIteratorStream(t *testing.T) {
+	server := newDeviceServer(5)
+	defer server.Close()
+
+	it := appstore.NewPageIterator(newClient(t, server, nil), "/devices", map[string]string{"limit": "2"})
+	resources, errs := it.Stream(context.Background())
+	seen := make(map[string]bool)
+	for resource := range resources {
+		seen[resource["id"].(string)] = true
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if len(seen) != 5 {
+		t.Errorf("got %d devices, want 5", len(seen))
+	}
+}
+
+func TestPageIteratorStreamCancel(t *testing.T) {
+	server := newDeviceServer(5)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := appstore.NewPageIterator(newClient(t, server, nil), "/devices", map[string]string{"limit": "2"})
+	resources, errs := it.Stream(ctx)
+	<-resources
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+}
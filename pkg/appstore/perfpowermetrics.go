@@ -0,0 +1,48 @@
+package appstore
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// xcodeMetricsContentType is the media type of power and performance metrics
+const xcodeMetricsContentType = "application/vnd.apple.xcode-metrics+json"
+
+// PerfPowerMetricsAPI handles power and performance metric operations
+type PerfPowerMetricsAPI struct {
+	client *Client
+}
+
+// NewPerfPowerMetricsAPI creates a new PerfPowerMetrics API client
+func NewPerfPowerMetricsAPI(client *Client) *PerfPowerMetricsAPI {
+	return &PerfPowerMetricsAPI{client: client}
+}
+
+// AppMetrics retrieves the power and performance metrics of an app, including
+// the goal ranges Apple recommends for each metric
+func (p *PerfPowerMetricsAPI) AppMetrics(appId string, params map[string]string) (map[string]interface{}, error) {
+	return p.get("/apps/"+appId+"/perfPowerMetrics", params)
+}
+
+// BuildMetrics retrieves the power and performance metrics of a build
+func (p *PerfPowerMetricsAPI) BuildMetrics(buildId string, params map[string]string) (map[string]interface{}, error) {
+	return p.get("/builds/"+buildId+"/perfPowerMetrics", params)
+}
+
+// get requests an Xcode metrics document and decodes it
+func (p *PerfPowerMetricsAPI) get(path string, params map[string]string) (map[string]interface{}, error) {
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	body, err := p.client.GetHTTPClient().GetRaw(path, params, xcodeMetricsContentType)
+
+	var result map[string]interface{}
+	if len(body) > 0 {
+		if jsonErr := json.Unmarshal(body, &result); jsonErr != nil && err == nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", jsonErr)
+		}
+	}
+
+	return result, err
+}
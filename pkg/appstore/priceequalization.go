@@ -0,0 +1,260 @@
+package appstore
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// appPricePointsAPIVersion is the API version serving app price points
+const appPricePointsAPIVersion = "v3"
+
+// PriceProduct represents the kind of product a price point belongs to
+type PriceProduct string
+
+// Price products
+const (
+	PriceProductApp           PriceProduct = "app"
+	PriceProductInAppPurchase PriceProduct = "inAppPurchase"
+	PriceProductSubscription  PriceProduct = "subscription"
+)
+
+// PricePoint is the price point of a product in a territory
+type PricePoint struct {
+	ID            string
+	Territory     string
+	CustomerPrice string
+	Proceeds      string
+}
+
+// PriceEqualizationAPI selects equivalent price points across territories
+// from a base price and builds the matching price schedules
+type PriceEqualizationAPI struct {
+	client *Client
+}
+
+// NewPriceEqualizationAPI creates a new PriceEqualization API client
+func NewPriceEqualizationAPI(client *Client) *PriceEqualizationAPI {
+	return &PriceEqualizationAPI{client: client}
+}
+
+// BasePricePoint returns the price point of a product in a territory whose
+// customer price equals customerPrice, e.g. "9.99"
+func (p *PriceEqualizationAPI) BasePricePoint(product PriceProduct, productId, territory, customerPrice string) (PricePoint, error) {
+	want, err := strconv.ParseFloat(customerPrice, 64)
+	if err != nil {
+		return PricePoint{}, fmt.Errorf("invalid customer price %q", customerPrice)
+	}
+
+	httpClient := p.client.GetHTTPClient()
+	var path string
+	switch product {
+	case PriceProductApp:
+		path = "/apps/" + productId + "/appPricePoints"
+	case PriceProductInAppPurchase:
+		httpClient = httpClient.WithAPIVersion(inAppPurchasesAPIVersion)
+		path = "/inAppPurchases/" + productId + "/pricePoints"
+	case PriceProductSubscription:
+		path = "/subscriptions/" + productId + "/pricePoints"
+	default:
+		return PricePoint{}, fmt.Errorf("unknown price product: %s", product)
+	}
+	if err := p.client.EnsureAuth(); err != nil {
+		return PricePoint{}, err
+	}
+
+	query := map[string]string{"filter[territory]": territory, "limit": "200"}
+	for query != nil {
+		response, err := httpClient.Get(path, query)
+		if err != nil {
+			return PricePoint{}, err
+		}
+		for _, resource := range resourceList(response) {
+			price, err := strconv.ParseFloat(stringAttribute(resource, "customerPrice"), 64)
+			if err == nil && price == want {
+				point := parsePricePoint(resource)
+				point.Territory = territory
+				return point, nil
+			}
+		}
+		query = nextPageParams(response)
+	}
+
+	return PricePoint{}, fmt.Errorf("no price point of %s in territory %s", customerPrice, territory)
+}
+
+// Equalizations returns the price points equivalent to a base price point
+// in every other territory, as computed by Apple from exchange rates and taxes
+func (p *PriceEqualizationAPI) Equalizations(product PriceProduct, basePricePointId string) ([]PricePoint, error) {
+	httpClient := p.client.GetHTTPClient()
+	var path string
+	switch product {
+	case PriceProductApp:
+		httpClient = httpClient.WithAPIVersion(appPricePointsAPIVersion)
+		path = "/appPricePoints/" + basePricePointId + "/equalizations"
+	case PriceProductInAppPurchase:
+		path = "/inAppPurchasePricePoints/" + basePricePointId + "/equalizations"
+	case PriceProductSubscription:
+		path = "/subscriptionPricePoints/" + basePricePointId + "/equalizations"
+	default:
+		return nil, fmt.Errorf("unknown price product: %s", product)
+	}
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	var points []PricePoint
+	query := map[string]string{"include": "territory", "limit": "200"}
+	for query != nil {
+		response, err := httpClient.Get(path, query)
+		if err != nil {
+			return points, err
+		}
+		for _, resource := range resourceList(response) {
+			points = append(points, parsePricePoint(resource))
+		}
+		query = nextPageParams(response)
+	}
+	return points, nil
+}
+
+// Equalize returns the base price point of a product in baseTerritory
+// followed by its equivalent price points in every other territory
+func (p *PriceEqualizationAPI) Equalize(product PriceProduct, productId, baseTerritory, customerPrice string) ([]PricePoint, error) {
+	base, err := p.BasePricePoint(product, productId, baseTerritory, customerPrice)
+	if err != nil {
+		return nil, err
+	}
+	equalized, err := p.Equalizations(product, base.ID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]PricePoint{base}, equalized...), nil
+}
+
+// SubscriptionSchedule equalizes a subscription price from a base territory
+// and returns the price changes to pass to SubscriptionPricesAPI.SchedulePriceChanges.
+// An empty start date applies the prices immediately.
+func (p *PriceEqualizationAPI) SubscriptionSchedule(subscriptionId, baseTerritory, customerPrice, startDate string, preserveCurrentPrice bool) ([]SubscriptionPriceChange, error) {
+	points, err := p.Equalize(PriceProductSubscription, subscriptionId, baseTerritory, customerPrice)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]SubscriptionPriceChange, len(points))
+	for i, point := range points {
+		changes[i] = SubscriptionPriceChange{
+			Territory:            point.Territory,
+			PricePointID:         point.ID,
+			StartDate:            startDate,
+			PreserveCurrentPrice: preserveCurrentPrice,
+		}
+	}
+	return changes, nil
+}
+
+// InAppPurchaseSchedule equalizes an in-app purchase price from a base
+// territory and returns the price schedule payload setting every territory
+// manually. An empty start date applies the prices immediately.
+func (p *PriceEqualizationAPI) InAppPurchaseSchedule(iapId, baseTerritory, customerPrice, startDate string) (map[string]interface{}, error) {
+	points, err := p.Equalize(PriceProductInAppPurchase, iapId, baseTerritory, customerPrice)
+	if err != nil {
+		return nil, err
+	}
+	return priceSchedule("inAppPurchasePriceSchedules", "inAppPurchase", "inAppPurchases", iapId, baseTerritory,
+		"inAppPurchasePrices", "inAppPurchasePricePoint", "inAppPurchasePricePoints", points, startDate), nil
+}
+
+// AppSchedule equalizes an app price from a base territory and returns the
+// price schedule payload setting every territory manually. An empty start
+// date applies the prices immediately.
+func (p *PriceEqualizationAPI) AppSchedule(appId, baseTerritory, customerPrice, startDate string) (map[string]interface{}, error) {
+	points, err := p.Equalize(PriceProductApp, appId, baseTerritory, customerPrice)
+	if err != nil {
+		return nil, err
+	}
+	return priceSchedule("appPriceSchedules", "app", "apps", appId, baseTerritory,
+		"appPrices", "appPricePoint", "appPricePoints", points, startDate), nil
+}
+
+// CreateInAppPurchasePriceSchedule submits an in-app purchase price schedule payload
+func (p *PriceEqualizationAPI) CreateInAppPurchasePriceSchedule(schedule map[string]interface{}) (map[string]interface{}, error) {
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return p.client.GetHTTPClient().PostJSON("/inAppPurchasePriceSchedules", schedule)
+}
+
+// CreateAppPriceSchedule submits an app price schedule payload
+func (p *PriceEqualizationAPI) CreateAppPriceSchedule(schedule map[string]interface{}) (map[string]interface{}, error) {
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return p.client.GetHTTPClient().PostJSON("/appPriceSchedules", schedule)
+}
+
+// priceSchedule builds a price schedule payload whose manual prices are
+// created inline from price points using local IDs
+func priceSchedule(scheduleType, productRelationship, productType, productId, baseTerritory, priceType, pointRelationship, pointType string, points []PricePoint, startDate string) map[string]interface{} {
+	var start interface{}
+	if startDate != "" {
+		start = startDate
+	}
+
+	linkages := make([]map[string]string, len(points))
+	included := make([]map[string]interface{}, len(points))
+	for i, point := range points {
+		localId := "${price-" + strconv.Itoa(i) + "}"
+		linkages[i] = map[string]string{
+			"type": priceType,
+			"id":   localId,
+		}
+		included[i] = map[string]interface{}{
+			"type": priceType,
+			"id":   localId,
+			"attributes": map[string]interface{}{
+				"startDate": start,
+			},
+			"relationships": map[string]interface{}{
+				pointRelationship: map[string]interface{}{
+					"data": map[string]string{
+						"type": pointType,
+						"id":   point.ID,
+					},
+				},
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": scheduleType,
+			"relationships": map[string]interface{}{
+				productRelationship: map[string]interface{}{
+					"data": map[string]string{
+						"type": productType,
+						"id":   productId,
+					},
+				},
+				"baseTerritory": map[string]interface{}{
+					"data": map[string]string{
+						"type": "territories",
+						"id":   baseTerritory,
+					},
+				},
+				"manualPrices": map[string]interface{}{
+					"data": linkages,
+				},
+			},
+		},
+		"included": included,
+	}
+}
+
+// parsePricePoint converts a price point resource object to a PricePoint
+func parsePricePoint(resource map[string]interface{}) PricePoint {
+	return PricePoint{
+		ID:            resourceID(resource),
+		Territory:     relationshipID(resource, "territory"),
+		CustomerPrice: stringAttribute(resource, "customerPrice"),
+		Proceeds:      stringAttribute(resource, "proceeds"),
+	}
+}
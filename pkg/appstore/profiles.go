@@ -1,5 +1,121 @@
 package appstore
 
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// ProfileType is the kind of a provisioning profile
+type ProfileType string
+
+// Profile types
+const (
+	ProfileTypeIOSAppDevelopment         ProfileType = "IOS_APP_DEVELOPMENT"
+	ProfileTypeIOSAppStore               ProfileType = "IOS_APP_STORE"
+	ProfileTypeIOSAppAdHoc               ProfileType = "IOS_APP_ADHOC"
+	ProfileTypeIOSAppInHouse             ProfileType = "IOS_APP_INHOUSE"
+	ProfileTypeMacAppDevelopment         ProfileType = "MAC_APP_DEVELOPMENT"
+	ProfileTypeMacAppStore               ProfileType = "MAC_APP_STORE"
+	ProfileTypeMacAppDirect              ProfileType = "MAC_APP_DIRECT"
+	ProfileTypeTvOSAppDevelopment        ProfileType = "TVOS_APP_DEVELOPMENT"
+	ProfileTypeTvOSAppStore              ProfileType = "TVOS_APP_STORE"
+	ProfileTypeTvOSAppAdHoc              ProfileType = "TVOS_APP_ADHOC"
+	ProfileTypeTvOSAppInHouse            ProfileType = "TVOS_APP_INHOUSE"
+	ProfileTypeMacCatalystAppDevelopment ProfileType = "MAC_CATALYST_APP_DEVELOPMENT"
+	ProfileTypeMacCatalystAppStore       ProfileType = "MAC_CATALYST_APP_STORE"
+	ProfileTypeMacCatalystAppDirect      ProfileType = "MAC_CATALYST_APP_DIRECT"
+)
+
+// profileTypes lists every known profile type
+var profileTypes = []ProfileType{
+	ProfileTypeIOSAppDevelopment,
+	ProfileTypeIOSAppStore,
+	ProfileTypeIOSAppAdHoc,
+	ProfileTypeIOSAppInHouse,
+	ProfileTypeMacAppDevelopment,
+	ProfileTypeMacAppStore,
+	ProfileTypeMacAppDirect,
+	ProfileTypeTvOSAppDevelopment,
+	ProfileTypeTvOSAppStore,
+	ProfileTypeTvOSAppAdHoc,
+	ProfileTypeTvOSAppInHouse,
+	ProfileTypeMacCatalystAppDevelopment,
+	ProfileTypeMacCatalystAppStore,
+	ProfileTypeMacCatalystAppDirect,
+}
+
+// IsValid reports whether the type is a known profile type
+func (t ProfileType) IsValid() bool {
+	return slices.Contains(profileTypes, t)
+}
+
+// Profile represents a provisioning profile. Relationships holds the links of
+// its bundle ID, certificates, and devices, and the bundle ID linkage when
+// retrieved with Get.
+type Profile struct {
+	ID             string `json:"-"`
+	Name           string `json:"name"`
+	Platform       string `json:"platform"`
+	ProfileType    string `json:"profileType"`
+	ProfileState   string `json:"profileState"`
+	UUID           string `json:"uuid"`
+	CreatedDate    string `json:"createdDate"`
+	ExpirationDate string `json:"expirationDate"`
+	// ProfileContent is the base64 encoded profile
+	ProfileContent string                                  `json:"profileContent"`
+	Relationships  map[string]jsonapi.ResourceRelationship `json:"-"`
+	Links          jsonapi.Links                           `json:"-"`
+	// BundleId, Certificates, and Devices hold the related resources when
+	// they were requested with include, such as include=bundleId,certificates
+	BundleId     *BundleId     `json:"-"`
+	Certificates []Certificate `json:"-"`
+	Devices      []Device      `json:"-"`
+}
+
+// resolve links the included bundle ID, certificates, and devices of the profile
+func (p *Profile) resolve(resolver *jsonapi.Resolver) error {
+	var bundleId BundleId
+	ok, err := resolver.UnmarshalOne(p.Relationships["bundleId"].Data, &bundleId)
+	if err != nil {
+		return err
+	}
+	if ok {
+		p.BundleId = &bundleId
+	}
+	if err := resolver.UnmarshalMany(p.Relationships["certificates"].Data, &p.Certificates); err != nil {
+		return err
+	}
+	return resolver.UnmarshalMany(p.Relationships["devices"].Data, &p.Devices)
+}
+
+// decodeProfiles decodes the profiles of list responses, linking their
+// included resources
+func decodeProfiles(response map[string]interface{}) ([]Profile, error) {
+	profiles, err := unmarshalResources[Profile](resourceList(response))
+	if err != nil {
+		return nil, err
+	}
+	resolver := jsonapi.NewResolver(response)
+	for i := range profiles {
+		if err := profiles[i].resolve(resolver); err != nil {
+			return nil, err
+		}
+	}
+	return profiles, nil
+}
+
+// BundleIdID returns the ID of the bundle ID of the profile, when its linkage was included
+func (p Profile) BundleIdID() string {
+	if data := p.Relationships["bundleId"].Data; len(data) > 0 {
+		return data[0].ID
+	}
+	return ""
+}
+
 // ProfilesAPI handles profile-related operations
 type ProfilesAPI struct {
 	client *Client
@@ -10,6 +126,11 @@ func NewProfilesAPI(client *Client) *ProfilesAPI {
 	return &ProfilesAPI{client: client}
 }
 
+// WithContext returns a copy of the API whose requests are sent with ctx
+func (p *ProfilesAPI) WithContext(ctx context.Context) *ProfilesAPI {
+	return NewProfilesAPI(p.client.WithContext(ctx))
+}
+
 // Query retrieves profiles with optional parameters
 func (p *ProfilesAPI) Query(params map[string]string) (map[string]interface{}, error) {
 	if err := p.client.EnsureAuth(); err != nil {
@@ -18,60 +139,87 @@ func (p *ProfilesAPI) Query(params map[string]string) (map[string]interface{}, e
 	return p.client.GetHTTPClient().Get("/profiles", params)
 }
 
-// ProfileRelationship represents a relationship item
-type ProfileRelationship struct {
-	Type string `json:"type"`
-	ID   string `json:"id"`
+// AllPages retrieves the profiles of every page in a single response, following pagination
+func (p *ProfilesAPI) AllPages(params map[string]string) (map[string]interface{}, error) {
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return p.client.GetHTTPClient().GetAllPages("/profiles", params)
 }
 
-// Create creates a new profile
-func (p *ProfilesAPI) Create(name, bId, profileType string, devices []string, certificates []string) (map[string]interface{}, error) {
+// List retrieves every profile matching params, following pagination.
+// Related resources requested with include are linked to the profiles.
+func (p *ProfilesAPI) List(params map[string]string) ([]Profile, error) {
 	if err := p.client.EnsureAuth(); err != nil {
 		return nil, err
 	}
+	response, err := p.client.GetHTTPClient().GetAllPages("/profiles", params)
+	if err != nil {
+		return nil, err
+	}
+	return decodeProfiles(response)
+}
 
-	// Prepare devices relationship
-	devicesData := make([]ProfileRelationship, len(devices))
-	for i, device := range devices {
-		devicesData[i] = ProfileRelationship{
-			Type: "devices",
-			ID:   device,
-		}
+// ListPage retrieves a single page of profiles matching params with its paging
+// metadata. Pass PageInfo.NextParams as params to request the next page.
+func (p *ProfilesAPI) ListPage(params map[string]string) ([]Profile, jsonapi.PageInfo, error) {
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, jsonapi.PageInfo{}, err
+	}
+	response, err := p.client.GetHTTPClient().Get("/profiles", params)
+	if err != nil {
+		return nil, jsonapi.PageInfo{}, err
 	}
+	profiles, err := decodeProfiles(response)
+	return profiles, jsonapi.Paging(response), err
+}
 
-	// Prepare certificates relationship
-	certificatesData := make([]ProfileRelationship, len(certificates))
-	for i, cert := range certificates {
-		certificatesData[i] = ProfileRelationship{
-			Type: "certificates",
-			ID:   cert,
-		}
+// Get retrieves a profile by ID with its bundle ID
+func (p *ProfilesAPI) Get(pId string) (Profile, error) {
+	if err := p.client.EnsureAuth(); err != nil {
+		return Profile{}, err
+	}
+	response, err := p.client.GetHTTPClient().Get("/profiles/"+pId, map[string]string{"include": "bundleId"})
+	if err != nil {
+		return Profile{}, err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return Profile{}, err
 	}
+	var profile Profile
+	if err := jsonapi.Unmarshal(resource, &profile); err != nil {
+		return Profile{}, err
+	}
+	err = profile.resolve(jsonapi.NewResolver(response))
+	return profile, err
+}
 
-	data := map[string]interface{}{
-		"data": map[string]interface{}{
-			"type": "profiles",
-			"relationships": map[string]interface{}{
-				"bundleId": map[string]interface{}{
-					"data": ProfileRelationship{
-						Type: "bundleIds",
-						ID:   bId,
-					},
-				},
-				"devices": map[string]interface{}{
-					"data": devicesData,
-				},
-				"certificates": map[string]interface{}{
-					"data": certificatesData,
-				},
-			},
-			"attributes": map[string]string{
-				"profileType": profileType,
-				"name":        name,
-			},
-		},
+// ProfileRelationship represents a relationship item
+type ProfileRelationship = jsonapi.Linkage
+
+// Create creates a new profile
+func (p *ProfilesAPI) Create(name, bId string, profileType ProfileType, devices []string, certificates []string) (map[string]interface{}, error) {
+	if !profileType.IsValid() {
+		return nil, fmt.Errorf("invalid profile type: %s", profileType)
+	}
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, err
 	}
 
+	data := jsonapi.NewDocument(jsonapi.Resource{
+		Type: "profiles",
+		Attributes: map[string]string{
+			"profileType": string(profileType),
+			"name":        name,
+		},
+		Relationships: map[string]jsonapi.Relationship{
+			"bundleId":     jsonapi.ToOne("bundleIds", bId),
+			"devices":      jsonapi.ToMany("devices", devices),
+			"certificates": jsonapi.ToMany("certificates", certificates),
+		},
+	})
+
 	return p.client.GetHTTPClient().PostJSON("/profiles", data)
 }
 
@@ -98,3 +246,100 @@ func (p *ProfilesAPI) Delete(pId string) (map[string]interface{}, error) {
 	}
 	return p.client.GetHTTPClient().Delete("/profiles/"+pId, nil)
 }
+
+// Regenerate replaces a profile with a new one of the same name, bundle ID,
+// type, certificates, and devices, returning the new profile. When
+// includeAllDevices is set, development and ad hoc profiles get every enabled
+// device of their platform instead of their current devices.
+func (p *ProfilesAPI) Regenerate(pId string, includeAllDevices bool) (map[string]interface{}, error) {
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	response, err := p.client.GetHTTPClient().Get("/profiles/"+pId, map[string]string{"include": "bundleId"})
+	if err != nil {
+		return response, err
+	}
+	profile, err := responseResource(response)
+	if err != nil {
+		return nil, err
+	}
+	name := stringAttribute(profile, "name")
+	profileType := stringAttribute(profile, "profileType")
+	bId := relationshipID(profile, "bundleId")
+	if bId == "" {
+		return nil, fmt.Errorf("profile %s has no bundle id", pId)
+	}
+
+	certificates, err := p.client.linkageIDs("/profiles/" + pId + "/relationships/certificates")
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []string
+	if profileUsesDevices(profileType) {
+		if includeAllDevices {
+			enabled, err := NewDeviceAPI(p.client).List(DeviceListOptions{
+				Status:   DeviceStatusEnabled,
+				Platform: BundleIdPlatform(stringAttribute(profile, "platform")),
+			}.Query())
+			if err != nil {
+				return nil, err
+			}
+			for _, device := range enabled {
+				devices = append(devices, device.ID)
+			}
+		} else {
+			devices, err = p.client.linkageIDs("/profiles/" + pId + "/relationships/devices")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if response, err := p.Delete(pId); err != nil {
+		return response, err
+	}
+	created, err := p.Create(name, bId, ProfileType(profileType), devices, certificates)
+	if err != nil {
+		return created, fmt.Errorf("profile %s was deleted but its replacement could not be created: %w", name, err)
+	}
+	return created, nil
+}
+
+// profileUsesDevices reports whether profiles of a type are limited to registered devices
+func profileUsesDevices(profileType string) bool {
+	return strings.Contains(profileType, "DEVELOPMENT") || strings.Contains(profileType, "ADHOC")
+}
+
+// linkageIDs returns the IDs of a to-many relationship, following pagination
+func (c *Client) linkageIDs(path string) ([]string, error) {
+	var ids []string
+	err := c.GetHTTPClient().EachPage(path, nil, func(page map[string]interface{}) error {
+		for _, resource := range resourceList(page) {
+			ids = append(ids, resourceID(resource))
+		}
+		return nil
+	})
+	return ids, err
+}
+
+// listPage returns the resource objects and paging metadata of a single page
+// of a list endpoint
+func (c *Client) listPage(path string, params map[string]string) ([]map[string]interface{}, jsonapi.PageInfo, error) {
+	response, err := c.GetHTTPClient().Get(path, params)
+	if err != nil {
+		return nil, jsonapi.PageInfo{}, err
+	}
+	return resourceList(response), jsonapi.Paging(response), nil
+}
+
+// listResources returns every resource object of a list endpoint, following pagination
+func (c *Client) listResources(path string, params map[string]string) ([]map[string]interface{}, error) {
+	var resources []map[string]interface{}
+	err := c.GetHTTPClient().EachPage(path, params, func(page map[string]interface{}) error {
+		resources = append(resources, resourceList(page)...)
+		return nil
+	})
+	return resources, err
+}
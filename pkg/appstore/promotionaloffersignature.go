@@ -0,0 +1,120 @@
+package appstore
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"appstore-connect-api/pkg/jwtutil"
+)
+
+// promotionalOfferSeparator separates the fields of the signed payload
+const promotionalOfferSeparator = "\u2063"
+
+// PromotionalOfferSignatureRequest holds the values the app passes to StoreKit
+// when redeeming a promotional offer
+type PromotionalOfferSignatureRequest struct {
+	BundleID            string
+	ProductID           string
+	OfferID             string
+	ApplicationUsername string
+	// Nonce and Timestamp are generated when empty
+	Nonce     string
+	Timestamp int64
+}
+
+// PromotionalOfferSignature holds the values to return to the app for
+// SKPaymentDiscount or Product.PurchaseOption.promotionalOffer
+type PromotionalOfferSignature struct {
+	KeyID     string `json:"keyIdentifier"`
+	Nonce     string `json:"nonce"`
+	Timestamp int64  `json:"timestamp"`
+	Signature string `json:"signature"`
+}
+
+// PromotionalOfferSigner signs promotional offers with an in-app purchase key
+type PromotionalOfferSigner struct {
+	keyID string
+	key   *ecdsa.PrivateKey
+}
+
+// NewPromotionalOfferSigner creates a new promotional offer signer. The
+// private key can be a file path or the content of the .p8 key file.
+func NewPromotionalOfferSigner(keyID, privateKey string) (*PromotionalOfferSigner, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("key id is required")
+	}
+	if privateKey == "" {
+		return nil, fmt.Errorf("private key is required")
+	}
+
+	content, err := readSecret(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := jwtutil.ParsePrivateKey(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	return &PromotionalOfferSigner{keyID: keyID, key: key}, nil
+}
+
+// Sign generates the signature of a promotional offer
+func (p *PromotionalOfferSigner) Sign(request PromotionalOfferSignatureRequest) (PromotionalOfferSignature, error) {
+	if request.BundleID == "" || request.ProductID == "" || request.OfferID == "" {
+		return PromotionalOfferSignature{}, fmt.Errorf("bundle id, product id and offer id are required")
+	}
+
+	nonce := request.Nonce
+	if nonce == "" {
+		var err error
+		nonce, err = randomUUID()
+		if err != nil {
+			return PromotionalOfferSignature{}, err
+		}
+	}
+	timestamp := request.Timestamp
+	if timestamp == 0 {
+		timestamp = time.Now().UnixMilli()
+	}
+
+	payload := strings.Join([]string{
+		request.BundleID,
+		p.keyID,
+		request.ProductID,
+		request.OfferID,
+		strings.ToLower(request.ApplicationUsername),
+		strings.ToLower(nonce),
+		strconv.FormatInt(timestamp, 10),
+	}, promotionalOfferSeparator)
+
+	digest := sha256.Sum256([]byte(payload))
+	signature, err := ecdsa.SignASN1(rand.Reader, p.key, digest[:])
+	if err != nil {
+		return PromotionalOfferSignature{}, fmt.Errorf("failed to sign promotional offer: %w", err)
+	}
+
+	return PromotionalOfferSignature{
+		KeyID:     p.keyID,
+		Nonce:     strings.ToLower(nonce),
+		Timestamp: timestamp,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// randomUUID generates a random version 4 UUID
+func randomUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
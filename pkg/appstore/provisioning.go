@@ -0,0 +1,134 @@
+package appstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProvisioningType selects the kind of signing identity and profiles to provision
+type ProvisioningType string
+
+// Provisioning types, named after fastlane match types
+const (
+	ProvisioningTypeDevelopment ProvisioningType = "development"
+	ProvisioningTypeAdHoc       ProvisioningType = "adhoc"
+	ProvisioningTypeAppStore    ProvisioningType = "appstore"
+	ProvisioningTypeDeveloperID ProvisioningType = "developer_id"
+)
+
+// provisioningKind holds the certificate and profile types of a provisioning
+// type on a platform
+type provisioningKind struct {
+	// certificateTypes are accepted for signing, the first is used for new certificates
+	certificateTypes []string
+	profileType      string
+}
+
+// provisioningKinds maps provisioning types and platforms to their kinds
+var provisioningKinds = map[ProvisioningType]map[string]provisioningKind{
+	ProvisioningTypeDevelopment: {
+		"IOS":    {[]string{"DEVELOPMENT", "IOS_DEVELOPMENT"}, "IOS_APP_DEVELOPMENT"},
+		"MAC_OS": {[]string{"DEVELOPMENT", "MAC_APP_DEVELOPMENT"}, "MAC_APP_DEVELOPMENT"},
+	},
+	ProvisioningTypeAdHoc: {
+		"IOS": {[]string{"DISTRIBUTION", "IOS_DISTRIBUTION"}, "IOS_APP_ADHOC"},
+	},
+	ProvisioningTypeAppStore: {
+		"IOS":    {[]string{"DISTRIBUTION", "IOS_DISTRIBUTION"}, "IOS_APP_STORE"},
+		"MAC_OS": {[]string{"DISTRIBUTION", "MAC_APP_DISTRIBUTION"}, "MAC_APP_STORE"},
+	},
+	ProvisioningTypeDeveloperID: {
+		"MAC_OS": {[]string{"DEVELOPER_ID_APPLICATION"}, "MAC_APP_DIRECT"},
+	},
+}
+
+// kind returns the certificate and profile types of a provisioning type on a platform
+func (t ProvisioningType) kind(platform string) (provisioningKind, error) {
+	kind, ok := provisioningKinds[t][platform]
+	if !ok {
+		return provisioningKind{}, fmt.Errorf("provisioning type %q is not supported on %s", t, platform)
+	}
+	return kind, nil
+}
+
+// ProvisioningSpec describes the bundle IDs and profiles to provision for one
+// provisioning type, the way a fastlane match configuration does
+type ProvisioningSpec struct {
+	Type ProvisioningType `json:"type" yaml:"type"`
+	// Platform is IOS or MAC_OS, IOS when empty
+	Platform string `json:"platform,omitempty" yaml:"platform,omitempty"`
+	// ProfilePrefix starts the names of managed profiles, "asc" when empty
+	ProfilePrefix string                 `json:"profilePrefix,omitempty" yaml:"profilePrefix,omitempty"`
+	BundleIDs     []ProvisioningBundleID `json:"bundleIds" yaml:"bundleIds"`
+}
+
+// ProvisioningBundleID describes a bundle ID and its capabilities, matched by identifier
+type ProvisioningBundleID struct {
+	Identifier   string   `json:"identifier" yaml:"identifier"`
+	Name         string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+}
+
+// LoadProvisioningSpec reads a provisioning spec from a .json, .yaml, or .yml file
+func LoadProvisioningSpec(path string) (*ProvisioningSpec, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provisioning spec: %w", err)
+	}
+
+	var spec ProvisioningSpec
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &spec)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &spec)
+	default:
+		return nil, fmt.Errorf("unsupported provisioning spec format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse provisioning spec: %w", err)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate fills in defaults and checks that the spec is complete
+func (s *ProvisioningSpec) Validate() error {
+	if s.Platform == "" {
+		s.Platform = "IOS"
+	}
+	if s.ProfilePrefix == "" {
+		s.ProfilePrefix = "asc"
+	}
+	if _, err := s.Type.kind(s.Platform); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, bundleID := range s.BundleIDs {
+		if bundleID.Identifier == "" {
+			return fmt.Errorf("bundle id identifier is required")
+		}
+		if seen[bundleID.Identifier] {
+			return fmt.Errorf("duplicate bundle id %s", bundleID.Identifier)
+		}
+		seen[bundleID.Identifier] = true
+		if err := validateCapabilities(bundleID.Capabilities); err != nil {
+			return fmt.Errorf("bundle id %s: %w", bundleID.Identifier, err)
+		}
+	}
+	return nil
+}
+
+// profileName returns the name of the managed profile of a bundle ID
+func (s *ProvisioningSpec) profileName(identifier string) string {
+	return fmt.Sprintf("%s %s %s", s.ProfilePrefix, s.Type, identifier)
+}
@@ -0,0 +1,366 @@
+package appstore
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProvisioningAction records a change made by ProvisioningSync
+type ProvisioningAction struct {
+	// Action is created, regenerated, enabled, deleted, or revoked
+	Action string `json:"action"`
+	// Resource is bundleId, capability, certificate, or profile
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+	ID       string `json:"id"`
+}
+
+// ProvisioningSync converges bundle IDs, certificates, and profiles to a
+// ProvisioningSpec, in the spirit of fastlane match and sigh
+type ProvisioningSync struct {
+	client *Client
+	// OutputDir receives the private keys and certificates of new certificates
+	// and the managed profiles. New certificates are only created when it is
+	// set, since their private key is not stored anywhere else.
+	OutputDir string
+}
+
+// NewProvisioningSync creates a provisioning sync for a client
+func NewProvisioningSync(client *Client) *ProvisioningSync {
+	return &ProvisioningSync{client: client}
+}
+
+// Sync registers missing bundle IDs and capabilities, creates a certificate
+// when none of the spec's type is valid, and creates or regenerates the
+// profile of each bundle ID so it holds every valid certificate and, for
+// development and ad hoc profiles, every enabled device. The bundle IDs and
+// profiles are converged by a Reconciler.
+func (p *ProvisioningSync) Sync(spec *ProvisioningSpec) ([]ProvisioningAction, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	kind, _ := spec.Type.kind(spec.Platform)
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	var actions []ProvisioningAction
+	certificates, err := p.validCertificates(kind.certificateTypes)
+	if err != nil {
+		return actions, err
+	}
+	if len(certificates) == 0 {
+		action, certificate, err := p.createCertificate(kind.certificateTypes[0])
+		if err != nil {
+			return actions, err
+		}
+		actions = append(actions, action)
+		certificates = []map[string]interface{}{certificate}
+	}
+
+	reconcileSpec := p.reconcileSpec(spec, kind, certificates)
+	reconciler := NewReconciler(p.client)
+	plan, err := reconciler.Plan(reconcileSpec)
+	if err != nil {
+		return actions, err
+	}
+	result := reconciler.Apply(plan)
+	actions = append(actions, provisioningActions(plan, result)...)
+	if err := result.Err(); err != nil {
+		return actions, err
+	}
+
+	for _, profile := range plan.State().Desired.Profiles {
+		if err := p.writeProfileID(profile.ID); err != nil {
+			return actions, err
+		}
+	}
+	return actions, nil
+}
+
+// reconcileSpec returns the reconcile spec of a provisioning spec, pinning
+// the given certificates by serial number
+func (p *ProvisioningSync) reconcileSpec(spec *ProvisioningSpec, kind provisioningKind, certificates []map[string]interface{}) *ReconcileSpec {
+	reconcileSpec := &ReconcileSpec{}
+	var names []string
+	for _, certificate := range certificates {
+		name := resourceID(certificate)
+		names = append(names, name)
+		reconcileSpec.Certificates = append(reconcileSpec.Certificates, ReconcileCertificate{
+			Name:         name,
+			Type:         stringAttribute(certificate, "certificateType"),
+			SerialNumber: stringAttribute(certificate, "serialNumber"),
+		})
+	}
+	for _, bundleID := range spec.BundleIDs {
+		reconcileSpec.BundleIDs = append(reconcileSpec.BundleIDs, ReconcileBundleID{
+			Identifier:   bundleID.Identifier,
+			Name:         bundleID.Name,
+			Platform:     spec.Platform,
+			Capabilities: bundleID.Capabilities,
+		})
+		reconcileSpec.Profiles = append(reconcileSpec.Profiles, ReconcileProfile{
+			Name:         spec.profileName(bundleID.Identifier),
+			Type:         kind.profileType,
+			BundleID:     bundleID.Identifier,
+			Certificates: names,
+			AllDevices:   true,
+		})
+	}
+	return reconcileSpec
+}
+
+// provisioningActions returns the actions of the changes applied by a reconciler
+func provisioningActions(plan *ReconcilePlan, result ReconcileResult) []ProvisioningAction {
+	state := plan.state
+	var actions []ProvisioningAction
+	for _, change := range result.Applied {
+		switch change.Resource {
+		case "bundleId":
+			actions = append(actions, ProvisioningAction{Action: "created", Resource: "bundleId", Name: change.Name, ID: state.bundleIDs[change.Name]})
+		case "capability":
+			capability := strings.TrimPrefix(change.Detail, "enable ")
+			actions = append(actions, ProvisioningAction{Action: "enabled", Resource: "capability", Name: change.Name + " " + capability})
+		case "profile":
+			action := "created"
+			if change.Action == ReconcileActionReplace {
+				action = "regenerated"
+			}
+			actions = append(actions, ProvisioningAction{Action: action, Resource: "profile", Name: change.Name, ID: state.profiles[change.Name]})
+		}
+	}
+	return actions
+}
+
+// Repair regenerates every invalid profile, for example after a certificate
+// was revoked or a device was disabled, including all enabled devices
+func (p *ProvisioningSync) Repair() ([]ProvisioningAction, error) {
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	profiles, err := p.client.listResources("/profiles", map[string]string{"filter[profileState]": "INVALID"})
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []ProvisioningAction
+	profilesAPI := NewProfilesAPI(p.client)
+	for _, profile := range profiles {
+		response, err := profilesAPI.Regenerate(resourceID(profile), true)
+		if err != nil {
+			return actions, err
+		}
+		created, err := responseResource(response)
+		if err != nil {
+			return actions, err
+		}
+		actions = append(actions, ProvisioningAction{
+			Action:   "regenerated",
+			Resource: "profile",
+			Name:     stringAttribute(created, "name"),
+			ID:       resourceID(created),
+		})
+	}
+	return actions, nil
+}
+
+// Nuke deletes every profile and revokes every certificate of a provisioning
+// type on all platforms. Ad hoc and App Store profiles share distribution
+// certificates, so nuking either revokes the certificates of both.
+func (p *ProvisioningSync) Nuke(provisioningType ProvisioningType) ([]ProvisioningAction, error) {
+	kinds, ok := provisioningKinds[provisioningType]
+	if !ok {
+		return nil, fmt.Errorf("unknown provisioning type %q", provisioningType)
+	}
+	if err := p.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	profileTypes := make(map[string]bool)
+	certificateTypes := make(map[string]bool)
+	for _, kind := range kinds {
+		profileTypes[kind.profileType] = true
+		for _, certificateType := range kind.certificateTypes {
+			certificateTypes[certificateType] = true
+		}
+	}
+
+	var actions []ProvisioningAction
+	profiles, err := p.client.listResources("/profiles", map[string]string{
+		"filter[profileType]": joinKeys(profileTypes),
+	})
+	if err != nil {
+		return actions, err
+	}
+	for _, profile := range profiles {
+		if _, err := NewProfilesAPI(p.client).Delete(resourceID(profile)); err != nil {
+			return actions, fmt.Errorf("failed to delete profile %s: %w", resourceID(profile), err)
+		}
+		actions = append(actions, ProvisioningAction{Action: "deleted", Resource: "profile", Name: stringAttribute(profile, "name"), ID: resourceID(profile)})
+	}
+
+	certificates, err := p.client.listResources("/certificates", map[string]string{
+		"filter[certificateType]": joinKeys(certificateTypes),
+	})
+	if err != nil {
+		return actions, err
+	}
+	for _, certificate := range certificates {
+		if _, err := NewCertificatesAPI(p.client).Delete(resourceID(certificate)); err != nil {
+			return actions, fmt.Errorf("failed to revoke certificate %s: %w", resourceID(certificate), err)
+		}
+		actions = append(actions, ProvisioningAction{Action: "revoked", Resource: "certificate", Name: stringAttribute(certificate, "name"), ID: resourceID(certificate)})
+	}
+	return actions, nil
+}
+
+// validCertificates returns the unexpired certificates of the given types
+func (p *ProvisioningSync) validCertificates(certificateTypes []string) ([]map[string]interface{}, error) {
+	certificates, err := p.client.listResources("/certificates", map[string]string{
+		"filter[certificateType]": strings.Join(certificateTypes, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var valid []map[string]interface{}
+	for _, certificate := range certificates {
+		expiration, err := time.Parse(time.RFC3339, stringAttribute(certificate, "expirationDate"))
+		if err == nil && expiration.Before(time.Now()) {
+			continue
+		}
+		valid = append(valid, certificate)
+	}
+	return valid, nil
+}
+
+// createCertificate creates a certificate from a new private key and writes
+// both to the output directory, returning the new certificate
+func (p *ProvisioningSync) createCertificate(certificateType string) (ProvisioningAction, map[string]interface{}, error) {
+	if p.OutputDir == "" {
+		return ProvisioningAction{}, nil, fmt.Errorf("no valid %s certificate exists, set an output directory to create one", certificateType)
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return ProvisioningAction{}, nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "appstore-connect-api"},
+	}, privateKey)
+	if err != nil {
+		return ProvisioningAction{}, nil, fmt.Errorf("failed to create CSR: %w", err)
+	}
+
+	response, err := NewCertificatesAPI(p.client).CreateFromCSR(CertificateType(certificateType), base64.StdEncoding.EncodeToString(csr))
+	if err != nil {
+		return ProvisioningAction{}, nil, err
+	}
+	certificate, err := responseResource(response)
+	if err != nil {
+		return ProvisioningAction{}, nil, err
+	}
+	id := resourceID(certificate)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return ProvisioningAction{}, nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+	if err := p.write(id+".key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return ProvisioningAction{}, nil, err
+	}
+	if err := p.writeBase64(id+".cer", stringAttribute(certificate, "certificateContent")); err != nil {
+		return ProvisioningAction{}, nil, err
+	}
+
+	return ProvisioningAction{Action: "created", Resource: "certificate", Name: stringAttribute(certificate, "name"), ID: id}, certificate, nil
+}
+
+// writeProfileID fetches a profile and writes it to the output directory, if set
+func (p *ProvisioningSync) writeProfileID(pId string) error {
+	if p.OutputDir == "" || pId == "" {
+		return nil
+	}
+	response, err := p.client.GetHTTPClient().Get("/profiles/"+pId, nil)
+	if err != nil {
+		return err
+	}
+	profile, err := responseResource(response)
+	if err != nil {
+		return err
+	}
+	return p.writeProfile(profile)
+}
+
+// writeProfile writes the content of a profile to the output directory, if set
+func (p *ProvisioningSync) writeProfile(profile map[string]interface{}) error {
+	extension := ".mobileprovision"
+	if strings.HasPrefix(stringAttribute(profile, "profileType"), "MAC_") {
+		extension = ".provisionprofile"
+	}
+	name := strings.ReplaceAll(stringAttribute(profile, "name"), " ", "_")
+	return p.writeBase64(name+extension, stringAttribute(profile, "profileContent"))
+}
+
+// writeBase64 decodes content and writes it to the output directory, if set
+func (p *ProvisioningSync) writeBase64(name, content string) error {
+	if p.OutputDir == "" {
+		return nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return p.write(name, decoded, 0644)
+}
+
+// write writes a file to the output directory, if set
+func (p *ProvisioningSync) write(name string, content []byte, perm os.FileMode) error {
+	if p.OutputDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(p.OutputDir, 0700); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(p.OutputDir, name), content, perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// sameIDs reports whether two lists hold the same IDs, ignoring order
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+	for _, id := range b {
+		if !set[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// joinKeys joins the keys of a set with commas, in sorted order
+func joinKeys(set map[string]bool) string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
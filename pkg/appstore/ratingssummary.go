@@ -0,0 +1,100 @@
+package appstore
+
+import (
+	"sort"
+	"time"
+)
+
+// RatingsSummary is a snapshot of the ratings of a set of customer reviews
+type RatingsSummary struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	// From and To span the creation dates of the summarized reviews
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Count   int       `json:"count"`
+	Average float64   `json:"average"`
+	// Histogram holds the number of reviews per star rating, from 1 to 5
+	Histogram   map[int]int                 `json:"histogram"`
+	Territories map[string]TerritoryRatings `json:"territories"`
+}
+
+// TerritoryRatings holds the ratings of a single territory
+type TerritoryRatings struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+}
+
+// SummarizeReviews aggregates reviews into a ratings summary
+func SummarizeReviews(reviews []CustomerReview) RatingsSummary {
+	summary := RatingsSummary{
+		GeneratedAt: time.Now(),
+		Histogram:   make(map[int]int),
+		Territories: make(map[string]TerritoryRatings),
+	}
+
+	total := 0
+	territoryTotals := make(map[string]int)
+	for _, review := range reviews {
+		summary.Count++
+		total += review.Rating
+		summary.Histogram[review.Rating]++
+
+		territory := summary.Territories[review.Territory]
+		territory.Count++
+		territoryTotals[review.Territory] += review.Rating
+		summary.Territories[review.Territory] = territory
+
+		if summary.From.IsZero() || review.CreatedDate.Before(summary.From) {
+			summary.From = review.CreatedDate
+		}
+		if review.CreatedDate.After(summary.To) {
+			summary.To = review.CreatedDate
+		}
+	}
+
+	if summary.Count > 0 {
+		summary.Average = float64(total) / float64(summary.Count)
+	}
+	for code, territory := range summary.Territories {
+		territory.Average = float64(territoryTotals[code]) / float64(territory.Count)
+		summary.Territories[code] = territory
+	}
+
+	return summary
+}
+
+// RatingsTimeline summarizes reviews per period, such as 24 hours, ordered
+// from oldest to newest. Periods are aligned to UTC.
+func RatingsTimeline(reviews []CustomerReview, period time.Duration) []RatingsSummary {
+	buckets := make(map[time.Time][]CustomerReview)
+	for _, review := range reviews {
+		start := review.CreatedDate.UTC().Truncate(period)
+		buckets[start] = append(buckets[start], review)
+	}
+
+	starts := make([]time.Time, 0, len(buckets))
+	for start := range buckets {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+
+	timeline := make([]RatingsSummary, 0, len(starts))
+	for _, start := range starts {
+		summary := SummarizeReviews(buckets[start])
+		summary.From = start
+		summary.To = start.Add(period)
+		timeline = append(timeline, summary)
+	}
+	return timeline
+}
+
+// RatingsSummary fetches every review of an app and summarizes them
+func (c *CustomerReviewsAPI) RatingsSummary(appId string) (RatingsSummary, error) {
+	reviews, err := c.AllReviews(appId, nil)
+	if err != nil {
+		return RatingsSummary{}, err
+	}
+	return SummarizeReviews(reviews), nil
+}
@@ -0,0 +1,894 @@
+package appstore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReconcileAction represents the kind of a reconcile change
+type ReconcileAction string
+
+// Reconcile actions
+const (
+	ReconcileActionCreate ReconcileAction = "CREATE"
+	ReconcileActionUpdate ReconcileAction = "UPDATE"
+	// ReconcileActionReplace deletes a resource and creates it again, for
+	// profiles, which cannot be modified in place
+	ReconcileActionReplace ReconcileAction = "REPLACE"
+	ReconcileActionDelete  ReconcileAction = "DELETE"
+	// ReconcileActionWarn marks a difference that is reported but never applied
+	ReconcileActionWarn ReconcileAction = "WARN"
+)
+
+// ReconcileChange is a single difference between a ReconcileSpec and App Store Connect
+type ReconcileChange struct {
+	Action ReconcileAction `json:"action"`
+	// Resource is bundleId, capability, certificate, device, or profile
+	Resource string `json:"resource"`
+	// Name is the identifier, certificate name, UDID, or profile name the change applies to
+	Name string `json:"name"`
+	// ID is the ID of the existing resource, empty for creates
+	ID     string `json:"id,omitempty"`
+	Detail string `json:"detail,omitempty"`
+	apply  func() error
+	// key names the resource the change creates, for the changes that need it
+	key string
+	// needs lists the keys of the resources the change depends on
+	needs []string
+}
+
+// String formats the change as a line of a diff report
+func (c ReconcileChange) String() string {
+	symbol := "~"
+	switch c.Action {
+	case ReconcileActionCreate:
+		symbol = "+"
+	case ReconcileActionReplace:
+		symbol = "-/+"
+	case ReconcileActionDelete:
+		symbol = "-"
+	case ReconcileActionWarn:
+		symbol = "!"
+	}
+	line := fmt.Sprintf("%s %s %s", symbol, c.Resource, c.Name)
+	if c.Detail != "" {
+		line += ": " + c.Detail
+	}
+	return line
+}
+
+// ReconcilePlan lists the changes needed to make App Store Connect match a spec, in apply order
+type ReconcilePlan struct {
+	Changes []ReconcileChange `json:"changes"`
+	state   *reconcileState
+}
+
+// HasChanges reports whether the plan contains changes to apply
+func (p *ReconcilePlan) HasChanges() bool {
+	for _, change := range p.Changes {
+		if change.apply != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the plan as a diff report, one change per line
+func (p *ReconcilePlan) String() string {
+	if len(p.Changes) == 0 {
+		return "no changes"
+	}
+	lines := make([]string, len(p.Changes))
+	for i, change := range p.Changes {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// add appends a change to the plan
+func (p *ReconcilePlan) add(change ReconcileChange) {
+	p.Changes = append(p.Changes, change)
+}
+
+// ReconcileFailure is a change that failed to apply
+type ReconcileFailure struct {
+	Change ReconcileChange `json:"change"`
+	Err    error           `json:"-"`
+}
+
+// ReconcileResult reports the outcome of applying a plan
+type ReconcileResult struct {
+	Applied  []ReconcileChange  `json:"applied"`
+	Failures []ReconcileFailure `json:"failures"`
+	Warnings []ReconcileChange  `json:"warnings"`
+}
+
+// Err returns the failures of the result joined into one error, or nil
+func (r ReconcileResult) Err() error {
+	errs := make([]error, len(r.Failures))
+	for i, failure := range r.Failures {
+		errs[i] = fmt.Errorf("%s %s: %w", failure.Change.Resource, failure.Change.Name, failure.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// reconcileState holds the IDs of portal resources. Planning fills it from
+// App Store Connect and applying completes it as resources are created, so
+// later changes can refer to resources created by earlier ones.
+type reconcileState struct {
+	// bundleIDs maps identifiers to IDs
+	bundleIDs map[string]string
+	// certificates maps spec certificate names to IDs
+	certificates map[string]string
+	// devices maps normalized UDIDs to devices in their desired state
+	devices map[string]*reconcileDevice
+	// profiles maps names to IDs
+	profiles map[string]string
+	// observed and desired are exported by ReconcilePlan.State, without IDs
+	// in desired, and without devices in desired, which are taken from devices
+	observed ReconcileResources
+	desired  ReconcileResources
+}
+
+// reconcileDevice is a device in its desired state, with an empty ID until it is registered
+type reconcileDevice struct {
+	id       string
+	name     string
+	platform string
+	enabled  bool
+}
+
+// Reconciler converges bundle IDs, capabilities, certificates, devices, and
+// profiles to a ReconcileSpec with plan/apply semantics. Resources missing
+// from App Store Connect are created, differing devices are updated, and
+// outdated profiles are replaced. Deletions only happen when the spec sets Prune.
+type Reconciler struct {
+	client *Client
+}
+
+// NewReconciler creates a new reconciler
+func NewReconciler(client *Client) *Reconciler {
+	return &Reconciler{client: client}
+}
+
+// Sync plans and applies the changes needed to match spec
+func (r *Reconciler) Sync(spec *ReconcileSpec) (*ReconcilePlan, ReconcileResult, error) {
+	plan, err := r.Plan(spec)
+	if err != nil {
+		return nil, ReconcileResult{}, err
+	}
+	result := r.Apply(plan)
+	return plan, result, result.Err()
+}
+
+// Plan compares spec with App Store Connect and returns the changes needed
+// to match it, without applying them
+func (r *Reconciler) Plan(spec *ReconcileSpec) (*ReconcilePlan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	if err := r.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	plan := &ReconcilePlan{state: &reconcileState{
+		bundleIDs:    make(map[string]string),
+		certificates: make(map[string]string),
+		devices:      make(map[string]*reconcileDevice),
+		profiles:     make(map[string]string),
+	}}
+	if err := r.planBundleIDs(plan, spec); err != nil {
+		return nil, err
+	}
+	if err := r.planCertificates(plan, spec); err != nil {
+		return nil, err
+	}
+	if err := r.planDevices(plan, spec); err != nil {
+		return nil, err
+	}
+	if err := r.planProfiles(plan, spec); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Apply applies every change of a plan in order, continuing past failures.
+// Changes that depend on a resource whose creation failed, such as the
+// profiles of a bundle ID that could not be registered, are skipped and
+// reported as failures. Warnings are collected without being applied.
+func (r *Reconciler) Apply(plan *ReconcilePlan) ReconcileResult {
+	var result ReconcileResult
+	failed := make(map[string]bool)
+	for _, change := range plan.Changes {
+		if change.apply == nil {
+			result.Warnings = append(result.Warnings, change)
+			continue
+		}
+		err := change.failedDependency(failed)
+		if err == nil {
+			err = change.apply()
+		}
+		if err != nil {
+			if change.key != "" {
+				failed[change.key] = true
+			}
+			result.Failures = append(result.Failures, ReconcileFailure{Change: change, Err: err})
+			continue
+		}
+		result.Applied = append(result.Applied, change)
+	}
+	return result
+}
+
+// failedDependency returns an error naming the first resource the change
+// depends on whose creation failed, or nil
+func (c ReconcileChange) failedDependency(failed map[string]bool) error {
+	for _, key := range c.needs {
+		if failed[key] {
+			return fmt.Errorf("skipped, %s failed", key)
+		}
+	}
+	return nil
+}
+
+// reconcileKey returns the key of a resource for ReconcileChange dependencies
+func reconcileKey(resource, name string) string {
+	return resource + " " + name
+}
+
+// planBundleIDs adds the changes of the bundle IDs and capabilities of spec
+func (r *Reconciler) planBundleIDs(plan *ReconcilePlan, spec *ReconcileSpec) error {
+	state := plan.state
+	bundleIDs, err := r.client.listResources("/bundleIds", nil)
+	if err != nil {
+		return fmt.Errorf("failed to list bundle ids: %w", err)
+	}
+	byIdentifier := make(map[string]map[string]interface{}, len(bundleIDs))
+	for _, bundleID := range bundleIDs {
+		identifier := stringAttribute(bundleID, "identifier")
+		byIdentifier[identifier] = bundleID
+		state.bundleIDs[identifier] = resourceID(bundleID)
+	}
+
+	capabilitiesAPI := NewBundleIdCapabilityAPI(r.client)
+	for _, desired := range spec.BundleIDs {
+		desired := desired
+		if desired.Platform == "" {
+			desired.Platform = "IOS"
+		}
+
+		current, ok := byIdentifier[desired.Identifier]
+		enabled := make(map[string]string)
+		desiredState := ReconcileBundleIDState{
+			Identifier: desired.Identifier,
+			Name:       bundleIDName(desired),
+			Platform:   desired.Platform,
+		}
+		if !ok {
+			plan.add(ReconcileChange{
+				Action:   ReconcileActionCreate,
+				Resource: "bundleId",
+				Name:     desired.Identifier,
+				Detail:   "register for " + desired.Platform,
+				apply: func() error {
+					return r.createBundleID(state, desired)
+				},
+				key: reconcileKey("bundleId", desired.Identifier),
+			})
+		} else {
+			response, err := NewBundleIdAPI(r.client).Query(resourceID(current), nil)
+			if err != nil {
+				return fmt.Errorf("failed to list capabilities of %s: %w", desired.Identifier, err)
+			}
+			for _, capability := range resourceList(response) {
+				enabled[stringAttribute(capability, "capabilityType")] = resourceID(capability)
+			}
+
+			// existing bundle IDs are never renamed or moved to another platform
+			desiredState.Name = stringAttribute(current, "name")
+			desiredState.Platform = stringAttribute(current, "platform")
+			state.observed.BundleIDs = append(state.observed.BundleIDs, ReconcileBundleIDState{
+				ID:           resourceID(current),
+				Identifier:   desired.Identifier,
+				Name:         desiredState.Name,
+				Platform:     desiredState.Platform,
+				Capabilities: sortedKeys(enabled),
+			})
+			if !spec.Prune {
+				desiredState.Capabilities = sortedKeys(enabled)
+			}
+		}
+
+		wanted := make(map[string]bool)
+		for _, capability := range desired.Capabilities {
+			capability := capability
+			wanted[capability] = true
+			if _, ok := enabled[capability]; ok {
+				if spec.Prune {
+					desiredState.Capabilities = append(desiredState.Capabilities, capability)
+				}
+				continue
+			}
+			desiredState.Capabilities = append(desiredState.Capabilities, capability)
+			plan.add(ReconcileChange{
+				Action:   ReconcileActionCreate,
+				Resource: "capability",
+				Name:     desired.Identifier,
+				Detail:   "enable " + capability,
+				apply: func() error {
+					bId, ok := state.bundleIDs[desired.Identifier]
+					if !ok {
+						return fmt.Errorf("bundle id %s was not registered", desired.Identifier)
+					}
+					_, err := capabilitiesAPI.Enable(bId, CapabilityType(capability))
+					return err
+				},
+				needs: []string{reconcileKey("bundleId", desired.Identifier)},
+			})
+		}
+
+		state.desired.BundleIDs = append(state.desired.BundleIDs, desiredState)
+
+		if !spec.Prune {
+			continue
+		}
+		for _, capability := range sortedKeys(enabled) {
+			if wanted[capability] {
+				continue
+			}
+			capabilityId := enabled[capability]
+			plan.add(ReconcileChange{
+				Action:   ReconcileActionDelete,
+				Resource: "capability",
+				Name:     desired.Identifier,
+				ID:       capabilityId,
+				Detail:   "disable " + capability,
+				apply: func() error {
+					_, err := capabilitiesAPI.Disable(capabilityId)
+					return err
+				},
+			})
+		}
+	}
+	return nil
+}
+
+// planCertificates resolves the certificates of spec and adds the changes of missing ones
+func (r *Reconciler) planCertificates(plan *ReconcilePlan, spec *ReconcileSpec) error {
+	state := plan.state
+	certificates, err := r.client.listResources("/certificates", nil)
+	if err != nil {
+		return fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	for _, desired := range spec.Certificates {
+		desired := desired
+		current, expired := matchCertificate(certificates, desired)
+		if current != nil {
+			observed := ReconcileCertificateState{
+				ID:           resourceID(current),
+				Name:         desired.Name,
+				Type:         stringAttribute(current, "certificateType"),
+				SerialNumber: stringAttribute(current, "serialNumber"),
+			}
+			state.observed.Certificates = append(state.observed.Certificates, observed)
+			state.desired.Certificates = append(state.desired.Certificates, observed)
+			state.certificates[desired.Name] = resourceID(current)
+			if expired {
+				plan.add(ReconcileChange{
+					Action:   ReconcileActionWarn,
+					Resource: "certificate",
+					Name:     desired.Name,
+					ID:       resourceID(current),
+					Detail:   "pinned certificate " + desired.SerialNumber + " has expired",
+				})
+			}
+			continue
+		}
+
+		state.desired.Certificates = append(state.desired.Certificates, ReconcileCertificateState{
+			Name:         desired.Name,
+			Type:         desired.Type,
+			SerialNumber: desired.SerialNumber,
+		})
+		if desired.CSR == "" {
+			plan.add(ReconcileChange{
+				Action:   ReconcileActionWarn,
+				Resource: "certificate",
+				Name:     desired.Name,
+				Detail:   "no matching " + desired.Type + " certificate and no csr to create one",
+			})
+			continue
+		}
+		plan.add(ReconcileChange{
+			Action:   ReconcileActionCreate,
+			Resource: "certificate",
+			Name:     desired.Name,
+			Detail:   "create " + desired.Type + " from " + desired.CSR,
+			apply: func() error {
+				csr, err := os.ReadFile(desired.CSR)
+				if err != nil {
+					return fmt.Errorf("failed to read csr: %w", err)
+				}
+				response, err := NewCertificatesAPI(r.client).CreateFromCSR(CertificateType(desired.Type), string(csr))
+				if err != nil {
+					return err
+				}
+				certificate, err := responseResource(response)
+				if err != nil {
+					return err
+				}
+				state.certificates[desired.Name] = resourceID(certificate)
+				return nil
+			},
+			key: reconcileKey("certificate", desired.Name),
+		})
+	}
+	return nil
+}
+
+// matchCertificate returns the certificate pinned by serial number, or the
+// valid certificate of the desired type that expires last, and whether it has expired
+func matchCertificate(certificates []map[string]interface{}, desired ReconcileCertificate) (map[string]interface{}, bool) {
+	now := time.Now()
+	var match map[string]interface{}
+	var matchExpiration time.Time
+	for _, certificate := range certificates {
+		expiration, _ := time.Parse(time.RFC3339, stringAttribute(certificate, "expirationDate"))
+		if desired.SerialNumber != "" {
+			if strings.EqualFold(stringAttribute(certificate, "serialNumber"), desired.SerialNumber) {
+				return certificate, !expiration.IsZero() && expiration.Before(now)
+			}
+			continue
+		}
+		if stringAttribute(certificate, "certificateType") != desired.Type || expiration.Before(now) {
+			continue
+		}
+		if match == nil || expiration.After(matchExpiration) {
+			match, matchExpiration = certificate, expiration
+		}
+	}
+	return match, false
+}
+
+// planDevices adds the changes of the devices of spec, and records the
+// desired state of every device for the profiles
+func (r *Reconciler) planDevices(plan *ReconcilePlan, spec *ReconcileSpec) error {
+	state := plan.state
+	deviceAPI := NewDeviceAPI(r.client)
+	devices, err := deviceAPI.List(nil)
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+	current := make(map[string]Device, len(devices))
+	for _, device := range devices {
+		udid := normalizeUDID(device.UDID)
+		current[udid] = device
+		state.devices[udid] = &reconcileDevice{
+			id:       device.ID,
+			name:     device.Name,
+			platform: device.Platform,
+			enabled:  device.Status == "ENABLED",
+		}
+		state.observed.Devices = append(state.observed.Devices, ReconcileDeviceState{
+			ID:       device.ID,
+			UDID:     udid,
+			Name:     device.Name,
+			Platform: device.Platform,
+			Status:   device.Status,
+		})
+	}
+
+	listed := make(map[string]bool)
+	for _, desired := range spec.Devices {
+		desired := desired
+		if desired.Platform == "" {
+			desired.Platform = "IOS"
+		}
+		udid := normalizeUDID(desired.UDID)
+		listed[udid] = true
+
+		device, ok := current[udid]
+		if !ok {
+			if desired.Disabled {
+				continue
+			}
+			registered := &reconcileDevice{name: desired.Name, platform: desired.Platform, enabled: true}
+			state.devices[udid] = registered
+			plan.add(ReconcileChange{
+				Action:   ReconcileActionCreate,
+				Resource: "device",
+				Name:     desired.UDID,
+				Detail:   fmt.Sprintf("register %q for %s", desired.Name, desired.Platform),
+				apply: func() error {
+					response, err := deviceAPI.Register(desired.Name, BundleIdPlatform(desired.Platform), desired.UDID)
+					if err != nil {
+						return err
+					}
+					resource, err := responseResource(response)
+					if err != nil {
+						return err
+					}
+					registered.id = resourceID(resource)
+					return nil
+				},
+				key: reconcileKey("device", udid),
+			})
+			continue
+		}
+
+		state.devices[udid].name = desired.Name
+		state.devices[udid].enabled = !desired.Disabled
+		var name, status string
+		var fields []string
+		if device.Name != desired.Name {
+			name = desired.Name
+			fields = append(fields, fmt.Sprintf("name %q -> %q", device.Name, desired.Name))
+		}
+		if wantStatus := deviceStatus(!desired.Disabled); device.Status != wantStatus {
+			status = wantStatus
+			fields = append(fields, fmt.Sprintf("status %s -> %s", device.Status, wantStatus))
+		}
+		if len(fields) > 0 {
+			plan.add(r.deviceUpdate(device, name, status, strings.Join(fields, ", ")))
+		}
+	}
+
+	if !spec.Prune {
+		return nil
+	}
+	for _, udid := range sortedKeys(current) {
+		device := current[udid]
+		if listed[udid] || device.Status != "ENABLED" {
+			continue
+		}
+		state.devices[udid].enabled = false
+		plan.add(r.deviceUpdate(device, "", "DISABLED", "status ENABLED -> DISABLED, not in spec"))
+	}
+	return nil
+}
+
+// deviceUpdate returns the change that renames, enables, or disables a device
+func (r *Reconciler) deviceUpdate(device Device, name, status, detail string) ReconcileChange {
+	return ReconcileChange{
+		Action:   ReconcileActionUpdate,
+		Resource: "device",
+		Name:     device.UDID,
+		ID:       device.ID,
+		Detail:   detail,
+		apply: func() error {
+			_, err := NewDeviceAPI(r.client).Update(device.ID, name, status)
+			return err
+		},
+	}
+}
+
+// deviceStatus returns the device status for enabled
+func deviceStatus(enabled bool) string {
+	if enabled {
+		return "ENABLED"
+	}
+	return "DISABLED"
+}
+
+// planProfiles adds the changes of the profiles of spec, and with Prune the
+// deletions of profiles the spec does not list
+func (r *Reconciler) planProfiles(plan *ReconcilePlan, spec *ReconcileSpec) error {
+	state := plan.state
+	profiles, err := r.client.listResources("/profiles", map[string]string{"include": "bundleId"})
+	if err != nil {
+		return fmt.Errorf("failed to list profiles: %w", err)
+	}
+	byName := make(map[string]map[string]interface{}, len(profiles))
+	for _, profile := range profiles {
+		byName[stringAttribute(profile, "name")] = profile
+	}
+
+	listed := make(map[string]bool)
+	for _, desired := range spec.Profiles {
+		desired := desired
+		listed[desired.Name] = true
+		if _, ok := state.bundleIDs[desired.BundleID]; !ok && !specHasBundleID(spec, desired.BundleID) {
+			return fmt.Errorf("profile %s: bundle id %s does not exist and is not in the spec", desired.Name, desired.BundleID)
+		}
+		udids := r.profileDevices(state, desired)
+		needs := profileNeeds(desired, udids)
+		desiredState := ReconcileProfileState{
+			Name:         desired.Name,
+			Type:         desired.Type,
+			BundleID:     desired.BundleID,
+			State:        "ACTIVE",
+			Certificates: desired.Certificates,
+			Devices:      udids,
+		}
+		state.desired.Profiles = append(state.desired.Profiles, desiredState)
+
+		current, ok := byName[desired.Name]
+		if !ok {
+			plan.add(ReconcileChange{
+				Action:   ReconcileActionCreate,
+				Resource: "profile",
+				Name:     desired.Name,
+				Detail:   fmt.Sprintf("%s for %s with %d certificates and %d devices", desired.Type, desired.BundleID, len(desired.Certificates), len(udids)),
+				apply: func() error {
+					return r.createProfile(state, desired, udids, "")
+				},
+				needs: needs,
+			})
+			continue
+		}
+
+		observed, err := r.observeProfile(state, current)
+		if err != nil {
+			return err
+		}
+		differences := profileDifferences(observed, desiredState)
+		if len(differences) == 0 {
+			continue
+		}
+		profileId := observed.ID
+		plan.add(ReconcileChange{
+			Action:   ReconcileActionReplace,
+			Resource: "profile",
+			Name:     desired.Name,
+			ID:       profileId,
+			Detail:   strings.Join(differences, ", "),
+			apply: func() error {
+				return r.createProfile(state, desired, udids, profileId)
+			},
+			needs: needs,
+		})
+	}
+
+	if !spec.Prune {
+		return nil
+	}
+	for _, name := range sortedKeys(byName) {
+		if listed[name] || xcodeManagedProfile(name) {
+			continue
+		}
+		observed, err := r.observeProfile(state, byName[name])
+		if err != nil {
+			return err
+		}
+		name, profileId := name, observed.ID
+		plan.add(ReconcileChange{
+			Action:   ReconcileActionDelete,
+			Resource: "profile",
+			Name:     name,
+			ID:       profileId,
+			Detail:   "not in spec",
+			apply: func() error {
+				if _, err := NewProfilesAPI(r.client).Delete(profileId); err != nil {
+					return err
+				}
+				delete(state.profiles, name)
+				return nil
+			},
+		})
+	}
+	return nil
+}
+
+// profileDevices returns the UDIDs of the devices a profile should hold
+func (r *Reconciler) profileDevices(state *reconcileState, desired ReconcileProfile) []string {
+	if !profileUsesDevices(desired.Type) {
+		return nil
+	}
+	if !desired.AllDevices {
+		udids := make([]string, len(desired.Devices))
+		for i, udid := range desired.Devices {
+			udids[i] = normalizeUDID(udid)
+		}
+		sort.Strings(udids)
+		return udids
+	}
+
+	platform := profilePlatform(desired.Type)
+	var udids []string
+	for _, udid := range sortedKeys(state.devices) {
+		device := state.devices[udid]
+		if device.enabled && device.platform == platform {
+			udids = append(udids, udid)
+		}
+	}
+	return udids
+}
+
+// profileNeeds returns the keys of the resources a profile depends on
+func profileNeeds(desired ReconcileProfile, udids []string) []string {
+	needs := []string{reconcileKey("bundleId", desired.BundleID)}
+	for _, name := range desired.Certificates {
+		needs = append(needs, reconcileKey("certificate", name))
+	}
+	for _, udid := range udids {
+		needs = append(needs, reconcileKey("device", udid))
+	}
+	return needs
+}
+
+// observeProfile returns the state of an existing profile and records it.
+// Its bundle ID, certificates, and devices are referred to by identifier,
+// spec certificate name, and UDID, or by ID when they have no such key.
+func (r *Reconciler) observeProfile(state *reconcileState, current map[string]interface{}) (ReconcileProfileState, error) {
+	observed := ReconcileProfileState{
+		ID:       resourceID(current),
+		Name:     stringAttribute(current, "name"),
+		Type:     stringAttribute(current, "profileType"),
+		BundleID: relationshipID(current, "bundleId"),
+		State:    stringAttribute(current, "profileState"),
+	}
+	for identifier, id := range state.bundleIDs {
+		if id == observed.BundleID {
+			observed.BundleID = identifier
+		}
+	}
+
+	certificateIds, err := r.client.linkageIDs("/profiles/" + observed.ID + "/relationships/certificates")
+	if err != nil {
+		return observed, fmt.Errorf("failed to list certificates of profile %s: %w", observed.Name, err)
+	}
+	certificateNames := make(map[string]string, len(state.certificates))
+	for name, id := range state.certificates {
+		certificateNames[id] = name
+	}
+	for _, id := range certificateIds {
+		observed.Certificates = append(observed.Certificates, firstNonEmpty(certificateNames[id], id))
+	}
+
+	deviceIds, err := r.client.linkageIDs("/profiles/" + observed.ID + "/relationships/devices")
+	if err != nil {
+		return observed, fmt.Errorf("failed to list devices of profile %s: %w", observed.Name, err)
+	}
+	udids := make(map[string]string, len(state.devices))
+	for udid, device := range state.devices {
+		if device.id != "" {
+			udids[device.id] = udid
+		}
+	}
+	for _, id := range deviceIds {
+		observed.Devices = append(observed.Devices, firstNonEmpty(udids[id], id))
+	}
+
+	state.profiles[observed.Name] = observed.ID
+	state.observed.Profiles = append(state.observed.Profiles, observed)
+	return observed, nil
+}
+
+// profileDifferences describes how an observed profile differs from desired
+func profileDifferences(observed, desired ReconcileProfileState) []string {
+	var differences []string
+	if observed.State != desired.State {
+		differences = append(differences, "state "+observed.State)
+	}
+	if observed.Type != desired.Type {
+		differences = append(differences, fmt.Sprintf("type %s -> %s", observed.Type, desired.Type))
+	}
+	if observed.BundleID != desired.BundleID {
+		differences = append(differences, "bundle id -> "+desired.BundleID)
+	}
+	if !sameIDs(observed.Certificates, desired.Certificates) {
+		differences = append(differences, "certificates")
+	}
+
+	current := make(map[string]bool, len(observed.Devices))
+	for _, udid := range observed.Devices {
+		current[udid] = true
+	}
+	added := 0
+	for _, udid := range desired.Devices {
+		if !current[udid] {
+			added++
+		}
+		delete(current, udid)
+	}
+	if added > 0 || len(current) > 0 {
+		differences = append(differences, fmt.Sprintf("devices +%d -%d", added, len(current)))
+	}
+	return differences
+}
+
+// createBundleID registers a bundle ID and records its ID
+func (r *Reconciler) createBundleID(state *reconcileState, desired ReconcileBundleID) error {
+	response, err := NewBundleIdAPI(r.client).Register(bundleIDName(desired), BundleIdPlatform(desired.Platform), desired.Identifier)
+	if err != nil {
+		return err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return err
+	}
+	state.bundleIDs[desired.Identifier] = resourceID(resource)
+	return nil
+}
+
+// createProfile creates a profile, resolving its bundle ID, certificates, and
+// devices. A profile being replaced is deleted only once they all resolve,
+// right before the create, since profile names must be unique.
+func (r *Reconciler) createProfile(state *reconcileState, desired ReconcileProfile, udids []string, replaceId string) error {
+	bId, ok := state.bundleIDs[desired.BundleID]
+	if !ok {
+		return fmt.Errorf("bundle id %s was not registered", desired.BundleID)
+	}
+
+	certificates := make([]string, len(desired.Certificates))
+	for i, name := range desired.Certificates {
+		id, ok := state.certificates[name]
+		if !ok {
+			return fmt.Errorf("certificate %s does not exist", name)
+		}
+		certificates[i] = id
+	}
+
+	devices := make([]string, 0, len(udids))
+	for _, udid := range udids {
+		device, ok := state.devices[udid]
+		if !ok || device.id == "" {
+			return fmt.Errorf("device %s is not registered", udid)
+		}
+		devices = append(devices, device.id)
+	}
+
+	if replaceId != "" {
+		if _, err := NewProfilesAPI(r.client).Delete(replaceId); err != nil {
+			return fmt.Errorf("failed to delete profile %s: %w", replaceId, err)
+		}
+		delete(state.profiles, desired.Name)
+	}
+	response, err := NewProfilesAPI(r.client).Create(desired.Name, bId, ProfileType(desired.Type), devices, certificates)
+	if err != nil {
+		return err
+	}
+	profile, err := responseResource(response)
+	if err != nil {
+		return err
+	}
+	state.profiles[desired.Name] = resourceID(profile)
+	return nil
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// bundleIDName returns the name of a bundle ID, derived from its identifier when the spec omits it
+func bundleIDName(desired ReconcileBundleID) string {
+	if desired.Name != "" {
+		return desired.Name
+	}
+	return strings.NewReplacer(".", " ", "-", " ", "_", " ").Replace(desired.Identifier)
+}
+
+// specHasBundleID reports whether spec declares a bundle ID
+func specHasBundleID(spec *ReconcileSpec, identifier string) bool {
+	for _, bundleID := range spec.BundleIDs {
+		if bundleID.Identifier == identifier {
+			return true
+		}
+	}
+	return false
+}
+
+// profilePlatform returns the device platform of a profile type
+func profilePlatform(profileType string) string {
+	if strings.HasPrefix(profileType, "MAC_") {
+		return "MAC_OS"
+	}
+	return "IOS"
+}
+
+// xcodeManagedProfile reports whether a profile is managed by Xcode, which
+// pruning leaves alone
+func xcodeManagedProfile(name string) bool {
+	return strings.HasPrefix(name, "XC ") || strings.Contains(name, "Team Provisioning Profile")
+}
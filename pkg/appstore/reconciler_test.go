@@ -0,0 +1,212 @@
+package appstore_test
+
+import (
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+)
+
+// fixtureSpec returns a spec matching the fixtures of appstoretest, with the
+// development profile holding every enabled iOS device
+func fixtureSpec() *appstore.ReconcileSpec {
+	return &appstore.ReconcileSpec{
+		BundleIDs: []appstore.ReconcileBundleID{{Identifier: "com.example.app"}},
+		Certificates: []appstore.ReconcileCertificate{
+			{Name: "development", Type: "DEVELOPMENT", SerialNumber: serialNumber("CERT0001")},
+			{Name: "distribution", Type: "DISTRIBUTION", SerialNumber: serialNumber("CERT0002")},
+		},
+		Profiles: []appstore.ReconcileProfile{
+			{Name: "com.example.app Development", Type: "IOS_APP_DEVELOPMENT", BundleID: "com.example.app", Certificates: []string{"development"}, AllDevices: true},
+			{Name: "com.example.app AppStore", Type: "IOS_APP_STORE", BundleID: "com.example.app", Certificates: []string{"distribution"}},
+		},
+	}
+}
+
+// serialNumber returns the serial number of a fixture certificate
+func serialNumber(id string) string {
+	return strings.ToUpper(hex.EncodeToString([]byte(id)))
+}
+
+// profileNamed returns the stored profile with a name
+func profileNamed(server *appstoretest.Server, name string) (appstoretest.Resource, bool) {
+	for _, profile := range server.Resources("profiles") {
+		if profile.Attributes["name"] == name {
+			return profile, true
+		}
+	}
+	return appstoretest.Resource{}, false
+}
+
+func TestReconcilerInSync(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+
+	plan, err := appstore.NewReconciler(newClient(t, server, nil)).Plan(fixtureSpec())
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	if plan.HasChanges() {
+		t.Errorf("got changes for a spec matching the fixtures:\n%s", plan)
+	}
+}
+
+func TestReconcilerPlanAndApply(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	reconciler := appstore.NewReconciler(newClient(t, server, nil))
+
+	spec := fixtureSpec()
+	spec.BundleIDs = append(spec.BundleIDs, appstore.ReconcileBundleID{Identifier: "com.example.widget"})
+	spec.Profiles = append(spec.Profiles, appstore.ReconcileProfile{
+		Name: "com.example.widget AppStore", Type: "IOS_APP_STORE", BundleID: "com.example.widget", Certificates: []string{"distribution"},
+	})
+	plan, err := reconciler.Plan(spec)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	want := []string{
+		"+ bundleId com.example.widget: register for IOS",
+		"+ profile com.example.widget AppStore: IOS_APP_STORE for com.example.widget with 1 certificates and 0 devices",
+	}
+	if got := plan.String(); got != strings.Join(want, "\n") {
+		t.Fatalf("got plan\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+	if len(server.Resources("bundleIds")) != 1 {
+		t.Fatal("Plan changed the server")
+	}
+
+	result := reconciler.Apply(plan)
+	if err := result.Err(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(result.Applied) != 2 {
+		t.Errorf("got %d applied changes, want 2", len(result.Applied))
+	}
+	profile, ok := profileNamed(server, "com.example.widget AppStore")
+	if !ok {
+		t.Fatal("profile was not created")
+	}
+	state := plan.State()
+	for _, bundleID := range state.Desired.BundleIDs {
+		if bundleID.Identifier == "com.example.widget" && bundleID.ID != profile.Relationships["bundleId"].Data.(map[string]interface{})["id"] {
+			t.Errorf("profile was created for another bundle id than %s", bundleID.ID)
+		}
+	}
+
+	plan, err = reconciler.Plan(spec)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	if plan.HasChanges() {
+		t.Errorf("got changes after applying the plan:\n%s", plan)
+	}
+}
+
+func TestReconcilerReplacesProfile(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	reconciler := appstore.NewReconciler(newClient(t, server, nil))
+
+	spec := fixtureSpec()
+	spec.Devices = []appstore.ReconcileDevice{{UDID: "00008110-000A1B2C3D4E5F99", Name: "New iPhone"}}
+	plan, err := reconciler.Plan(spec)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	if len(plan.Changes) != 2 || plan.Changes[1].Action != appstore.ReconcileActionReplace {
+		t.Fatalf("got plan\n%s\nwant a device registration and a profile replacement", plan)
+	}
+
+	if err := reconciler.Apply(plan).Err(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	profile, ok := profileNamed(server, "com.example.app Development")
+	if !ok || profile.ID == "PROFILE0001" {
+		t.Fatalf("profile was not replaced, got %+v", profile)
+	}
+	if devices := profile.Relationships["devices"].Data.([]interface{}); len(devices) != 3 {
+		t.Errorf("got %d devices in the new profile, want 3", len(devices))
+	}
+}
+
+func TestReconcilerSkipsChangesOfFailedDependencies(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	server.Handle(http.MethodPost, "/devices", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	reconciler := appstore.NewReconciler(newClient(t, server, nil))
+
+	spec := fixtureSpec()
+	spec.Devices = []appstore.ReconcileDevice{{UDID: "00008110-000A1B2C3D4E5F99", Name: "New iPhone"}}
+	plan, err := reconciler.Plan(spec)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+
+	result := reconciler.Apply(plan)
+	if len(result.Failures) != 2 {
+		t.Fatalf("got failures %v, want the device and the profile", result.Err())
+	}
+	if failure := result.Failures[1]; failure.Change.Resource != "profile" || !strings.Contains(failure.Err.Error(), "skipped") {
+		t.Errorf("got failure %s %s: %v, want the skipped profile", failure.Change.Resource, failure.Change.Name, failure.Err)
+	}
+	if _, ok := profileNamed(server, "com.example.app Development"); !ok {
+		t.Error("the profile was deleted although its replacement could not be created")
+	}
+	for _, request := range server.Requests() {
+		if request.Method == http.MethodDelete {
+			t.Errorf("got request DELETE %s", request.Path)
+		}
+	}
+}
+
+func TestProvisioningSync(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	sync := appstore.NewProvisioningSync(newClient(t, server, nil))
+	sync.OutputDir = t.TempDir()
+
+	spec := &appstore.ProvisioningSpec{
+		Type: appstore.ProvisioningTypeAppStore,
+		BundleIDs: []appstore.ProvisioningBundleID{
+			{Identifier: "com.example.app"},
+			{Identifier: "com.example.widget"},
+		},
+	}
+	actions, err := sync.Sync(spec)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	var got []string
+	for _, action := range actions {
+		got = append(got, action.Action+" "+action.Resource+" "+action.Name)
+	}
+	want := []string{
+		"created bundleId com.example.widget",
+		"created profile asc appstore com.example.app",
+		"created profile asc appstore com.example.widget",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got actions\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	for _, name := range []string{"asc_appstore_com.example.app.mobileprovision", "asc_appstore_com.example.widget.mobileprovision"} {
+		if _, err := os.Stat(filepath.Join(sync.OutputDir, name)); err != nil {
+			t.Errorf("profile was not written: %v", err)
+		}
+	}
+
+	actions, err = sync.Sync(spec)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(actions) != 0 {
+		t.Errorf("got actions %+v after syncing, want none", actions)
+	}
+}
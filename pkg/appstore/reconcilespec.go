@@ -0,0 +1,196 @@
+package appstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReconcileSpec is the desired state of the developer portal resources
+// managed by a Reconciler
+type ReconcileSpec struct {
+	BundleIDs    []ReconcileBundleID    `json:"bundleIds,omitempty" yaml:"bundleIds,omitempty"`
+	Certificates []ReconcileCertificate `json:"certificates,omitempty" yaml:"certificates,omitempty"`
+	Devices      []ReconcileDevice      `json:"devices,omitempty" yaml:"devices,omitempty"`
+	Profiles     []ReconcileProfile     `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+	// Prune deletes profiles, disables devices, and disables capabilities of
+	// managed bundle IDs that the spec does not list. Bundle IDs and
+	// certificates are never deleted.
+	Prune bool `json:"prune,omitempty" yaml:"prune,omitempty"`
+}
+
+// ReconcileBundleID describes a bundle ID, matched by identifier
+type ReconcileBundleID struct {
+	Identifier string `json:"identifier" yaml:"identifier"`
+	Name       string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Platform is IOS, MAC_OS, or UNIVERSAL, IOS when empty
+	Platform     string   `json:"platform,omitempty" yaml:"platform,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+}
+
+// ReconcileCertificate describes a signing certificate that profiles refer to by name
+type ReconcileCertificate struct {
+	// Name is the local name profiles refer to the certificate by
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type" yaml:"type"`
+	// SerialNumber pins an existing certificate, otherwise the valid
+	// certificate of Type that expires last is used
+	SerialNumber string `json:"serialNumber,omitempty" yaml:"serialNumber,omitempty"`
+	// CSR is the path of a certificate signing request to create the
+	// certificate from when none matches
+	CSR string `json:"csr,omitempty" yaml:"csr,omitempty"`
+}
+
+// ReconcileDevice describes a registered device, matched by UDID
+type ReconcileDevice struct {
+	UDID string `json:"udid" yaml:"udid"`
+	Name string `json:"name" yaml:"name"`
+	// Platform is IOS or MAC_OS, IOS when empty
+	Platform string `json:"platform,omitempty" yaml:"platform,omitempty"`
+	Disabled bool   `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// ReconcileProfile describes a provisioning profile, matched by name
+type ReconcileProfile struct {
+	Name string `json:"name" yaml:"name"`
+	// Type is a profile type such as IOS_APP_DEVELOPMENT or IOS_APP_STORE
+	Type string `json:"type" yaml:"type"`
+	// BundleID is the identifier of the profile's bundle ID
+	BundleID string `json:"bundleId" yaml:"bundleId"`
+	// Certificates are the names of certificates of the spec
+	Certificates []string `json:"certificates" yaml:"certificates"`
+	// Devices are the UDIDs of the devices of development and ad hoc profiles
+	Devices []string `json:"devices,omitempty" yaml:"devices,omitempty"`
+	// AllDevices includes every enabled device of the profile's platform
+	AllDevices bool `json:"allDevices,omitempty" yaml:"allDevices,omitempty"`
+}
+
+// LoadReconcileSpec reads a reconcile spec from a .json, .yaml, or .yml file
+func LoadReconcileSpec(path string) (*ReconcileSpec, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reconcile spec: %w", err)
+	}
+
+	var spec ReconcileSpec
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &spec)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &spec)
+	default:
+		return nil, fmt.Errorf("unsupported reconcile spec format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reconcile spec: %w", err)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks that the spec is complete, that its keys are unique, and
+// that profiles refer to certificates of the spec
+func (s *ReconcileSpec) Validate() error {
+	bundleIDs := make(map[string]bool)
+	for _, bundleID := range s.BundleIDs {
+		if bundleID.Identifier == "" {
+			return fmt.Errorf("bundle id identifier is required")
+		}
+		if bundleIDs[bundleID.Identifier] {
+			return fmt.Errorf("duplicate bundle id %s", bundleID.Identifier)
+		}
+		bundleIDs[bundleID.Identifier] = true
+		if bundleID.Platform != "" && !BundleIdPlatform(bundleID.Platform).IsValid() {
+			return fmt.Errorf("bundle id %s: invalid platform: %s", bundleID.Identifier, bundleID.Platform)
+		}
+		if err := validateCapabilities(bundleID.Capabilities); err != nil {
+			return fmt.Errorf("bundle id %s: %w", bundleID.Identifier, err)
+		}
+	}
+
+	certificates := make(map[string]bool)
+	for _, certificate := range s.Certificates {
+		if certificate.Name == "" {
+			return fmt.Errorf("certificate name is required")
+		}
+		if certificate.Type == "" {
+			return fmt.Errorf("certificate %s: type is required", certificate.Name)
+		}
+		if !CertificateType(certificate.Type).IsValid() {
+			return fmt.Errorf("certificate %s: invalid certificate type: %s", certificate.Name, certificate.Type)
+		}
+		if certificates[certificate.Name] {
+			return fmt.Errorf("duplicate certificate %s", certificate.Name)
+		}
+		certificates[certificate.Name] = true
+	}
+
+	devices := make(map[string]bool)
+	for _, device := range s.Devices {
+		if device.UDID == "" {
+			return fmt.Errorf("device udid is required")
+		}
+		if device.Name == "" {
+			return fmt.Errorf("device %s: name is required", device.UDID)
+		}
+		if device.Platform != "" && !BundleIdPlatform(device.Platform).IsValid() {
+			return fmt.Errorf("device %s: invalid platform: %s", device.UDID, device.Platform)
+		}
+		udid := normalizeUDID(device.UDID)
+		if devices[udid] {
+			return fmt.Errorf("duplicate device %s", device.UDID)
+		}
+		devices[udid] = true
+	}
+
+	profiles := make(map[string]bool)
+	for _, profile := range s.Profiles {
+		if profile.Name == "" {
+			return fmt.Errorf("profile name is required")
+		}
+		if profiles[profile.Name] {
+			return fmt.Errorf("duplicate profile %s", profile.Name)
+		}
+		profiles[profile.Name] = true
+		if profile.Type == "" {
+			return fmt.Errorf("profile %s: type is required", profile.Name)
+		}
+		if !ProfileType(profile.Type).IsValid() {
+			return fmt.Errorf("profile %s: invalid profile type: %s", profile.Name, profile.Type)
+		}
+		if profile.BundleID == "" {
+			return fmt.Errorf("profile %s: bundle id is required", profile.Name)
+		}
+		if len(profile.Certificates) == 0 {
+			return fmt.Errorf("profile %s: at least one certificate is required", profile.Name)
+		}
+		for _, name := range profile.Certificates {
+			if !certificates[name] {
+				return fmt.Errorf("profile %s: unknown certificate %s", profile.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateCapabilities checks that capabilities are known capability types
+func validateCapabilities(capabilities []string) error {
+	for _, capability := range capabilities {
+		if !CapabilityType(capability).IsValid() {
+			return fmt.Errorf("invalid capability type: %s", capability)
+		}
+	}
+	return nil
+}
+
+// normalizeUDID returns the form of a UDID used for comparisons
+func normalizeUDID(udid string) string {
+	return strings.ToUpper(strings.TrimSpace(udid))
+}
@@ -0,0 +1,174 @@
+package appstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// ReconcileStateVersion is the version of the ReconcileState JSON format
+const ReconcileStateVersion = 1
+
+// ReconcileState is the observed and desired state of the resources managed
+// by a spec, for tools such as Terraform providers and GitOps controllers.
+// Resources are sorted by their key, so the JSON encoding is stable. Hashes
+// cover every field except ID and Hash, and an observed resource is in sync
+// when its hash equals the hash of the desired resource with the same key.
+type ReconcileState struct {
+	Version  int                `json:"version"`
+	Observed ReconcileResources `json:"observed"`
+	Desired  ReconcileResources `json:"desired"`
+}
+
+// ReconcileResources lists the managed resources of each kind
+type ReconcileResources struct {
+	BundleIDs    []ReconcileBundleIDState    `json:"bundleIds"`
+	Certificates []ReconcileCertificateState `json:"certificates"`
+	Devices      []ReconcileDeviceState      `json:"devices"`
+	Profiles     []ReconcileProfileState     `json:"profiles"`
+}
+
+// ReconcileBundleIDState is the state of a bundle ID, keyed by identifier
+type ReconcileBundleIDState struct {
+	ID           string   `json:"id,omitempty"`
+	Identifier   string   `json:"identifier"`
+	Name         string   `json:"name"`
+	Platform     string   `json:"platform"`
+	Capabilities []string `json:"capabilities"`
+	Hash         string   `json:"hash"`
+}
+
+// ReconcileCertificateState is the state of a certificate, keyed by its spec name
+type ReconcileCertificateState struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	Hash         string `json:"hash"`
+}
+
+// ReconcileDeviceState is the state of a device, keyed by normalized UDID
+type ReconcileDeviceState struct {
+	ID       string `json:"id,omitempty"`
+	UDID     string `json:"udid"`
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+	Status   string `json:"status"`
+	Hash     string `json:"hash"`
+}
+
+// ReconcileProfileState is the state of a profile, keyed by name. The bundle
+// ID, certificates, and devices are referred to by their keys.
+type ReconcileProfileState struct {
+	ID           string   `json:"id,omitempty"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	BundleID     string   `json:"bundleId"`
+	State        string   `json:"state"`
+	Certificates []string `json:"certificates"`
+	Devices      []string `json:"devices"`
+	Hash         string   `json:"hash"`
+}
+
+// State returns the state observed while planning and the desired state the
+// plan converges to. Desired resources created by applying the plan carry
+// their new IDs when State is called after Apply.
+func (p *ReconcilePlan) State() *ReconcileState {
+	state := p.state
+	desired := ReconcileResources{
+		BundleIDs:    append([]ReconcileBundleIDState{}, state.desired.BundleIDs...),
+		Certificates: append([]ReconcileCertificateState{}, state.desired.Certificates...),
+		Profiles:     append([]ReconcileProfileState{}, state.desired.Profiles...),
+	}
+	for i, bundleID := range desired.BundleIDs {
+		desired.BundleIDs[i].ID = state.bundleIDs[bundleID.Identifier]
+	}
+	for i, certificate := range desired.Certificates {
+		desired.Certificates[i].ID = state.certificates[certificate.Name]
+	}
+	for _, udid := range sortedKeys(state.devices) {
+		device := state.devices[udid]
+		desired.Devices = append(desired.Devices, ReconcileDeviceState{
+			ID:       device.id,
+			UDID:     udid,
+			Name:     device.name,
+			Platform: device.platform,
+			Status:   deviceStatus(device.enabled),
+		})
+	}
+	for i, profile := range desired.Profiles {
+		desired.Profiles[i].ID = state.profiles[profile.Name]
+	}
+
+	return &ReconcileState{
+		Version:  ReconcileStateVersion,
+		Observed: state.observed.sorted(),
+		Desired:  desired.sorted(),
+	}
+}
+
+// sorted returns a copy of the resources sorted by key, with hashes
+func (r ReconcileResources) sorted() ReconcileResources {
+	sorted := ReconcileResources{
+		BundleIDs:    make([]ReconcileBundleIDState, len(r.BundleIDs)),
+		Certificates: make([]ReconcileCertificateState, len(r.Certificates)),
+		Devices:      make([]ReconcileDeviceState, len(r.Devices)),
+		Profiles:     make([]ReconcileProfileState, len(r.Profiles)),
+	}
+	for i, bundleID := range r.BundleIDs {
+		bundleID.Capabilities = sortedStrings(bundleID.Capabilities)
+		bundleID.ID, bundleID.Hash = "", ""
+		bundleID.Hash = contentHash(bundleID)
+		bundleID.ID = r.BundleIDs[i].ID
+		sorted.BundleIDs[i] = bundleID
+	}
+	for i, certificate := range r.Certificates {
+		certificate.ID, certificate.Hash = "", ""
+		certificate.Hash = contentHash(certificate)
+		certificate.ID = r.Certificates[i].ID
+		sorted.Certificates[i] = certificate
+	}
+	for i, device := range r.Devices {
+		device.ID, device.Hash = "", ""
+		device.Hash = contentHash(device)
+		device.ID = r.Devices[i].ID
+		sorted.Devices[i] = device
+	}
+	for i, profile := range r.Profiles {
+		profile.Certificates = sortedStrings(profile.Certificates)
+		profile.Devices = sortedStrings(profile.Devices)
+		profile.ID, profile.Hash = "", ""
+		profile.Hash = contentHash(profile)
+		profile.ID = r.Profiles[i].ID
+		sorted.Profiles[i] = profile
+	}
+
+	sort.Slice(sorted.BundleIDs, func(i, j int) bool {
+		return sorted.BundleIDs[i].Identifier < sorted.BundleIDs[j].Identifier
+	})
+	sort.Slice(sorted.Certificates, func(i, j int) bool {
+		return sorted.Certificates[i].Name < sorted.Certificates[j].Name
+	})
+	sort.Slice(sorted.Devices, func(i, j int) bool {
+		return sorted.Devices[i].UDID < sorted.Devices[j].UDID
+	})
+	sort.Slice(sorted.Profiles, func(i, j int) bool {
+		return sorted.Profiles[i].Name < sorted.Profiles[j].Name
+	})
+	return sorted
+}
+
+// contentHash returns the SHA-256 hash of the JSON encoding of value
+func contentHash(value interface{}) string {
+	content, _ := json.Marshal(value)
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// sortedStrings returns a sorted copy of values, never nil
+func sortedStrings(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
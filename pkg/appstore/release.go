@@ -0,0 +1,357 @@
+package appstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// ReleaseStep is a step of a release
+type ReleaseStep string
+
+// Release steps, in the order they run
+const (
+	ReleaseStepWaitForBuild    ReleaseStep = "wait_for_build"
+	ReleaseStepCompliance      ReleaseStep = "set_compliance"
+	ReleaseStepCreateVersion   ReleaseStep = "create_version"
+	ReleaseStepReleaseNotes    ReleaseStep = "set_release_notes"
+	ReleaseStepAttachBuild     ReleaseStep = "attach_build"
+	ReleaseStepPhasedRelease   ReleaseStep = "enable_phased_release"
+	ReleaseStepSubmitForReview ReleaseStep = "submit_for_review"
+)
+
+// ReleaseStatus is the status of a release step in a progress event
+type ReleaseStatus string
+
+// Release step statuses
+const (
+	ReleaseStatusStarted   ReleaseStatus = "started"
+	ReleaseStatusProgress  ReleaseStatus = "progress"
+	ReleaseStatusCompleted ReleaseStatus = "completed"
+	ReleaseStatusSkipped   ReleaseStatus = "skipped"
+	ReleaseStatusFailed    ReleaseStatus = "failed"
+)
+
+// ReleaseEvent is an entry of the structured progress log of a release
+type ReleaseEvent struct {
+	Time    time.Time     `json:"time"`
+	Step    ReleaseStep   `json:"step"`
+	Status  ReleaseStatus `json:"status"`
+	Message string        `json:"message,omitempty"`
+}
+
+// ReleaseConfig describes a release
+type ReleaseConfig struct {
+	AppID string
+	// Platform defaults to iOS
+	Platform      AppStorePlatform
+	VersionString string
+	// BuildNumber is the CFBundleVersion of the uploaded build to release
+	BuildNumber string
+	// UsesNonExemptEncryption answers export compliance for the build when it
+	// has no answer yet, for example from ITSAppUsesNonExemptEncryption
+	UsesNonExemptEncryption *bool
+	// ReleaseNotes are the "What's New" texts by locale
+	ReleaseNotes map[string]string
+	// PhasedRelease rolls the version out over seven days once released
+	PhasedRelease bool
+	// Submit submits the version for App Review
+	Submit bool
+	// PollInterval is the build processing poll interval, 30 seconds when zero
+	PollInterval time.Duration
+}
+
+// ReleaseState is the progress of a release, stored after every step so an
+// interrupted release resumes where it stopped
+type ReleaseState struct {
+	BuildID            string        `json:"buildId,omitempty"`
+	AppStoreVersionID  string        `json:"appStoreVersionId,omitempty"`
+	ReviewSubmissionID string        `json:"reviewSubmissionId,omitempty"`
+	Completed          []ReleaseStep `json:"completed,omitempty"`
+}
+
+// completed reports whether a step has completed
+func (s *ReleaseState) completed(step ReleaseStep) bool {
+	for _, completed := range s.Completed {
+		if completed == step {
+			return true
+		}
+	}
+	return false
+}
+
+// Release chains the steps of shipping a build: waiting for processing,
+// answering export compliance, creating the App Store version, setting
+// release notes, attaching the build, enabling phased release, and
+// submitting for review.
+//
+// Every step is idempotent against App Store Connect, and completed steps are
+// recorded in State. With StatePath set the state is loaded before running
+// and saved after every step, so a release can be run again after a failure.
+type Release struct {
+	// State is the progress of the release
+	State ReleaseState
+	// StatePath is the file the state is loaded from and saved to, if any
+	StatePath string
+	// Progress receives the structured progress log
+	Progress func(event ReleaseEvent)
+
+	client *Client
+	config ReleaseConfig
+}
+
+// NewRelease creates a release of a build
+func NewRelease(client *Client, config ReleaseConfig) (*Release, error) {
+	if config.AppID == "" {
+		return nil, fmt.Errorf("app id is required")
+	}
+	if config.VersionString == "" {
+		return nil, fmt.Errorf("version string is required")
+	}
+	if config.BuildNumber == "" {
+		return nil, fmt.Errorf("build number is required")
+	}
+	if config.Platform == "" {
+		config.Platform = AppStorePlatformIOS
+	}
+	return &Release{client: client, config: config}, nil
+}
+
+// Run runs the steps of the release that have not completed yet. It stops at
+// the first failing step.
+func (r *Release) Run(ctx context.Context) error {
+	if err := r.loadState(); err != nil {
+		return err
+	}
+
+	steps := []struct {
+		step    ReleaseStep
+		enabled bool
+		run     func(ctx context.Context) (string, error)
+	}{
+		{ReleaseStepWaitForBuild, true, r.waitForBuild},
+		{ReleaseStepCompliance, r.config.UsesNonExemptEncryption != nil, r.setCompliance},
+		{ReleaseStepCreateVersion, true, r.createVersion},
+		{ReleaseStepReleaseNotes, len(r.config.ReleaseNotes) > 0, r.setReleaseNotes},
+		{ReleaseStepAttachBuild, true, r.attachBuild},
+		{ReleaseStepPhasedRelease, r.config.PhasedRelease, r.enablePhasedRelease},
+		{ReleaseStepSubmitForReview, r.config.Submit, r.submitForReview},
+	}
+	for _, s := range steps {
+		switch {
+		case r.State.completed(s.step):
+			r.emit(s.step, ReleaseStatusSkipped, "already completed")
+			continue
+		case !s.enabled:
+			r.emit(s.step, ReleaseStatusSkipped, "not requested")
+			continue
+		}
+
+		r.emit(s.step, ReleaseStatusStarted, "")
+		message, err := s.run(ctx)
+		if err != nil {
+			r.emit(s.step, ReleaseStatusFailed, err.Error())
+			if saveErr := r.saveState(); saveErr != nil {
+				return saveErr
+			}
+			return fmt.Errorf("%s: %w", s.step, err)
+		}
+		r.State.Completed = append(r.State.Completed, s.step)
+		if err := r.saveState(); err != nil {
+			return err
+		}
+		r.emit(s.step, ReleaseStatusCompleted, message)
+	}
+	return nil
+}
+
+// waitForBuild waits until the build has been processed
+func (r *Release) waitForBuild(ctx context.Context) (string, error) {
+	build, err := NewBuildsAPI(r.client).WaitForUpload(ctx, r.config.AppID, r.config.BuildNumber, r.config.PollInterval, func(build Build) {
+		r.emit(ReleaseStepWaitForBuild, ReleaseStatusProgress, "build "+build.Version+" is "+string(build.ProcessingState))
+	})
+	if err != nil {
+		return "", err
+	}
+	if build.ProcessingState != BuildProcessingStateValid {
+		return "", fmt.Errorf("build %s finished processing as %s", build.Version, build.ProcessingState)
+	}
+	r.State.BuildID = build.ID
+	return "build " + build.Version + " is valid", nil
+}
+
+// setCompliance answers export compliance unless the build already has an answer
+func (r *Release) setCompliance(ctx context.Context) (string, error) {
+	buildsAPI := NewBuildsAPI(r.client.WithContext(ctx))
+	build, err := buildsAPI.Get(r.State.BuildID)
+	if err != nil {
+		return "", err
+	}
+	if build.UsesNonExemptEncryption != nil {
+		return fmt.Sprintf("already answered, uses non-exempt encryption: %t", *build.UsesNonExemptEncryption), nil
+	}
+	uses := *r.config.UsesNonExemptEncryption
+	if _, err := buildsAPI.SetUsesNonExemptEncryption(r.State.BuildID, uses); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("uses non-exempt encryption: %t", uses), nil
+}
+
+// createVersion finds or creates the App Store version
+func (r *Release) createVersion(ctx context.Context) (string, error) {
+	versionsAPI := NewAppStoreVersionsAPI(r.client.WithContext(ctx))
+	version, found, err := versionsAPI.Find(r.config.AppID, r.config.Platform, r.config.VersionString)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		r.State.AppStoreVersionID = version.ID
+		return "version " + version.VersionString + " exists in state " + version.AppStoreState, nil
+	}
+	version, err = versionsAPI.Create(r.config.AppID, r.config.Platform, r.config.VersionString)
+	if err != nil {
+		return "", err
+	}
+	r.State.AppStoreVersionID = version.ID
+	return "created version " + version.VersionString, nil
+}
+
+// setReleaseNotes sets the "What's New" text of every locale that differs
+func (r *Release) setReleaseNotes(ctx context.Context) (string, error) {
+	localizationsAPI := NewAppStoreVersionLocalizationsAPI(r.client.WithContext(ctx))
+	existing, err := localizationsAPI.List(r.State.AppStoreVersionID)
+	if err != nil {
+		return "", err
+	}
+	byLocale := make(map[string]AppStoreVersionLocalization, len(existing))
+	for _, localization := range existing {
+		byLocale[localization.Locale] = localization
+	}
+
+	changed := 0
+	for _, locale := range sortedKeys(r.config.ReleaseNotes) {
+		notes := r.config.ReleaseNotes[locale]
+		current, ok := byLocale[locale]
+		switch {
+		case !ok:
+			if _, err := localizationsAPI.Create(r.State.AppStoreVersionID, AppStoreVersionLocalization{Locale: locale, WhatsNew: notes}); err != nil {
+				return "", fmt.Errorf("failed to create %s localization: %w", locale, err)
+			}
+		case current.WhatsNew != notes:
+			if _, err := localizationsAPI.Update(current.ID, map[string]string{"whatsNew": notes}); err != nil {
+				return "", fmt.Errorf("failed to update %s localization: %w", locale, err)
+			}
+		default:
+			continue
+		}
+		changed++
+	}
+	return fmt.Sprintf("updated %d of %d locales", changed, len(r.config.ReleaseNotes)), nil
+}
+
+// attachBuild selects the build for the App Store version
+func (r *Release) attachBuild(ctx context.Context) (string, error) {
+	if _, err := NewAppStoreVersionsAPI(r.client.WithContext(ctx)).SetBuild(r.State.AppStoreVersionID, r.State.BuildID); err != nil {
+		return "", err
+	}
+	return "attached build " + r.config.BuildNumber, nil
+}
+
+// enablePhasedRelease creates the phased release of the version, which
+// conflicts when it already exists
+func (r *Release) enablePhasedRelease(ctx context.Context) (string, error) {
+	_, err := NewAppStoreVersionsAPI(r.client.WithContext(ctx)).EnablePhasedRelease(r.State.AppStoreVersionID)
+	if httpclient.IsConflict(err) {
+		return "phased release already enabled", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return "phased release enabled", nil
+}
+
+// submitForReview adds the version to an open review submission, creating
+// one when there is none, and submits it
+func (r *Release) submitForReview(ctx context.Context) (string, error) {
+	submissionsAPI := NewReviewSubmissionsAPI(r.client.WithContext(ctx))
+	if r.State.ReviewSubmissionID == "" {
+		open, err := submissionsAPI.All(r.config.AppID, map[string]string{
+			"filter[platform]": string(r.config.Platform),
+			"filter[state]":    "READY_FOR_REVIEW",
+			"limit":            "1",
+		})
+		if err != nil {
+			return "", err
+		}
+		if resources := resourceList(open); len(resources) > 0 {
+			r.State.ReviewSubmissionID = resourceID(resources[0])
+		} else {
+			response, err := submissionsAPI.Create(r.config.AppID, r.config.Platform)
+			if err != nil {
+				return "", err
+			}
+			resource, err := responseResource(response)
+			if err != nil {
+				return "", err
+			}
+			r.State.ReviewSubmissionID = resourceID(resource)
+		}
+		if err := r.saveState(); err != nil {
+			return "", err
+		}
+	}
+
+	// the version is already an item when a previous run stopped after adding it
+	if _, err := submissionsAPI.AddVersion(r.State.ReviewSubmissionID, r.State.AppStoreVersionID); err != nil && !httpclient.IsConflict(err) {
+		return "", err
+	}
+	if _, err := submissionsAPI.Submit(r.State.ReviewSubmissionID); err != nil {
+		return "", err
+	}
+	return "submitted for review", nil
+}
+
+// emit sends an event to the progress log
+func (r *Release) emit(step ReleaseStep, status ReleaseStatus, message string) {
+	if r.Progress != nil {
+		r.Progress(ReleaseEvent{Time: time.Now().UTC(), Step: step, Status: status, Message: message})
+	}
+}
+
+// loadState reads the state from StatePath, if it exists
+func (r *Release) loadState() error {
+	if r.StatePath == "" {
+		return nil
+	}
+	content, err := os.ReadFile(r.StatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read release state: %w", err)
+	}
+	if err := json.Unmarshal(content, &r.State); err != nil {
+		return fmt.Errorf("failed to parse release state: %w", err)
+	}
+	return nil
+}
+
+// saveState writes the state to StatePath, if set
+func (r *Release) saveState() error {
+	if r.StatePath == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(r.State, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal release state: %w", err)
+	}
+	if err := os.WriteFile(r.StatePath, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write release state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package appstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReportCache stores downloaded report files on disk, keyed by report type,
+// sub type, frequency, version, date and vendor. Every file is stored with a
+// SHA-256 checksum and entries failing verification are discarded.
+type ReportCache struct {
+	dir string
+}
+
+// NewReportCache creates a new report cache in the given directory
+func NewReportCache(dir string) (*ReportCache, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("cache directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &ReportCache{dir: dir}, nil
+}
+
+// Get returns the cached content of a report, reporting whether it was found
+func (c *ReportCache) Get(params SalesReportParams) ([]byte, bool, error) {
+	path, err := c.path(params)
+	if err != nil {
+		return nil, false, err
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cached report: %w", err)
+	}
+
+	checksum, err := os.ReadFile(path + ".sha256")
+	if err != nil || strings.TrimSpace(string(checksum)) != sha256Hex(content) {
+		// Corrupt or incomplete entry, drop it so it is downloaded again
+		c.remove(path)
+		return nil, false, nil
+	}
+
+	return content, true, nil
+}
+
+// Put stores the content of a report
+func (c *ReportCache) Put(params SalesReportParams, content []byte) error {
+	path, err := c.path(params)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write the content before its checksum, an interrupted write then fails verification
+	if err := writeFileAtomic(path, content); err != nil {
+		return fmt.Errorf("failed to write cached report: %w", err)
+	}
+	if err := writeFileAtomic(path+".sha256", []byte(sha256Hex(content))); err != nil {
+		return fmt.Errorf("failed to write cached report checksum: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a report from the cache
+func (c *ReportCache) Delete(params SalesReportParams) error {
+	path, err := c.path(params)
+	if err != nil {
+		return err
+	}
+	c.remove(path)
+	return nil
+}
+
+// path returns the cache file path of a report
+func (c *ReportCache) path(params SalesReportParams) (string, error) {
+	version, err := reportVersion(params.ReportType, params.Version)
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.Join([]string{
+		string(params.ReportType),
+		string(params.ReportSubType),
+		string(params.Frequency),
+		version,
+		params.ReportDate,
+	}, "_") + ".tsv"
+
+	return filepath.Join(c.dir, sanitizeCacheName(params.VendorNumber), sanitizeCacheName(name)), nil
+}
+
+func (c *ReportCache) remove(path string) {
+	os.Remove(path)
+	os.Remove(path + ".sha256")
+}
+
+// sanitizeCacheName replaces characters that are unsafe in file names
+func sanitizeCacheName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == 0 {
+			return '-'
+		}
+		return r
+	}, name)
+}
+
+// sha256Hex returns the hex encoded SHA-256 checksum of content
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFileAtomic writes a file through a temporary file and a rename
+func writeFileAtomic(path string, content []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,188 @@
+package appstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"appstore-connect-api/pkg/httpclient"
+)
+
+const (
+	defaultReportRangeConcurrency = 4
+	defaultReportRangeRetries     = 2
+	defaultReportRangeRetryDelay  = 2 * time.Second
+)
+
+// ReportRangeOptions configures a date range report download
+type ReportRangeOptions struct {
+	// Concurrency bounds the number of simultaneous downloads
+	Concurrency int
+	// Retries is the number of additional attempts per report after a 429,
+	// 5xx, or network error, a negative value disables retries; RetryDelay
+	// is doubled after every failed attempt
+	Retries    int
+	RetryDelay time.Duration
+	// SkipMissing treats reports that are not available as empty instead of failing
+	SkipMissing bool
+	// Progress is invoked after every report download completes or fails
+	Progress func(progress ReportProgress)
+}
+
+// ReportProgress describes the progress of a date range report download
+type ReportProgress struct {
+	Completed  int
+	Total      int
+	ReportDate string
+	Err        error
+}
+
+// DownloadRange downloads the report described by spec for every period
+// between from and to, inclusive, and consolidates the rows into one report
+// in date order. Daily reports are fetched per day, weekly reports per week
+// ending on Sunday, monthly reports per month and yearly reports per year.
+func (r *ReportsAPI) DownloadRange(spec SalesReportParams, from, to time.Time, options ReportRangeOptions) (*Report, error) {
+	dates, err := reportDates(spec.Frequency, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultReportRangeConcurrency
+	}
+	if options.Retries < 0 {
+		options.Retries = 0
+	} else if options.Retries == 0 {
+		options.Retries = defaultReportRangeRetries
+	}
+	if options.RetryDelay <= 0 {
+		options.RetryDelay = defaultReportRangeRetryDelay
+	}
+
+	reports := make([]*Report, len(dates))
+	errs := make([]error, len(dates))
+	semaphore := make(chan struct{}, options.Concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	completed := 0
+
+	for i, date := range dates {
+		wg.Add(1)
+		go func(i int, date string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			params := spec
+			params.ReportDate = date
+			reports[i], errs[i] = r.downloadWithRetry(params, options)
+
+			mu.Lock()
+			completed++
+			progress := ReportProgress{Completed: completed, Total: len(dates), ReportDate: date, Err: errs[i]}
+			mu.Unlock()
+			if options.Progress != nil {
+				options.Progress(progress)
+			}
+		}(i, date)
+	}
+	wg.Wait()
+
+	consolidated := &Report{ReportType: spec.ReportType, ReportSubType: spec.ReportSubType}
+	knownColumns := make(map[string]bool)
+	for i, report := range reports {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to download report for %s: %w", dates[i], errs[i])
+		}
+		if report == nil {
+			continue
+		}
+		for _, column := range report.Columns {
+			if !knownColumns[column] {
+				knownColumns[column] = true
+				consolidated.Columns = append(consolidated.Columns, column)
+			}
+		}
+		consolidated.Rows = append(consolidated.Rows, report.Rows...)
+	}
+
+	return consolidated, nil
+}
+
+// downloadWithRetry downloads and parses a single report, retrying transient
+// failures. The delay between attempts ends early when the context of the
+// client is done.
+func (r *ReportsAPI) downloadWithRetry(params SalesReportParams, options ReportRangeOptions) (*Report, error) {
+	ctx := r.client.GetHTTPClient().Context()
+	delay := options.RetryDelay
+	for attempt := 0; ; attempt++ {
+		content, err := r.DownloadSalesReport(params)
+		if err == nil {
+			return ParseReport(bytes.NewReader(content))
+		}
+		if errors.Is(err, ErrReportNotAvailable) && options.SkipMissing {
+			return nil, nil
+		}
+		if attempt >= options.Retries || !transientReportError(err) {
+			return nil, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// transientReportError reports whether a failed report download is worth
+// retrying: a 429 or 5xx response, or a network error
+func transientReportError(err error) bool {
+	if httpclient.IsRateLimited(err) || httpclient.IsServerError(err) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// reportDates returns the report dates of a frequency between from and to
+func reportDates(frequency ReportFrequency, from, to time.Time) ([]string, error) {
+	from, to = dateOnly(from), dateOnly(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("range end is before range start")
+	}
+
+	var dates []string
+	switch frequency {
+	case ReportFrequencyDaily:
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			dates = append(dates, d.Format("2006-01-02"))
+		}
+	case ReportFrequencyWeekly:
+		// Weekly reports are identified by the Sunday ending the week
+		d := from.AddDate(0, 0, (7-int(from.Weekday()))%7)
+		for ; !d.After(to.AddDate(0, 0, 6)); d = d.AddDate(0, 0, 7) {
+			dates = append(dates, d.Format("2006-01-02"))
+		}
+	case ReportFrequencyMonthly:
+		for d := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !d.After(to); d = d.AddDate(0, 1, 0) {
+			dates = append(dates, d.Format("2006-01"))
+		}
+	case ReportFrequencyYearly:
+		for y := from.Year(); y <= to.Year(); y++ {
+			dates = append(dates, fmt.Sprintf("%d", y))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported frequency: %s", frequency)
+	}
+	return dates, nil
+}
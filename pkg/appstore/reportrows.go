@@ -0,0 +1,336 @@
+package appstore
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// reportDateLayouts are the date formats used across report types
+var reportDateLayouts = []string{"01/02/2006", "2006-01-02"}
+
+// SalesReportRow is a row of a SALES report
+type SalesReportRow struct {
+	Provider              string    `report:"Provider"`
+	ProviderCountry       string    `report:"Provider Country"`
+	SKU                   string    `report:"SKU"`
+	Developer             string    `report:"Developer"`
+	Title                 string    `report:"Title"`
+	Version               string    `report:"Version"`
+	ProductTypeIdentifier string    `report:"Product Type Identifier"`
+	Units                 int       `report:"Units"`
+	DeveloperProceeds     float64   `report:"Developer Proceeds"`
+	BeginDate             time.Time `report:"Begin Date"`
+	EndDate               time.Time `report:"End Date"`
+	CustomerCurrency      string    `report:"Customer Currency"`
+	CountryCode           string    `report:"Country Code"`
+	CurrencyOfProceeds    string    `report:"Currency of Proceeds"`
+	AppleIdentifier       string    `report:"Apple Identifier"`
+	CustomerPrice         float64   `report:"Customer Price"`
+	PromoCode             string    `report:"Promo Code"`
+	ParentIdentifier      string    `report:"Parent Identifier"`
+	Subscription          string    `report:"Subscription"`
+	Period                string    `report:"Period"`
+	Category              string    `report:"Category"`
+	CMB                   string    `report:"CMB"`
+	Device                string    `report:"Device"`
+	SupportedPlatforms    string    `report:"Supported Platforms"`
+	ProceedsReason        string    `report:"Proceeds Reason"`
+	PreservedPricing      bool      `report:"Preserved Pricing"`
+	Client                string    `report:"Client"`
+	OrderType             string    `report:"Order Type"`
+	// Extra holds columns this struct does not know about
+	Extra map[string]string `report:",extra"`
+}
+
+// SubscriptionReportRow is a row of a SUBSCRIPTION report, giving the active
+// subscription counts per product, price and territory
+type SubscriptionReportRow struct {
+	AppName                      string  `report:"App Name"`
+	AppAppleID                   string  `report:"App Apple ID"`
+	SubscriptionName             string  `report:"Subscription Name"`
+	SubscriptionAppleID          string  `report:"Subscription Apple ID"`
+	SubscriptionGroupID          string  `report:"Subscription Group ID"`
+	StandardSubscriptionDuration string  `report:"Standard Subscription Duration"`
+	PromotionalOfferName         string  `report:"Promotional Offer Name"`
+	PromotionalOfferID           string  `report:"Promotional Offer ID"`
+	CustomerPrice                float64 `report:"Customer Price"`
+	CustomerCurrency             string  `report:"Customer Currency"`
+	DeveloperProceeds            float64 `report:"Developer Proceeds"`
+	ProceedsCurrency             string  `report:"Proceeds Currency"`
+	PreservedPricing             bool    `report:"Preserved Pricing"`
+	ProceedsReason               string  `report:"Proceeds Reason"`
+	Client                       string  `report:"Client"`
+	Device                       string  `report:"Device"`
+	State                        string  `report:"State"`
+	Country                      string  `report:"Country"`
+	ActiveStandardPrice          int     `report:"Active Standard Price Subscriptions"`
+	ActiveFreeTrial              int     `report:"Active Free Trial Introductory Offer Subscriptions"`
+	ActivePayUpFront             int     `report:"Active Pay Up Front Introductory Offer Subscriptions"`
+	ActivePayAsYouGo             int     `report:"Active Pay As You Go Introductory Offer Subscriptions"`
+	FreeTrialPromotionalOffer    int     `report:"Free Trial Promotional Offer Subscriptions"`
+	PayUpFrontPromotionalOffer   int     `report:"Pay Up Front Promotional Offer Subscriptions"`
+	PayAsYouGoPromotionalOffer   int     `report:"Pay As You Go Promotional Offer Subscriptions"`
+	FreeTrialOfferCode           int     `report:"Free Trial Offer Code Subscriptions"`
+	PayUpFrontOfferCode          int     `report:"Pay Up Front Offer Code Subscriptions"`
+	PayAsYouGoOfferCode          int     `report:"Pay As You Go Offer Code Subscriptions"`
+	MarketingOptIns              int     `report:"Marketing Opt-Ins"`
+	BillingRetry                 int     `report:"Billing Retry"`
+	GracePeriod                  int     `report:"Grace Period"`
+	Subscribers                  int     `report:"Subscribers"`
+	// Extra holds columns this struct does not know about
+	Extra map[string]string `report:",extra"`
+}
+
+// SubscriberReportRow is a row of a SUBSCRIBER report, giving transaction
+// level subscription activity per anonymized subscriber
+type SubscriberReportRow struct {
+	EventDate                    time.Time `report:"Event Date"`
+	AppName                      string    `report:"App Name"`
+	AppAppleID                   string    `report:"App Apple ID"`
+	SubscriptionName             string    `report:"Subscription Name"`
+	SubscriptionAppleID          string    `report:"Subscription Apple ID"`
+	SubscriptionGroupID          string    `report:"Subscription Group ID"`
+	StandardSubscriptionDuration string    `report:"Standard Subscription Duration"`
+	SubscriptionOfferType        string    `report:"Subscription Offer Type"`
+	SubscriptionOfferDuration    string    `report:"Subscription Offer Duration"`
+	MarketingOptInDuration       string    `report:"Marketing Opt-In Duration"`
+	CustomerPrice                float64   `report:"Customer Price"`
+	CustomerCurrency             string    `report:"Customer Currency"`
+	DeveloperProceeds            float64   `report:"Developer Proceeds"`
+	ProceedsCurrency             string    `report:"Proceeds Currency"`
+	PreservedPricing             bool      `report:"Preserved Pricing"`
+	ProceedsReason               string    `report:"Proceeds Reason"`
+	Client                       string    `report:"Client"`
+	Country                      string    `report:"Country"`
+	SubscriberID                 string    `report:"Subscriber ID"`
+	SubscriberIDReset            bool      `report:"Subscriber ID Reset"`
+	Refund                       bool      `report:"Refund"`
+	PurchaseDate                 time.Time `report:"Purchase Date"`
+	Units                        int       `report:"Units"`
+	// Extra holds columns this struct does not know about
+	Extra map[string]string `report:",extra"`
+}
+
+// SubscriptionEventReportRow is a row of a SUBSCRIPTION_EVENT report, giving
+// aggregated subscription events such as upgrades, cancellations and renewals
+type SubscriptionEventReportRow struct {
+	EventDate                    time.Time `report:"Event Date"`
+	Event                        string    `report:"Event"`
+	AppName                      string    `report:"App Name"`
+	AppAppleID                   string    `report:"App Apple ID"`
+	SubscriptionName             string    `report:"Subscription Name"`
+	SubscriptionAppleID          string    `report:"Subscription Apple ID"`
+	SubscriptionGroupID          string    `report:"Subscription Group ID"`
+	StandardSubscriptionDuration string    `report:"Standard Subscription Duration"`
+	SubscriptionOfferType        string    `report:"Subscription Offer Type"`
+	SubscriptionOfferDuration    string    `report:"Subscription Offer Duration"`
+	MarketingOptIn               bool      `report:"Marketing Opt-In"`
+	MarketingOptInDuration       string    `report:"Marketing Opt-In Duration"`
+	PreservedPricing             bool      `report:"Preserved Pricing"`
+	ProceedsReason               string    `report:"Proceeds Reason"`
+	PromotionalOfferName         string    `report:"Promotional Offer Name"`
+	PromotionalOfferID           string    `report:"Promotional Offer ID"`
+	ConsecutivePaidPeriods       int       `report:"Consecutive Paid Periods"`
+	OriginalStartDate            time.Time `report:"Original Start Date"`
+	Device                       string    `report:"Device"`
+	Client                       string    `report:"Client"`
+	State                        string    `report:"State"`
+	Country                      string    `report:"Country"`
+	PreviousSubscriptionName     string    `report:"Previous Subscription Name"`
+	PreviousSubscriptionAppleID  string    `report:"Previous Subscription Apple ID"`
+	DaysBeforeCanceling          int       `report:"Days Before Canceling"`
+	CancellationReason           string    `report:"Cancellation Reason"`
+	DaysCanceled                 int       `report:"Days Canceled"`
+	Quantity                     int       `report:"Quantity"`
+	// Extra holds columns this struct does not know about
+	Extra map[string]string `report:",extra"`
+}
+
+// PreOrderReportRow is a row of a PRE_ORDER report
+type PreOrderReportRow struct {
+	Provider           string    `report:"Provider"`
+	ProviderCountry    string    `report:"Provider Country"`
+	Title              string    `report:"Title"`
+	SKU                string    `report:"SKU"`
+	Developer          string    `report:"Developer"`
+	PreOrderStartDate  time.Time `report:"Pre-Order Start Date"`
+	PreOrderEndDate    time.Time `report:"Pre-Order End Date"`
+	Ordered            int       `report:"Ordered"`
+	Canceled           int       `report:"Canceled"`
+	CumulativeOrdered  int       `report:"Cumulative Ordered"`
+	CumulativeCanceled int       `report:"Cumulative Canceled"`
+	StartDate          time.Time `report:"Start Date"`
+	EndDate            time.Time `report:"End Date"`
+	CountryCode        string    `report:"Country Code"`
+	AppleIdentifier    string    `report:"Apple Identifier"`
+	Device             string    `report:"Device"`
+	SupportedPlatforms string    `report:"Supported Platforms"`
+	Category           string    `report:"Category"`
+	Client             string    `report:"Client"`
+	// Extra holds columns this struct does not know about
+	Extra map[string]string `report:",extra"`
+}
+
+// TypedRows decodes the report rows into the row type matching the report
+// type, returning e.g. []SalesReportRow for SALES reports
+func (r *Report) TypedRows() (interface{}, error) {
+	switch r.ReportType {
+	case ReportTypeSales:
+		return r.SalesRows()
+	case ReportTypeSubscription:
+		return r.SubscriptionRows()
+	case ReportTypeSubscriptionEvent:
+		return r.SubscriptionEventRows()
+	case ReportTypeSubscriber:
+		return r.SubscriberRows()
+	case ReportTypePreOrder:
+		return r.PreOrderRows()
+	default:
+		return nil, fmt.Errorf("no row type for report type: %s", r.ReportType)
+	}
+}
+
+// SalesRows decodes the report rows as SALES report rows
+func (r *Report) SalesRows() ([]SalesReportRow, error) {
+	var rows []SalesReportRow
+	err := r.DecodeRows(&rows)
+	return rows, err
+}
+
+// SubscriptionRows decodes the report rows as SUBSCRIPTION report rows
+func (r *Report) SubscriptionRows() ([]SubscriptionReportRow, error) {
+	var rows []SubscriptionReportRow
+	err := r.DecodeRows(&rows)
+	return rows, err
+}
+
+// SubscriptionEventRows decodes the report rows as SUBSCRIPTION_EVENT report rows
+func (r *Report) SubscriptionEventRows() ([]SubscriptionEventReportRow, error) {
+	var rows []SubscriptionEventReportRow
+	err := r.DecodeRows(&rows)
+	return rows, err
+}
+
+// SubscriberRows decodes the report rows as SUBSCRIBER report rows
+func (r *Report) SubscriberRows() ([]SubscriberReportRow, error) {
+	var rows []SubscriberReportRow
+	err := r.DecodeRows(&rows)
+	return rows, err
+}
+
+// PreOrderRows decodes the report rows as PRE_ORDER report rows
+func (r *Report) PreOrderRows() ([]PreOrderReportRow, error) {
+	var rows []PreOrderReportRow
+	err := r.DecodeRows(&rows)
+	return rows, err
+}
+
+// DecodeRows decodes the report rows into out, a pointer to a slice of structs.
+// Fields are matched to columns by their `report` tag; a map[string]string
+// field tagged `report:",extra"` receives any columns without a matching field.
+// Strings, integers, floats, booleans and time.Time fields are supported.
+func (r *Report) DecodeRows(out interface{}) error {
+	slice := reflect.ValueOf(out)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("decode target must be a pointer to a slice")
+	}
+	rowType := slice.Elem().Type().Elem()
+	if rowType.Kind() != reflect.Struct {
+		return fmt.Errorf("decode target must be a slice of structs")
+	}
+
+	// Map column names to struct fields
+	fields := make(map[string]int)
+	extraField := -1
+	for i := 0; i < rowType.NumField(); i++ {
+		tag := rowType.Field(i).Tag.Get("report")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		if tag == ",extra" {
+			extraField = i
+			continue
+		}
+		fields[tag] = i
+	}
+
+	rows := reflect.MakeSlice(slice.Elem().Type(), 0, len(r.Rows))
+	for rowIndex, row := range r.Rows {
+		item := reflect.New(rowType).Elem()
+		for column, value := range row {
+			fieldIndex, ok := fields[column]
+			if !ok {
+				if extraField >= 0 {
+					extra := item.Field(extraField)
+					if extra.IsNil() {
+						extra.Set(reflect.MakeMap(extra.Type()))
+					}
+					extra.SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(value))
+				}
+				continue
+			}
+			if err := setReportField(item.Field(fieldIndex), value); err != nil {
+				return fmt.Errorf("row %d, column %q: %w", rowIndex+1, column, err)
+			}
+		}
+		rows = reflect.Append(rows, item)
+	}
+
+	slice.Elem().Set(rows)
+	return nil
+}
+
+// setReportField parses a report value into a struct field
+func setReportField(field reflect.Value, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		for _, layout := range reportDateLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				field.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid date %q", value)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int64:
+		number := strings.ReplaceAll(value, ",", "")
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			f, ferr := strconv.ParseFloat(number, 64)
+			if ferr != nil {
+				return fmt.Errorf("invalid integer %q", value)
+			}
+			n = int64(f)
+		}
+		field.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		switch strings.ToLower(value) {
+		case "yes", "y", "true", "1":
+			field.SetBool(true)
+		case "no", "n", "false", "0":
+			field.SetBool(false)
+		default:
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
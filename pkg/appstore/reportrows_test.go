@@ -0,0 +1,77 @@
+package appstore_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+const salesReport = "Provider\tSKU\tTitle\tUnits\tDeveloper Proceeds\tBegin Date\tEnd Date\tPreserved Pricing\tNew Column\n" +
+	"APPLE\tapp.sku\tExample\t1,204\t0.70\t01/31/2026\t01/31/2026\tYes\tx\n" +
+	"APPLE\tiap.sku\tCoins\t3\t 2.10 \t2026-01-31\t2026-01-31\t\t\n"
+
+func TestSalesRows(t *testing.T) {
+	report, err := appstore.ParseReport(strings.NewReader(salesReport))
+	if err != nil {
+		t.Fatalf("ParseReport: %v", err)
+	}
+	report.ReportType = appstore.ReportTypeSales
+
+	rows, err := report.SalesRows()
+	if err != nil {
+		t.Fatalf("SalesRows: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+
+	date := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	first := rows[0]
+	if first.SKU != "app.sku" || first.Units != 1204 || first.DeveloperProceeds != 0.70 {
+		t.Errorf("got SKU %q, units %d, proceeds %v", first.SKU, first.Units, first.DeveloperProceeds)
+	}
+	if !first.BeginDate.Equal(date) || !first.PreservedPricing {
+		t.Errorf("got begin date %v and preserved pricing %v", first.BeginDate, first.PreservedPricing)
+	}
+	if first.Extra["New Column"] != "x" {
+		t.Errorf("got extra columns %v, want the unknown column", first.Extra)
+	}
+
+	second := rows[1]
+	if second.DeveloperProceeds != 2.10 || !second.EndDate.Equal(date) || second.PreservedPricing {
+		t.Errorf("got proceeds %v, end date %v, preserved pricing %v", second.DeveloperProceeds, second.EndDate, second.PreservedPricing)
+	}
+
+	typed, err := report.TypedRows()
+	if err != nil {
+		t.Fatalf("TypedRows: %v", err)
+	}
+	if _, ok := typed.([]appstore.SalesReportRow); !ok {
+		t.Errorf("got rows of type %T, want []SalesReportRow", typed)
+	}
+}
+
+func TestDecodeRowsInvalidValue(t *testing.T) {
+	report, err := appstore.ParseReport(strings.NewReader("Units\nmany\n"))
+	if err != nil {
+		t.Fatalf("ParseReport: %v", err)
+	}
+
+	_, err = report.SalesRows()
+	if err == nil || !strings.Contains(err.Error(), `row 1, column "Units"`) {
+		t.Errorf("got %v, want an error naming the row and column", err)
+	}
+}
+
+func TestDecodeRowsTarget(t *testing.T) {
+	report := &appstore.Report{}
+	var rows []string
+	if err := report.DecodeRows(&rows); err == nil {
+		t.Error("decoding into a slice of strings succeeded")
+	}
+	if _, err := report.TypedRows(); err == nil {
+		t.Error("got rows for a report without a type")
+	}
+}
@@ -0,0 +1,366 @@
+package appstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// ErrReportNotAvailable is returned when no report exists for the requested
+// parameters, e.g. when there were no sales on the requested date
+var ErrReportNotAvailable = errors.New("report not available")
+
+// ReportType represents a Sales and Trends report type
+type ReportType string
+
+// Sales and Trends report types
+const (
+	ReportTypeSales             ReportType = "SALES"
+	ReportTypeSubscription      ReportType = "SUBSCRIPTION"
+	ReportTypeSubscriptionEvent ReportType = "SUBSCRIPTION_EVENT"
+	ReportTypeSubscriber        ReportType = "SUBSCRIBER"
+	ReportTypePreOrder          ReportType = "PRE_ORDER"
+)
+
+// ReportSubType represents a Sales and Trends report sub type
+type ReportSubType string
+
+// Sales and Trends report sub types
+const (
+	ReportSubTypeSummary  ReportSubType = "SUMMARY"
+	ReportSubTypeDetailed ReportSubType = "DETAILED"
+)
+
+// ReportFrequency represents the period a report covers
+type ReportFrequency string
+
+// Sales and Trends report frequencies
+const (
+	ReportFrequencyDaily   ReportFrequency = "DAILY"
+	ReportFrequencyWeekly  ReportFrequency = "WEEKLY"
+	ReportFrequencyMonthly ReportFrequency = "MONTHLY"
+	ReportFrequencyYearly  ReportFrequency = "YEARLY"
+)
+
+// supportedReportVersions lists the accepted versions per report type, the
+// first entry being the default used when no version is given
+var supportedReportVersions = map[ReportType][]string{
+	ReportTypeSales:             {"1_0", "1_1"},
+	ReportTypeSubscription:      {"1_4", "1_3"},
+	ReportTypeSubscriptionEvent: {"1_4", "1_3"},
+	ReportTypeSubscriber:        {"1_4", "1_3"},
+	ReportTypePreOrder:          {"1_0"},
+}
+
+// defaultReportSubTypes are the sub types requested by the typed row methods
+// when the parameters have none, the only sub type of most report types
+var defaultReportSubTypes = map[ReportType]ReportSubType{
+	ReportTypeSales:             ReportSubTypeSummary,
+	ReportTypeSubscription:      ReportSubTypeSummary,
+	ReportTypeSubscriptionEvent: ReportSubTypeSummary,
+	ReportTypeSubscriber:        ReportSubTypeDetailed,
+	ReportTypePreOrder:          ReportSubTypeSummary,
+}
+
+// dailyOnlyReportTypes are the report types only available with daily frequency
+var dailyOnlyReportTypes = map[ReportType]bool{
+	ReportTypeSubscription:      true,
+	ReportTypeSubscriptionEvent: true,
+	ReportTypeSubscriber:        true,
+}
+
+// SalesReportParams holds the parameters of a Sales and Trends report request
+type SalesReportParams struct {
+	ReportType    ReportType
+	ReportSubType ReportSubType
+	Frequency     ReportFrequency
+	// ReportDate is formatted as YYYY-MM-DD for daily and weekly reports,
+	// YYYY-MM for monthly reports and YYYY for yearly reports
+	ReportDate   string
+	VendorNumber string
+	Version      string
+}
+
+// ReportRow is a single report line keyed by column name. The typed row
+// methods, such as ReportsAPI.SalesRows and Report.SalesRows, decode rows into
+// the struct of their report type.
+type ReportRow map[string]string
+
+// Report is a parsed Sales and Trends report
+type Report struct {
+	ReportType    ReportType    `json:"reportType"`
+	ReportSubType ReportSubType `json:"reportSubType"`
+	Columns       []string      `json:"columns"`
+	Rows          []ReportRow   `json:"rows"`
+}
+
+// ReportsAPI handles Sales and Trends report operations
+type ReportsAPI struct {
+	client *Client
+	cache  *ReportCache
+}
+
+// NewReportsAPI creates a new Reports API client
+func NewReportsAPI(client *Client) *ReportsAPI {
+	return &ReportsAPI{client: client}
+}
+
+// WithContext returns a copy of the API whose requests are sent with ctx,
+// which also cancels the retries of DownloadRange
+func (r *ReportsAPI) WithContext(ctx context.Context) *ReportsAPI {
+	return &ReportsAPI{client: r.client.WithContext(ctx), cache: r.cache}
+}
+
+// WithCache returns a copy of the API that serves reports from the cache when
+// available and stores every downloaded report in it
+func (r *ReportsAPI) WithCache(cache *ReportCache) *ReportsAPI {
+	return &ReportsAPI{client: r.client, cache: cache}
+}
+
+// SalesReport downloads, decompresses and parses a Sales and Trends report
+func (r *ReportsAPI) SalesReport(params SalesReportParams) (*Report, error) {
+	content, err := r.DownloadSalesReport(params)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := ParseReport(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	report.ReportType = params.ReportType
+	report.ReportSubType = params.ReportSubType
+
+	return report, nil
+}
+
+// SalesRows downloads a SALES report and decodes its rows. The report type
+// and, when empty, the sub type of params are filled in.
+func (r *ReportsAPI) SalesRows(params SalesReportParams) ([]SalesReportRow, error) {
+	return reportRows[SalesReportRow](r, ReportTypeSales, params)
+}
+
+// SubscriptionRows downloads a SUBSCRIPTION report and decodes its rows
+func (r *ReportsAPI) SubscriptionRows(params SalesReportParams) ([]SubscriptionReportRow, error) {
+	return reportRows[SubscriptionReportRow](r, ReportTypeSubscription, params)
+}
+
+// SubscriptionEventRows downloads a SUBSCRIPTION_EVENT report and decodes its rows
+func (r *ReportsAPI) SubscriptionEventRows(params SalesReportParams) ([]SubscriptionEventReportRow, error) {
+	return reportRows[SubscriptionEventReportRow](r, ReportTypeSubscriptionEvent, params)
+}
+
+// SubscriberRows downloads a SUBSCRIBER report and decodes its rows
+func (r *ReportsAPI) SubscriberRows(params SalesReportParams) ([]SubscriberReportRow, error) {
+	return reportRows[SubscriberReportRow](r, ReportTypeSubscriber, params)
+}
+
+// PreOrderRows downloads a PRE_ORDER report and decodes its rows
+func (r *ReportsAPI) PreOrderRows(params SalesReportParams) ([]PreOrderReportRow, error) {
+	return reportRows[PreOrderReportRow](r, ReportTypePreOrder, params)
+}
+
+// reportRows downloads a report of reportType and decodes its rows as T
+func reportRows[T any](r *ReportsAPI, reportType ReportType, params SalesReportParams) ([]T, error) {
+	if params.ReportType == "" {
+		params.ReportType = reportType
+	} else if params.ReportType != reportType {
+		return nil, fmt.Errorf("%s rows require a %s report, not %s", reportType, reportType, params.ReportType)
+	}
+	if params.ReportSubType == "" {
+		params.ReportSubType = defaultReportSubTypes[reportType]
+	}
+
+	report, err := r.SalesReport(params)
+	if err != nil {
+		return nil, err
+	}
+	var rows []T
+	if err := report.DecodeRows(&rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// DownloadSalesReport downloads a Sales and Trends report and returns the decompressed TSV content
+func (r *ReportsAPI) DownloadSalesReport(params SalesReportParams) ([]byte, error) {
+	query, err := params.query()
+	if err != nil {
+		return nil, err
+	}
+
+	if r.cache != nil {
+		content, ok, err := r.cache.Get(params)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return content, nil
+		}
+	}
+
+	if err := r.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	body, err := r.client.GetHTTPClient().GetRaw("/salesReports", query, "application/a-gzip")
+	if err != nil {
+		if httpclient.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s %s", ErrReportNotAvailable, params.ReportType, params.ReportDate)
+		}
+		return nil, err
+	}
+
+	content, err := gunzip(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.cache != nil {
+		if err := r.cache.Put(params, content); err != nil {
+			return nil, err
+		}
+	}
+
+	return content, nil
+}
+
+// StreamSalesReport downloads a Sales and Trends report as a stream of
+// decompressed TSV content, for reports too large to hold in memory. The
+// report cache is not used. The caller must close the stream.
+func (r *ReportsAPI) StreamSalesReport(params SalesReportParams) (io.ReadCloser, error) {
+	query, err := params.query()
+	if err != nil {
+		return nil, err
+	}
+
+	body, _, err := r.client.Download("/salesReports", query)
+	if err != nil {
+		if httpclient.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s %s", ErrReportNotAvailable, params.ReportType, params.ReportDate)
+		}
+		return nil, err
+	}
+	return body, nil
+}
+
+// query validates the parameters and converts them to query parameters
+func (p SalesReportParams) query() (map[string]string, error) {
+	if p.ReportType == "" {
+		return nil, fmt.Errorf("report type is required")
+	}
+	if p.ReportSubType == "" {
+		return nil, fmt.Errorf("report sub type is required")
+	}
+	if p.Frequency == "" {
+		return nil, fmt.Errorf("frequency is required")
+	}
+	if p.VendorNumber == "" {
+		return nil, fmt.Errorf("vendor number is required")
+	}
+
+	if dailyOnlyReportTypes[p.ReportType] && p.Frequency != ReportFrequencyDaily {
+		return nil, fmt.Errorf("%s reports are only available with %s frequency", p.ReportType, ReportFrequencyDaily)
+	}
+
+	version, err := reportVersion(p.ReportType, p.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	query := map[string]string{
+		"filter[reportType]":    string(p.ReportType),
+		"filter[reportSubType]": string(p.ReportSubType),
+		"filter[frequency]":     string(p.Frequency),
+		"filter[vendorNumber]":  p.VendorNumber,
+	}
+	if p.ReportDate != "" {
+		query["filter[reportDate]"] = p.ReportDate
+	}
+	if version != "" {
+		query["filter[version]"] = version
+	}
+
+	return query, nil
+}
+
+// reportVersion validates the requested version of a report type, returning
+// the default version when none is requested
+func reportVersion(reportType ReportType, version string) (string, error) {
+	versions, ok := supportedReportVersions[reportType]
+	if !ok {
+		return version, nil
+	}
+	if version == "" {
+		return versions[0], nil
+	}
+	for _, v := range versions {
+		if v == version {
+			return version, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported version %s for %s reports", version, reportType)
+}
+
+// ParseReport parses tab-separated report content with a header line
+func ParseReport(reader io.Reader) (*Report, error) {
+	tsv := csv.NewReader(reader)
+	tsv.Comma = '\t'
+	tsv.LazyQuotes = true
+	tsv.FieldsPerRecord = -1
+
+	columns, err := tsv.Read()
+	if err == io.EOF {
+		return &Report{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report header: %w", err)
+	}
+
+	report := &Report{Columns: columns}
+	for {
+		record, err := tsv.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report row: %w", err)
+		}
+
+		row := make(ReportRow, len(columns))
+		for i, column := range columns {
+			if i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	return report, nil
+}
+
+// gunzip decompresses gzip content, returning it unchanged if it is not compressed
+func gunzip(content []byte) ([]byte, error) {
+	if len(content) < 2 || content[0] != 0x1f || content[1] != 0x8b {
+		return content, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress report: %w", err)
+	}
+	defer reader.Close()
+
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress report: %w", err)
+	}
+
+	return decompressed, nil
+}
@@ -0,0 +1,70 @@
+package appstore
+
+import (
+	"appstore-connect-api/pkg/httpclient"
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// resourceList returns the resource objects of a list response
+func resourceList(response map[string]interface{}) []map[string]interface{} {
+	return jsonapi.DataList(response)
+}
+
+// resourceID returns the ID of a resource object
+func resourceID(resource map[string]interface{}) string {
+	return jsonapi.ResourceID(resource)
+}
+
+// stringAttribute returns a string attribute of a resource object
+func stringAttribute(resource map[string]interface{}, name string) string {
+	if attributes, ok := resource["attributes"].(map[string]interface{}); ok {
+		if v, ok := attributes[name].(string); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// nextPageParams returns the query parameters of the next page of a list
+// response, or nil when the response is the last page
+func nextPageParams(response map[string]interface{}) map[string]string {
+	return httpclient.NextPageParams(response)
+}
+
+// decodeAttributes decodes the attributes of a resource object into out,
+// a pointer to a struct with json tags matching the attribute names
+func decodeAttributes(resource map[string]interface{}, out interface{}) error {
+	return jsonapi.DecodeAttributes(resource, out)
+}
+
+// responseResource returns the resource object of a single resource response
+func responseResource(response map[string]interface{}) (map[string]interface{}, error) {
+	return jsonapi.Data(response)
+}
+
+// relationshipID returns the ID of a to-one relationship of a resource object
+func relationshipID(resource map[string]interface{}, name string) string {
+	linkage, _ := jsonapi.ToOneLinkage(resource, name)
+	return linkage.ID
+}
+
+// unmarshalResources decodes resource objects into typed models, see
+// jsonapi.Unmarshal
+func unmarshalResources[T any](resources []map[string]interface{}) ([]T, error) {
+	models := make([]T, len(resources))
+	for i, resource := range resources {
+		if err := jsonapi.Unmarshal(resource, &models[i]); err != nil {
+			return nil, err
+		}
+	}
+	return models, nil
+}
+
+// includedResources indexes the included resources of a response by type and ID
+func includedResources(response map[string]interface{}) map[string]map[string]interface{} {
+	index := make(map[string]map[string]interface{})
+	for linkage, resource := range jsonapi.Included(response) {
+		index[linkage.Type+"/"+linkage.ID] = resource
+	}
+	return index
+}
@@ -0,0 +1,79 @@
+package appstore
+
+import (
+	"fmt"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// ReviewSubmissionsAPI handles App Review submission operations
+type ReviewSubmissionsAPI struct {
+	client *Client
+}
+
+// NewReviewSubmissionsAPI creates a new ReviewSubmissions API client
+func NewReviewSubmissionsAPI(client *Client) *ReviewSubmissionsAPI {
+	return &ReviewSubmissionsAPI{client: client}
+}
+
+// All retrieves the review submissions of an app
+func (r *ReviewSubmissionsAPI) All(appId string, params map[string]string) (map[string]interface{}, error) {
+	if err := r.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	query := map[string]string{"filter[app]": appId}
+	for k, v := range params {
+		query[k] = v
+	}
+	return r.client.GetHTTPClient().Get("/reviewSubmissions", query)
+}
+
+// Create creates a review submission of an app on a platform, to which
+// items are added before it is submitted
+func (r *ReviewSubmissionsAPI) Create(appId string, platform AppStorePlatform) (map[string]interface{}, error) {
+	if appId == "" {
+		return nil, fmt.Errorf("app id is required")
+	}
+	if platform == "" {
+		return nil, fmt.Errorf("platform is required")
+	}
+	if err := r.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return r.client.GetHTTPClient().PostJSON("/reviewSubmissions", jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "reviewSubmissions",
+		Attributes: map[string]string{"platform": string(platform)},
+		Relationships: map[string]jsonapi.Relationship{
+			"app": jsonapi.ToOne("apps", appId),
+		},
+	}))
+}
+
+// AddVersion adds an App Store version to a review submission
+func (r *ReviewSubmissionsAPI) AddVersion(reviewSubmissionId, appStoreVersionId string) (map[string]interface{}, error) {
+	if appStoreVersionId == "" {
+		return nil, fmt.Errorf("app store version id is required")
+	}
+	if err := r.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return r.client.GetHTTPClient().PostJSON("/reviewSubmissionItems", jsonapi.NewDocument(jsonapi.Resource{
+		Type: "reviewSubmissionItems",
+		Relationships: map[string]jsonapi.Relationship{
+			"reviewSubmission": jsonapi.ToOne("reviewSubmissions", reviewSubmissionId),
+			"appStoreVersion":  jsonapi.ToOne("appStoreVersions", appStoreVersionId),
+		},
+	}))
+}
+
+// Submit submits a review submission to App Review
+func (r *ReviewSubmissionsAPI) Submit(reviewSubmissionId string) (map[string]interface{}, error) {
+	if err := r.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return r.client.GetHTTPClient().PatchJSON("/reviewSubmissions/"+reviewSubmissionId, jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "reviewSubmissions",
+		ID:         reviewSubmissionId,
+		Attributes: map[string]bool{"submitted": true},
+	}))
+}
@@ -0,0 +1,267 @@
+package appstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const defaultReviewWatchInterval = 5 * time.Minute
+
+// reviewStateInitialized is the state key marking an app as polled before,
+// so an app without reviews keeps a baseline. Review IDs are never empty.
+const reviewStateInitialized = ""
+
+// ReviewEventType represents the kind of change observed on a review
+type ReviewEventType string
+
+// Review event types
+const (
+	ReviewAdded  ReviewEventType = "ADDED"
+	ReviewEdited ReviewEventType = "EDITED"
+)
+
+// ReviewEvent is a new or edited customer review
+type ReviewEvent struct {
+	Type   ReviewEventType `json:"type"`
+	AppID  string          `json:"appId"`
+	Review CustomerReview  `json:"review"`
+}
+
+// ReviewStateStore persists the reviews seen per app, keyed by review ID,
+// along with a marker under the empty key once the app has been polled
+type ReviewStateStore interface {
+	Load(appId string) (map[string]string, error)
+	Save(appId string, state map[string]string) error
+}
+
+// MemoryReviewStateStore keeps review state in memory
+type MemoryReviewStateStore struct {
+	mu    sync.Mutex
+	state map[string]map[string]string
+}
+
+// NewMemoryReviewStateStore creates a new in-memory review state store
+func NewMemoryReviewStateStore() *MemoryReviewStateStore {
+	return &MemoryReviewStateStore{state: make(map[string]map[string]string)}
+}
+
+// Load returns the stored state of an app
+func (m *MemoryReviewStateStore) Load(appId string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state := make(map[string]string, len(m.state[appId]))
+	for k, v := range m.state[appId] {
+		state[k] = v
+	}
+	return state, nil
+}
+
+// Save stores the state of an app
+func (m *MemoryReviewStateStore) Save(appId string, state map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	saved := make(map[string]string, len(state))
+	for k, v := range state {
+		saved[k] = v
+	}
+	m.state[appId] = saved
+	return nil
+}
+
+// FileReviewStateStore keeps review state as one JSON file per app in a directory
+type FileReviewStateStore struct {
+	dir string
+}
+
+// NewFileReviewStateStore creates a new file-backed review state store
+func NewFileReviewStateStore(dir string) (*FileReviewStateStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return &FileReviewStateStore{dir: dir}, nil
+}
+
+// Load returns the stored state of an app
+func (f *FileReviewStateStore) Load(appId string) (map[string]string, error) {
+	state := make(map[string]string)
+	content, err := os.ReadFile(f.path(appId))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read review state: %w", err)
+	}
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse review state: %w", err)
+	}
+	return state, nil
+}
+
+// Save stores the state of an app
+func (f *FileReviewStateStore) Save(appId string, state map[string]string) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review state: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves partial state
+	tmp := f.path(appId) + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write review state: %w", err)
+	}
+	return os.Rename(tmp, f.path(appId))
+}
+
+func (f *FileReviewStateStore) path(appId string) string {
+	return filepath.Join(f.dir, "reviews-"+appId+".json")
+}
+
+// ReviewWatcherConfig holds the review watcher configuration
+type ReviewWatcherConfig struct {
+	AppIDs   []string
+	Interval time.Duration
+	// SkipInitial records the reviews found by the first poll of an app
+	// instead of reporting them all as new. Reviews of later polls are
+	// reported, also when the app had no reviews at first.
+	SkipInitial bool
+}
+
+// ReviewWatcher polls customer reviews and reports new and edited ones
+type ReviewWatcher struct {
+	api    *CustomerReviewsAPI
+	config ReviewWatcherConfig
+	store  ReviewStateStore
+}
+
+// NewReviewWatcher creates a new review watcher. A nil store keeps state in memory.
+func NewReviewWatcher(api *CustomerReviewsAPI, config ReviewWatcherConfig, store ReviewStateStore) (*ReviewWatcher, error) {
+	if len(config.AppIDs) == 0 {
+		return nil, fmt.Errorf("at least one app id is required")
+	}
+	if config.Interval <= 0 {
+		config.Interval = defaultReviewWatchInterval
+	}
+	if store == nil {
+		store = NewMemoryReviewStateStore()
+	}
+	return &ReviewWatcher{api: api, config: config, store: store}, nil
+}
+
+// Poll checks every app once and returns the new and edited reviews
+func (w *ReviewWatcher) Poll() ([]ReviewEvent, error) {
+	var events []ReviewEvent
+	for _, appId := range w.config.AppIDs {
+		appEvents, err := w.pollApp(w.api, appId)
+		if err != nil {
+			return events, err
+		}
+		events = append(events, appEvents...)
+	}
+	return events, nil
+}
+
+// Watch polls until the context is cancelled, invoking handler for each event.
+// State is only saved after the handler accepted all events of an app, so
+// events are redelivered if the handler fails.
+func (w *ReviewWatcher) Watch(ctx context.Context, handler func(event ReviewEvent) error) error {
+	api := NewCustomerReviewsAPI(w.api.client.WithContext(ctx))
+	for {
+		for _, appId := range w.config.AppIDs {
+			if err := w.watchApp(api, appId, handler); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(w.config.Interval):
+		}
+	}
+}
+
+// Events starts watching in the background and delivers events on a channel.
+// Both channels are closed when watching stops; a terminal error other than
+// context cancellation is sent on the error channel first.
+func (w *ReviewWatcher) Events(ctx context.Context) (<-chan ReviewEvent, <-chan error) {
+	events := make(chan ReviewEvent)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		err := w.Watch(ctx, func(event ReviewEvent) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil && err != ctx.Err() {
+			errs <- err
+		}
+	}()
+
+	return events, errs
+}
+
+// pollApp returns the events of an app and saves its new state
+func (w *ReviewWatcher) pollApp(api *CustomerReviewsAPI, appId string) ([]ReviewEvent, error) {
+	var events []ReviewEvent
+	err := w.watchApp(api, appId, func(event ReviewEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	return events, err
+}
+
+// watchApp delivers the events of an app to handler and saves its new state.
+// Every page of reviews is read, since older reviews can be edited too.
+func (w *ReviewWatcher) watchApp(api *CustomerReviewsAPI, appId string, handler func(event ReviewEvent) error) error {
+	state, err := w.store.Load(appId)
+	if err != nil {
+		return err
+	}
+	_, polled := state[reviewStateInitialized]
+	// State saved before the marker existed holds the reviews seen instead
+	initial := !polled && len(state) == 0
+	state[reviewStateInitialized] = "true"
+
+	reviews, err := api.AllReviews(appId, map[string]string{"sort": "-createdDate"})
+	if err != nil {
+		return fmt.Errorf("failed to list reviews for app %s: %w", appId, err)
+	}
+
+	for _, review := range reviews {
+		fingerprint := review.fingerprint()
+		previous, seen := state[review.ID]
+		state[review.ID] = fingerprint
+
+		if initial && w.config.SkipInitial {
+			continue
+		}
+
+		event := ReviewEvent{AppID: appId, Review: review}
+		switch {
+		case !seen:
+			event.Type = ReviewAdded
+		case previous != fingerprint:
+			event.Type = ReviewEdited
+		default:
+			continue
+		}
+
+		if err := handler(event); err != nil {
+			return err
+		}
+	}
+
+	return w.store.Save(appId, state)
+}
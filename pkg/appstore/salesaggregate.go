@@ -0,0 +1,99 @@
+package appstore
+
+import "time"
+
+// SalesTotals holds aggregated units and proceeds of sales report rows
+type SalesTotals struct {
+	Units int `json:"units"`
+	// Proceeds holds the developer proceeds keyed by currency of proceeds
+	Proceeds map[string]float64 `json:"proceeds"`
+}
+
+// add accumulates a row into the totals. Developer proceeds in sales reports
+// are per unit, so they are multiplied by the units of the row.
+func (t *SalesTotals) add(row SalesReportRow) {
+	if t.Proceeds == nil {
+		t.Proceeds = make(map[string]float64)
+	}
+	t.Units += row.Units
+	if row.DeveloperProceeds != 0 {
+		t.Proceeds[row.CurrencyOfProceeds] += row.DeveloperProceeds * float64(row.Units)
+	}
+}
+
+// TotalSales aggregates all rows into a single total
+func TotalSales(rows []SalesReportRow) SalesTotals {
+	totals := SalesTotals{Proceeds: make(map[string]float64)}
+	for _, row := range rows {
+		totals.add(row)
+	}
+	return totals
+}
+
+// SalesBySKU aggregates rows per SKU
+func SalesBySKU(rows []SalesReportRow) map[string]SalesTotals {
+	return groupSales(rows, func(row SalesReportRow) string {
+		return row.SKU
+	})
+}
+
+// SalesByCountry aggregates rows per country code
+func SalesByCountry(rows []SalesReportRow) map[string]SalesTotals {
+	return groupSales(rows, func(row SalesReportRow) string {
+		return row.CountryCode
+	})
+}
+
+// SalesByDate aggregates rows per begin date, keyed as YYYY-MM-DD
+func SalesByDate(rows []SalesReportRow) map[string]SalesTotals {
+	return groupSales(rows, func(row SalesReportRow) string {
+		return row.BeginDate.Format("2006-01-02")
+	})
+}
+
+// FilterSalesByDate returns the rows whose begin date falls within from and to, inclusive
+func FilterSalesByDate(rows []SalesReportRow, from, to time.Time) []SalesReportRow {
+	var filtered []SalesReportRow
+	for _, row := range rows {
+		if row.BeginDate.Before(from) || row.BeginDate.After(to) {
+			continue
+		}
+		filtered = append(filtered, row)
+	}
+	return filtered
+}
+
+// groupSales aggregates rows by the key returned for each row
+func groupSales(rows []SalesReportRow, key func(row SalesReportRow) string) map[string]SalesTotals {
+	groups := make(map[string]SalesTotals)
+	for _, row := range rows {
+		k := key(row)
+		totals := groups[k]
+		totals.add(row)
+		groups[k] = totals
+	}
+	return groups
+}
+
+// PreOrderTotals holds aggregated pre-order counts
+type PreOrderTotals struct {
+	Ordered  int `json:"ordered"`
+	Canceled int `json:"canceled"`
+}
+
+// Net returns the number of pre-orders that were not canceled
+func (t PreOrderTotals) Net() int {
+	return t.Ordered - t.Canceled
+}
+
+// PreOrdersByCountry aggregates pre-order rows per country code
+func PreOrdersByCountry(rows []PreOrderReportRow) map[string]PreOrderTotals {
+	totals := make(map[string]PreOrderTotals)
+	for _, row := range rows {
+		t := totals[row.CountryCode]
+		t.Ordered += row.Ordered
+		t.Canceled += row.Canceled
+		totals[row.CountryCode] = t
+	}
+	return totals
+}
@@ -0,0 +1,50 @@
+package appstore
+
+// sandboxAPIVersion is the API version serving sandbox tester resources
+const sandboxAPIVersion = "v2"
+
+// SandboxTestersAPI handles sandbox tester-related operations
+type SandboxTestersAPI struct {
+	client *Client
+}
+
+// NewSandboxTestersAPI creates a new SandboxTesters API client
+func NewSandboxTestersAPI(client *Client) *SandboxTestersAPI {
+	return &SandboxTestersAPI{client: client}
+}
+
+// All retrieves all sandbox testers
+func (s *SandboxTestersAPI) All(params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().WithAPIVersion(sandboxAPIVersion).Get("/sandboxTesters", params)
+}
+
+// ClearPurchaseHistory clears the in-app purchase history of the given sandbox testers
+func (s *SandboxTestersAPI) ClearPurchaseHistory(testerIds []string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	testersData := make([]map[string]string, len(testerIds))
+	for i, id := range testerIds {
+		testersData[i] = map[string]string{
+			"type": "sandboxTesters",
+			"id":   id,
+		}
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "sandboxTestersClearPurchaseHistoryRequest",
+			"relationships": map[string]interface{}{
+				"sandboxTesters": map[string]interface{}{
+					"data": testersData,
+				},
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().WithAPIVersion(sandboxAPIVersion).PostJSON("/sandboxTestersClearPurchaseHistoryRequest", data)
+}
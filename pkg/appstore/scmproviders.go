@@ -0,0 +1,36 @@
+package appstore
+
+// ScmProvidersAPI handles source control provider operations, such as a
+// connected GitHub or Bitbucket account
+type ScmProvidersAPI struct {
+	client *Client
+}
+
+// NewScmProvidersAPI creates a new ScmProviders API client
+func NewScmProvidersAPI(client *Client) *ScmProvidersAPI {
+	return &ScmProvidersAPI{client: client}
+}
+
+// All retrieves all source control providers
+func (s *ScmProvidersAPI) All(params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/scmProviders", params)
+}
+
+// Get retrieves a source control provider by ID
+func (s *ScmProvidersAPI) Get(providerId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/scmProviders/"+providerId, params)
+}
+
+// Repositories retrieves the repositories of a source control provider
+func (s *ScmProvidersAPI) Repositories(providerId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/scmProviders/"+providerId+"/repositories", params)
+}
@@ -0,0 +1,188 @@
+package appstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScmGitReferenceKind represents whether a git reference is a branch or a tag
+type ScmGitReferenceKind string
+
+// Git reference kinds
+const (
+	ScmGitReferenceKindBranch ScmGitReferenceKind = "BRANCH"
+	ScmGitReferenceKindTag    ScmGitReferenceKind = "TAG"
+)
+
+// ScmRepository represents a repository connected to Xcode Cloud
+type ScmRepository struct {
+	ID               string `json:"-"`
+	OwnerName        string `json:"ownerName"`
+	RepositoryName   string `json:"repositoryName"`
+	HTTPCloneURL     string `json:"httpCloneUrl"`
+	SSHCloneURL      string `json:"sshCloneUrl"`
+	LastAccessedDate string `json:"lastAccessedDate"`
+}
+
+// ScmGitReference represents a branch or tag of a repository
+type ScmGitReference struct {
+	ID            string              `json:"-"`
+	Name          string              `json:"name"`
+	CanonicalName string              `json:"canonicalName"`
+	Kind          ScmGitReferenceKind `json:"kind"`
+	IsDeleted     bool                `json:"isDeleted"`
+}
+
+// ScmPullRequest represents a pull request of a repository
+type ScmPullRequest struct {
+	ID                         string `json:"-"`
+	Title                      string `json:"title"`
+	Number                     int    `json:"number"`
+	WebURL                     string `json:"webUrl"`
+	SourceRepositoryOwner      string `json:"sourceRepositoryOwner"`
+	SourceRepositoryName       string `json:"sourceRepositoryName"`
+	SourceBranchName           string `json:"sourceBranchName"`
+	DestinationRepositoryOwner string `json:"destinationRepositoryOwner"`
+	DestinationRepositoryName  string `json:"destinationRepositoryName"`
+	DestinationBranchName      string `json:"destinationBranchName"`
+	IsClosed                   bool   `json:"isClosed"`
+	IsCrossRepository          bool   `json:"isCrossRepository"`
+}
+
+// ScmRepositoriesAPI handles repository, git reference, and pull request
+// operations, resolving branches and pull requests to the IDs Xcode Cloud expects
+type ScmRepositoriesAPI struct {
+	client *Client
+}
+
+// NewScmRepositoriesAPI creates a new ScmRepositories API client
+func NewScmRepositoriesAPI(client *Client) *ScmRepositoriesAPI {
+	return &ScmRepositoriesAPI{client: client}
+}
+
+// All retrieves all repositories
+func (s *ScmRepositoriesAPI) All(params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/scmRepositories", params)
+}
+
+// Get retrieves a repository by ID
+func (s *ScmRepositoriesAPI) Get(repositoryId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/scmRepositories/"+repositoryId, params)
+}
+
+// GitReferences retrieves the branches and tags of a repository
+func (s *ScmRepositoriesAPI) GitReferences(repositoryId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/scmRepositories/"+repositoryId+"/gitReferences", params)
+}
+
+// GitReference retrieves a git reference by ID
+func (s *ScmRepositoriesAPI) GitReference(gitReferenceId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/scmGitReferences/"+gitReferenceId, params)
+}
+
+// PullRequests retrieves the pull requests of a repository
+func (s *ScmRepositoriesAPI) PullRequests(repositoryId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/scmRepositories/"+repositoryId+"/pullRequests", params)
+}
+
+// PullRequest retrieves a pull request by ID
+func (s *ScmRepositoriesAPI) PullRequest(pullRequestId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/scmPullRequests/"+pullRequestId, params)
+}
+
+// FindRepository returns the repository with the given owner and name, compared case-insensitively
+func (s *ScmRepositoriesAPI) FindRepository(ownerName, repositoryName string) (ScmRepository, error) {
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := s.All(query)
+		if err != nil {
+			return ScmRepository{}, err
+		}
+		for _, resource := range resourceList(response) {
+			var repository ScmRepository
+			if err := decodeAttributes(resource, &repository); err != nil {
+				return ScmRepository{}, err
+			}
+			if strings.EqualFold(repository.OwnerName, ownerName) && strings.EqualFold(repository.RepositoryName, repositoryName) {
+				repository.ID = resourceID(resource)
+				return repository, nil
+			}
+		}
+		query = nextPageParams(response)
+	}
+	return ScmRepository{}, fmt.Errorf("repository %s/%s not found", ownerName, repositoryName)
+}
+
+// FindBranch returns the branch of a repository with the given name, e.g. "main"
+func (s *ScmRepositoriesAPI) FindBranch(repositoryId, name string) (ScmGitReference, error) {
+	return s.findGitReference(repositoryId, ScmGitReferenceKindBranch, name)
+}
+
+// FindTag returns the tag of a repository with the given name, e.g. "v1.2.0"
+func (s *ScmRepositoriesAPI) FindTag(repositoryId, name string) (ScmGitReference, error) {
+	return s.findGitReference(repositoryId, ScmGitReferenceKindTag, name)
+}
+
+// findGitReference returns the non-deleted git reference of a kind with the given name
+func (s *ScmRepositoriesAPI) findGitReference(repositoryId string, kind ScmGitReferenceKind, name string) (ScmGitReference, error) {
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := s.GitReferences(repositoryId, query)
+		if err != nil {
+			return ScmGitReference{}, err
+		}
+		for _, resource := range resourceList(response) {
+			var reference ScmGitReference
+			if err := decodeAttributes(resource, &reference); err != nil {
+				return ScmGitReference{}, err
+			}
+			if reference.Kind == kind && reference.Name == name && !reference.IsDeleted {
+				reference.ID = resourceID(resource)
+				return reference, nil
+			}
+		}
+		query = nextPageParams(response)
+	}
+	return ScmGitReference{}, fmt.Errorf("%s %s not found", strings.ToLower(string(kind)), name)
+}
+
+// FindPullRequest returns the pull request of a repository with the given number
+func (s *ScmRepositoriesAPI) FindPullRequest(repositoryId string, number int) (ScmPullRequest, error) {
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := s.PullRequests(repositoryId, query)
+		if err != nil {
+			return ScmPullRequest{}, err
+		}
+		for _, resource := range resourceList(response) {
+			var pullRequest ScmPullRequest
+			if err := decodeAttributes(resource, &pullRequest); err != nil {
+				return ScmPullRequest{}, err
+			}
+			if pullRequest.Number == number {
+				pullRequest.ID = resourceID(resource)
+				return pullRequest, nil
+			}
+		}
+		query = nextPageParams(response)
+	}
+	return ScmPullRequest{}, fmt.Errorf("pull request #%d not found", number)
+}
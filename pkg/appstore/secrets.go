@@ -0,0 +1,147 @@
+package appstore
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// SecretResolver resolves a secret reference to the content of a private
+// key, for keys kept in a secret manager rather than on disk
+type SecretResolver interface {
+	// ResolveSecret returns the secret named by ref, the part of a reference
+	// after its scheme, such as "asc/api-key" for "aws-sm://asc/api-key"
+	ResolveSecret(ref string) (string, error)
+}
+
+// SecretResolverFunc adapts a function to a SecretResolver
+type SecretResolverFunc func(ref string) (string, error)
+
+// ResolveSecret calls f(ref)
+func (f SecretResolverFunc) ResolveSecret(ref string) (string, error) {
+	return f(ref)
+}
+
+// Schemes of the default secret resolvers
+const (
+	// SchemeKeychain names a generic password of the macOS keychain, as
+	// "keychain://service" or "keychain://service/account"
+	SchemeKeychain = "keychain"
+	// SchemeAWSSecretsManager names a secret of AWS Secrets Manager by name
+	// or ARN, as "aws-sm://asc/api-key"
+	SchemeAWSSecretsManager = "aws-sm"
+	// SchemeGCPSecretManager names a secret of GCP Secret Manager, as
+	// "gcp-sm://asc-api-key" for its latest version or by resource name, as
+	// "gcp-sm://projects/my-project/secrets/asc-api-key/versions/3"
+	SchemeGCPSecretManager = "gcp-sm"
+)
+
+// DefaultSecretResolvers returns the built-in resolvers by scheme. They run
+// the security, aws, and gcloud command line tools, so they use the
+// credentials those tools are configured with and add no SDK dependencies.
+// Resolvers backed by an SDK can replace them through Config.SecretResolvers.
+func DefaultSecretResolvers() map[string]SecretResolver {
+	return map[string]SecretResolver{
+		SchemeKeychain:          SecretResolverFunc(resolveKeychain),
+		SchemeAWSSecretsManager: SecretResolverFunc(resolveAWSSecret),
+		SchemeGCPSecretManager:  SecretResolverFunc(resolveGCPSecret),
+	}
+}
+
+// runCommand runs a command and returns its standard output
+var runCommand = func(name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%s: %w: %s", name, err, message)
+		}
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return output, nil
+}
+
+// resolveKeychain reads a generic password of the macOS keychain
+func resolveKeychain(ref string) (string, error) {
+	service, account, _ := strings.Cut(ref, "/")
+	if service == "" {
+		return "", fmt.Errorf("keychain service is required")
+	}
+	args := []string{"find-generic-password", "-s", service, "-w"}
+	if account != "" {
+		args = append(args, "-a", account)
+	}
+	output, err := runCommand("security", args...)
+	if err != nil {
+		return "", err
+	}
+	return decodeKeyContent(strings.TrimSpace(string(output)))
+}
+
+// resolveAWSSecret reads the string value of an AWS Secrets Manager secret
+func resolveAWSSecret(ref string) (string, error) {
+	if ref == "" {
+		return "", fmt.Errorf("secret id is required")
+	}
+	output, err := runCommand("aws", "secretsmanager", "get-secret-value",
+		"--secret-id", ref, "--query", "SecretString", "--output", "text")
+	if err != nil {
+		return "", err
+	}
+	return decodeKeyContent(strings.TrimSpace(string(output)))
+}
+
+// resolveGCPSecret reads a version of a GCP Secret Manager secret
+func resolveGCPSecret(ref string) (string, error) {
+	if ref == "" {
+		return "", fmt.Errorf("secret name is required")
+	}
+	args := []string{"secrets", "versions", "access"}
+	if strings.HasPrefix(ref, "projects/") {
+		args = append(args, ref)
+	} else {
+		args = append(args, "latest", "--secret="+ref)
+	}
+	output, err := runCommand("gcloud", args...)
+	if err != nil {
+		return "", err
+	}
+	return decodeKeyContent(strings.TrimSpace(string(output)))
+}
+
+// resolveSecret returns the content of a secret: the value a resolver
+// returns for a "scheme://ref" reference, the content of a file path, or
+// secret itself. resolvers override the default resolvers of their schemes.
+func resolveSecret(secret string, resolvers map[string]SecretResolver) (string, error) {
+	if scheme, ref, ok := strings.Cut(secret, "://"); ok && !strings.ContainsAny(scheme, "/\n") {
+		resolver, ok := resolvers[scheme]
+		if !ok {
+			resolver, ok = DefaultSecretResolvers()[scheme]
+		}
+		if !ok {
+			return "", fmt.Errorf("no secret resolver for scheme %q", scheme)
+		}
+		content, err := resolver.ResolveSecret(ref)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve secret %s: %w", secret, err)
+		}
+		return content, nil
+	}
+	return readSecret(secret)
+}
+
+// readSecret returns the content of secret if it is a file path, or secret itself
+func readSecret(secret string) (string, error) {
+	if _, err := os.Stat(secret); err == nil {
+		content, err := os.ReadFile(secret)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return string(content), nil
+	}
+	return secret, nil
+}
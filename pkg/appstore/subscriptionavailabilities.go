@@ -0,0 +1,90 @@
+package appstore
+
+import (
+	"fmt"
+
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// SubscriptionAvailabilitiesAPI handles subscription availability operations
+type SubscriptionAvailabilitiesAPI struct {
+	client *Client
+}
+
+// NewSubscriptionAvailabilitiesAPI creates a new SubscriptionAvailabilities API client
+func NewSubscriptionAvailabilitiesAPI(client *Client) *SubscriptionAvailabilitiesAPI {
+	return &SubscriptionAvailabilitiesAPI{client: client}
+}
+
+// Get retrieves the availability of a subscription with all of its
+// territories. A subscription whose availability was never configured
+// returns an empty ProductAvailability.
+func (s *SubscriptionAvailabilitiesAPI) Get(subscriptionId string) (ProductAvailability, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return ProductAvailability{}, err
+	}
+
+	response, err := s.client.GetHTTPClient().Get("/subscriptions/"+subscriptionId+"/subscriptionAvailability", nil)
+	if err != nil {
+		if httpclient.IsNotFound(err) {
+			return ProductAvailability{}, nil
+		}
+		return ProductAvailability{}, err
+	}
+	availability, ok := parseProductAvailability(response)
+	if !ok {
+		return ProductAvailability{}, nil
+	}
+
+	availability.Territories, err = s.client.availableTerritories("/subscriptionAvailabilities/" + availability.ID + "/availableTerritories")
+	return availability, err
+}
+
+// Set replaces the territories a subscription is available in
+func (s *SubscriptionAvailabilitiesAPI) Set(subscriptionId string, territories []string, availableInNewTerritories bool) (map[string]interface{}, error) {
+	if len(territories) == 0 {
+		return nil, fmt.Errorf("at least one territory is required")
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionAvailabilities",
+			"attributes": map[string]bool{
+				"availableInNewTerritories": availableInNewTerritories,
+			},
+			"relationships": map[string]interface{}{
+				"subscription": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptions",
+						"id":   subscriptionId,
+					},
+				},
+				"availableTerritories": territoryLinkages(territories),
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PostJSON("/subscriptionAvailabilities", data)
+}
+
+// Expand makes a subscription available in additional territories, keeping
+// its current territories and new territory setting
+func (s *SubscriptionAvailabilitiesAPI) Expand(subscriptionId string, territories []string) (map[string]interface{}, error) {
+	current, err := s.Get(subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	return s.Set(subscriptionId, mergeTerritories(current.Territories, territories, nil), current.AvailableInNewTerritories)
+}
+
+// Restrict removes territories from the availability of a subscription
+func (s *SubscriptionAvailabilitiesAPI) Restrict(subscriptionId string, territories []string) (map[string]interface{}, error) {
+	current, err := s.Get(subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	return s.Set(subscriptionId, mergeTerritories(current.Territories, nil, territories), current.AvailableInNewTerritories)
+}
@@ -0,0 +1,194 @@
+package appstore
+
+import "fmt"
+
+// SubscriptionGroup represents a subscription group of an app
+type SubscriptionGroup struct {
+	ID            string `json:"-"`
+	ReferenceName string `json:"referenceName"`
+}
+
+// SubscriptionGroupsAPI handles subscription group and group localization operations
+type SubscriptionGroupsAPI struct {
+	client *Client
+}
+
+// NewSubscriptionGroupsAPI creates a new SubscriptionGroups API client
+func NewSubscriptionGroupsAPI(client *Client) *SubscriptionGroupsAPI {
+	return &SubscriptionGroupsAPI{client: client}
+}
+
+// All retrieves the subscription groups of an app
+func (s *SubscriptionGroupsAPI) All(appId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/apps/"+appId+"/subscriptionGroups", params)
+}
+
+// List retrieves every subscription group of an app, following pagination
+func (s *SubscriptionGroupsAPI) List(appId string) ([]SubscriptionGroup, error) {
+	var groups []SubscriptionGroup
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := s.All(appId, query)
+		if err != nil {
+			return groups, err
+		}
+		for _, resource := range resourceList(response) {
+			var group SubscriptionGroup
+			if err := decodeAttributes(resource, &group); err != nil {
+				return groups, err
+			}
+			group.ID = resourceID(resource)
+			groups = append(groups, group)
+		}
+		query = nextPageParams(response)
+	}
+	return groups, nil
+}
+
+// Get retrieves a subscription group by ID
+func (s *SubscriptionGroupsAPI) Get(groupId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptionGroups/"+groupId, params)
+}
+
+// Create creates a new subscription group for an app
+func (s *SubscriptionGroupsAPI) Create(appId, referenceName string) (map[string]interface{}, error) {
+	if referenceName == "" {
+		return nil, fmt.Errorf("reference name is required")
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionGroups",
+			"attributes": map[string]string{
+				"referenceName": referenceName,
+			},
+			"relationships": map[string]interface{}{
+				"app": map[string]interface{}{
+					"data": map[string]string{
+						"type": "apps",
+						"id":   appId,
+					},
+				},
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PostJSON("/subscriptionGroups", data)
+}
+
+// Update renames a subscription group
+func (s *SubscriptionGroupsAPI) Update(groupId, referenceName string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionGroups",
+			"id":   groupId,
+			"attributes": map[string]string{
+				"referenceName": referenceName,
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PatchJSON("/subscriptionGroups/"+groupId, data)
+}
+
+// Delete deletes a subscription group by ID
+func (s *SubscriptionGroupsAPI) Delete(groupId string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Delete("/subscriptionGroups/"+groupId, nil)
+}
+
+// Localizations retrieves the localizations of a subscription group
+func (s *SubscriptionGroupsAPI) Localizations(groupId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptionGroups/"+groupId+"/subscriptionGroupLocalizations", params)
+}
+
+// CreateLocalization adds a localized display name to a subscription group.
+// customAppName is optional and overrides the app name shown with the group.
+func (s *SubscriptionGroupsAPI) CreateLocalization(groupId, locale, name, customAppName string) (map[string]interface{}, error) {
+	if locale == "" {
+		return nil, fmt.Errorf("locale is required")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]string{
+		"locale": locale,
+		"name":   name,
+	}
+	if customAppName != "" {
+		attributes["customAppName"] = customAppName
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "subscriptionGroupLocalizations",
+			"attributes": attributes,
+			"relationships": map[string]interface{}{
+				"subscriptionGroup": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptionGroups",
+						"id":   groupId,
+					},
+				},
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PostJSON("/subscriptionGroupLocalizations", data)
+}
+
+// UpdateLocalization changes the display name or custom app name of a
+// subscription group localization. Empty values are left unchanged.
+func (s *SubscriptionGroupsAPI) UpdateLocalization(localizationId, name, customAppName string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]string{}
+	if name != "" {
+		attributes["name"] = name
+	}
+	if customAppName != "" {
+		attributes["customAppName"] = customAppName
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "subscriptionGroupLocalizations",
+			"id":         localizationId,
+			"attributes": attributes,
+		},
+	}
+
+	return s.client.GetHTTPClient().PatchJSON("/subscriptionGroupLocalizations/"+localizationId, data)
+}
+
+// DeleteLocalization deletes a subscription group localization by ID
+func (s *SubscriptionGroupsAPI) DeleteLocalization(localizationId string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Delete("/subscriptionGroupLocalizations/"+localizationId, nil)
+}
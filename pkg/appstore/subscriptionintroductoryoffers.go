@@ -0,0 +1,152 @@
+package appstore
+
+import "fmt"
+
+// SubscriptionOfferMode represents how a customer pays during a subscription offer
+type SubscriptionOfferMode string
+
+// Subscription offer modes
+const (
+	SubscriptionOfferModeFreeTrial  SubscriptionOfferMode = "FREE_TRIAL"
+	SubscriptionOfferModePayAsYouGo SubscriptionOfferMode = "PAY_AS_YOU_GO"
+	SubscriptionOfferModePayUpFront SubscriptionOfferMode = "PAY_UP_FRONT"
+)
+
+// SubscriptionOfferDuration represents the length of a subscription offer period
+type SubscriptionOfferDuration string
+
+// Subscription offer durations
+const (
+	SubscriptionOfferDurationThreeDays   SubscriptionOfferDuration = "THREE_DAYS"
+	SubscriptionOfferDurationOneWeek     SubscriptionOfferDuration = "ONE_WEEK"
+	SubscriptionOfferDurationTwoWeeks    SubscriptionOfferDuration = "TWO_WEEKS"
+	SubscriptionOfferDurationOneMonth    SubscriptionOfferDuration = "ONE_MONTH"
+	SubscriptionOfferDurationTwoMonths   SubscriptionOfferDuration = "TWO_MONTHS"
+	SubscriptionOfferDurationThreeMonths SubscriptionOfferDuration = "THREE_MONTHS"
+	SubscriptionOfferDurationSixMonths   SubscriptionOfferDuration = "SIX_MONTHS"
+	SubscriptionOfferDurationOneYear     SubscriptionOfferDuration = "ONE_YEAR"
+)
+
+// IntroductoryOffer describes an introductory offer for new subscribers in a territory
+type IntroductoryOffer struct {
+	Territory       string
+	OfferMode       SubscriptionOfferMode
+	Duration        SubscriptionOfferDuration
+	NumberOfPeriods int
+	// PricePointID is required for pay as you go and pay up front offers
+	PricePointID string
+	// StartDate and EndDate are optional and formatted as YYYY-MM-DD
+	StartDate string
+	EndDate   string
+}
+
+// SubscriptionIntroductoryOffersAPI handles subscription introductory offer operations
+type SubscriptionIntroductoryOffersAPI struct {
+	client *Client
+}
+
+// NewSubscriptionIntroductoryOffersAPI creates a new SubscriptionIntroductoryOffers API client
+func NewSubscriptionIntroductoryOffersAPI(client *Client) *SubscriptionIntroductoryOffersAPI {
+	return &SubscriptionIntroductoryOffersAPI{client: client}
+}
+
+// All retrieves the introductory offers of a subscription
+func (s *SubscriptionIntroductoryOffersAPI) All(subscriptionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptions/"+subscriptionId+"/introductoryOffers", params)
+}
+
+// Create creates an introductory offer for a subscription
+func (s *SubscriptionIntroductoryOffersAPI) Create(subscriptionId string, offer IntroductoryOffer) (map[string]interface{}, error) {
+	if offer.OfferMode == "" {
+		return nil, fmt.Errorf("offer mode is required")
+	}
+	if offer.Duration == "" {
+		return nil, fmt.Errorf("duration is required")
+	}
+	if offer.OfferMode != SubscriptionOfferModeFreeTrial && offer.PricePointID == "" {
+		return nil, fmt.Errorf("price point id is required for %s offers", offer.OfferMode)
+	}
+	if offer.NumberOfPeriods <= 0 {
+		offer.NumberOfPeriods = 1
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]interface{}{
+		"offerMode":       offer.OfferMode,
+		"duration":        offer.Duration,
+		"numberOfPeriods": offer.NumberOfPeriods,
+	}
+	if offer.StartDate != "" {
+		attributes["startDate"] = offer.StartDate
+	}
+	if offer.EndDate != "" {
+		attributes["endDate"] = offer.EndDate
+	}
+
+	relationships := map[string]interface{}{
+		"subscription": map[string]interface{}{
+			"data": map[string]string{
+				"type": "subscriptions",
+				"id":   subscriptionId,
+			},
+		},
+	}
+	if offer.Territory != "" {
+		relationships["territory"] = map[string]interface{}{
+			"data": map[string]string{
+				"type": "territories",
+				"id":   offer.Territory,
+			},
+		}
+	}
+	if offer.PricePointID != "" {
+		relationships["subscriptionPricePoint"] = map[string]interface{}{
+			"data": map[string]string{
+				"type": "subscriptionPricePoints",
+				"id":   offer.PricePointID,
+			},
+		}
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":          "subscriptionIntroductoryOffers",
+			"attributes":    attributes,
+			"relationships": relationships,
+		},
+	}
+
+	return s.client.GetHTTPClient().PostJSON("/subscriptionIntroductoryOffers", data)
+}
+
+// UpdateEndDate changes the end date of an introductory offer, formatted as YYYY-MM-DD
+func (s *SubscriptionIntroductoryOffersAPI) UpdateEndDate(offerId, endDate string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionIntroductoryOffers",
+			"id":   offerId,
+			"attributes": map[string]string{
+				"endDate": endDate,
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PatchJSON("/subscriptionIntroductoryOffers/"+offerId, data)
+}
+
+// Delete deletes an introductory offer by ID
+func (s *SubscriptionIntroductoryOffersAPI) Delete(offerId string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Delete("/subscriptionIntroductoryOffers/"+offerId, nil)
+}
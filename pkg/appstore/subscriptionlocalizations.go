@@ -0,0 +1,170 @@
+package appstore
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SubscriptionLocalization represents the localized display name and description of a subscription
+type SubscriptionLocalization struct {
+	ID          string `json:"-"`
+	Locale      string `json:"locale"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+}
+
+// LocalizedText holds a localized display name and description
+type LocalizedText struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SubscriptionLocalizationsAPI handles subscription localization operations
+type SubscriptionLocalizationsAPI struct {
+	client *Client
+}
+
+// NewSubscriptionLocalizationsAPI creates a new SubscriptionLocalizations API client
+func NewSubscriptionLocalizationsAPI(client *Client) *SubscriptionLocalizationsAPI {
+	return &SubscriptionLocalizationsAPI{client: client}
+}
+
+// All retrieves the localizations of a subscription
+func (s *SubscriptionLocalizationsAPI) All(subscriptionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptions/"+subscriptionId+"/subscriptionLocalizations", params)
+}
+
+// List retrieves every localization of a subscription
+func (s *SubscriptionLocalizationsAPI) List(subscriptionId string) ([]SubscriptionLocalization, error) {
+	var localizations []SubscriptionLocalization
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := s.All(subscriptionId, query)
+		if err != nil {
+			return localizations, err
+		}
+		for _, resource := range resourceList(response) {
+			var localization SubscriptionLocalization
+			if err := decodeAttributes(resource, &localization); err != nil {
+				return localizations, err
+			}
+			localization.ID = resourceID(resource)
+			localizations = append(localizations, localization)
+		}
+		query = nextPageParams(response)
+	}
+	return localizations, nil
+}
+
+// Create adds a localized display name and description to a subscription
+func (s *SubscriptionLocalizationsAPI) Create(subscriptionId, locale, name, description string) (map[string]interface{}, error) {
+	if locale == "" {
+		return nil, fmt.Errorf("locale is required")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionLocalizations",
+			"attributes": map[string]string{
+				"locale":      locale,
+				"name":        name,
+				"description": description,
+			},
+			"relationships": map[string]interface{}{
+				"subscription": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptions",
+						"id":   subscriptionId,
+					},
+				},
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PostJSON("/subscriptionLocalizations", data)
+}
+
+// Update changes the display name or description of a subscription localization.
+// Empty values are left unchanged.
+func (s *SubscriptionLocalizationsAPI) Update(localizationId, name, description string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]string{}
+	if name != "" {
+		attributes["name"] = name
+	}
+	if description != "" {
+		attributes["description"] = description
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "subscriptionLocalizations",
+			"id":         localizationId,
+			"attributes": attributes,
+		},
+	}
+
+	return s.client.GetHTTPClient().PatchJSON("/subscriptionLocalizations/"+localizationId, data)
+}
+
+// Delete deletes a subscription localization by ID
+func (s *SubscriptionLocalizationsAPI) Delete(localizationId string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Delete("/subscriptionLocalizations/"+localizationId, nil)
+}
+
+// Sync creates or updates the localizations of a subscription to match the
+// desired texts keyed by locale, and returns the locales that were changed.
+// Locales that are not in desired are left untouched.
+func (s *SubscriptionLocalizationsAPI) Sync(subscriptionId string, desired map[string]LocalizedText) ([]string, error) {
+	existing, err := s.List(subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	byLocale := make(map[string]SubscriptionLocalization, len(existing))
+	for _, localization := range existing {
+		byLocale[localization.Locale] = localization
+	}
+
+	locales := make([]string, 0, len(desired))
+	for locale := range desired {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	var changed []string
+	for _, locale := range locales {
+		text := desired[locale]
+		current, ok := byLocale[locale]
+		switch {
+		case !ok:
+			if _, err := s.Create(subscriptionId, locale, text.Name, text.Description); err != nil {
+				return changed, fmt.Errorf("failed to create %s localization: %w", locale, err)
+			}
+		case current.Name != text.Name || current.Description != text.Description:
+			if _, err := s.Update(current.ID, text.Name, text.Description); err != nil {
+				return changed, fmt.Errorf("failed to update %s localization: %w", locale, err)
+			}
+		default:
+			continue
+		}
+		changed = append(changed, locale)
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,269 @@
+package appstore
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+)
+
+// OfferCodeCustomerEligibility represents which customers can redeem an offer code
+type OfferCodeCustomerEligibility string
+
+// Offer code customer eligibilities
+const (
+	OfferCodeEligibilityNew      OfferCodeCustomerEligibility = "NEW"
+	OfferCodeEligibilityExisting OfferCodeCustomerEligibility = "EXISTING"
+	OfferCodeEligibilityExpired  OfferCodeCustomerEligibility = "EXPIRED"
+)
+
+// OfferCodeOfferEligibility represents how an offer code combines with introductory offers
+type OfferCodeOfferEligibility string
+
+// Offer code offer eligibilities
+const (
+	OfferCodeStackWithIntroOffers OfferCodeOfferEligibility = "STACK_WITH_INTRO_OFFERS"
+	OfferCodeReplaceIntroOffers   OfferCodeOfferEligibility = "REPLACE_INTRO_OFFERS"
+)
+
+// OfferCode describes a subscription offer code campaign
+type OfferCode struct {
+	Name                  string
+	CustomerEligibilities []OfferCodeCustomerEligibility
+	OfferEligibility      OfferCodeOfferEligibility
+	OfferMode             SubscriptionOfferMode
+	Duration              SubscriptionOfferDuration
+	NumberOfPeriods       int
+	Prices                []SubscriptionOfferPrice
+}
+
+// SubscriptionOfferCodesAPI handles subscription offer code operations
+type SubscriptionOfferCodesAPI struct {
+	client *Client
+}
+
+// NewSubscriptionOfferCodesAPI creates a new SubscriptionOfferCodes API client
+func NewSubscriptionOfferCodesAPI(client *Client) *SubscriptionOfferCodesAPI {
+	return &SubscriptionOfferCodesAPI{client: client}
+}
+
+// All retrieves the offer codes of a subscription
+func (s *SubscriptionOfferCodesAPI) All(subscriptionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptions/"+subscriptionId+"/offerCodes", params)
+}
+
+// Create creates an offer code campaign for a subscription
+func (s *SubscriptionOfferCodesAPI) Create(subscriptionId string, offer OfferCode) (map[string]interface{}, error) {
+	if offer.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if len(offer.CustomerEligibilities) == 0 {
+		return nil, fmt.Errorf("at least one customer eligibility is required")
+	}
+	if offer.OfferEligibility == "" {
+		return nil, fmt.Errorf("offer eligibility is required")
+	}
+	if offer.OfferMode == "" {
+		return nil, fmt.Errorf("offer mode is required")
+	}
+	if offer.Duration == "" {
+		return nil, fmt.Errorf("duration is required")
+	}
+	if len(offer.Prices) == 0 {
+		return nil, fmt.Errorf("at least one price is required")
+	}
+	if offer.NumberOfPeriods <= 0 {
+		offer.NumberOfPeriods = 1
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	pricesData, included := subscriptionOfferPrices("subscriptionOfferCodePrices", offer.Prices)
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionOfferCodes",
+			"attributes": map[string]interface{}{
+				"name":                  offer.Name,
+				"customerEligibilities": offer.CustomerEligibilities,
+				"offerEligibility":      offer.OfferEligibility,
+				"offerMode":             offer.OfferMode,
+				"duration":              offer.Duration,
+				"numberOfPeriods":       offer.NumberOfPeriods,
+			},
+			"relationships": map[string]interface{}{
+				"subscription": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptions",
+						"id":   subscriptionId,
+					},
+				},
+				"prices": map[string]interface{}{
+					"data": pricesData,
+				},
+			},
+		},
+		"included": included,
+	}
+
+	return s.client.GetHTTPClient().PostJSON("/subscriptionOfferCodes", data)
+}
+
+// SetActive activates or deactivates an offer code campaign
+func (s *SubscriptionOfferCodesAPI) SetActive(offerCodeId string, active bool) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionOfferCodes",
+			"id":   offerCodeId,
+			"attributes": map[string]bool{
+				"active": active,
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PatchJSON("/subscriptionOfferCodes/"+offerCodeId, data)
+}
+
+// CustomCodes retrieves the custom codes of an offer code campaign
+func (s *SubscriptionOfferCodesAPI) CustomCodes(offerCodeId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptionOfferCodes/"+offerCodeId+"/customCodes", params)
+}
+
+// CreateCustomCode creates a custom code, such as "SPRING2025", redeemable
+// numberOfCodes times until the expiration date formatted as YYYY-MM-DD
+func (s *SubscriptionOfferCodesAPI) CreateCustomCode(offerCodeId, customCode string, numberOfCodes int, expirationDate string) (map[string]interface{}, error) {
+	if customCode == "" {
+		return nil, fmt.Errorf("custom code is required")
+	}
+	if numberOfCodes <= 0 {
+		return nil, fmt.Errorf("number of codes must be positive")
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]interface{}{
+		"customCode":    customCode,
+		"numberOfCodes": numberOfCodes,
+	}
+	if expirationDate != "" {
+		attributes["expirationDate"] = expirationDate
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "subscriptionOfferCodeCustomCodes",
+			"attributes": attributes,
+			"relationships": map[string]interface{}{
+				"offerCode": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptionOfferCodes",
+						"id":   offerCodeId,
+					},
+				},
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PostJSON("/subscriptionOfferCodeCustomCodes", data)
+}
+
+// SetCustomCodeActive activates or deactivates a custom code
+func (s *SubscriptionOfferCodesAPI) SetCustomCodeActive(customCodeId string, active bool) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionOfferCodeCustomCodes",
+			"id":   customCodeId,
+			"attributes": map[string]bool{
+				"active": active,
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PatchJSON("/subscriptionOfferCodeCustomCodes/"+customCodeId, data)
+}
+
+// OneTimeUseCodes retrieves the one-time use code batches of an offer code campaign
+func (s *SubscriptionOfferCodesAPI) OneTimeUseCodes(offerCodeId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptionOfferCodes/"+offerCodeId+"/oneTimeUseCodes", params)
+}
+
+// CreateOneTimeUseCodes requests a batch of one-time use codes expiring on
+// the expiration date formatted as YYYY-MM-DD. Codes are generated
+// asynchronously and can be downloaded with DownloadOneTimeUseCodes.
+func (s *SubscriptionOfferCodesAPI) CreateOneTimeUseCodes(offerCodeId string, numberOfCodes int, expirationDate string) (map[string]interface{}, error) {
+	if numberOfCodes <= 0 {
+		return nil, fmt.Errorf("number of codes must be positive")
+	}
+	if expirationDate == "" {
+		return nil, fmt.Errorf("expiration date is required")
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionOfferCodeOneTimeUseCodes",
+			"attributes": map[string]interface{}{
+				"numberOfCodes":  numberOfCodes,
+				"expirationDate": expirationDate,
+			},
+			"relationships": map[string]interface{}{
+				"offerCode": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptionOfferCodes",
+						"id":   offerCodeId,
+					},
+				},
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PostJSON("/subscriptionOfferCodeOneTimeUseCodes", data)
+}
+
+// DownloadOneTimeUseCodes downloads the generated codes of a batch as CSV
+func (s *SubscriptionOfferCodesAPI) DownloadOneTimeUseCodes(batchId string) ([]byte, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().GetRaw("/subscriptionOfferCodeOneTimeUseCodes/"+batchId+"/values", nil, "text/csv")
+}
+
+// OneTimeUseCodeValues downloads the generated codes of a batch as a list
+func (s *SubscriptionOfferCodesAPI) OneTimeUseCodeValues(batchId string) ([]string, error) {
+	content, err := s.DownloadOneTimeUseCodes(batchId)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse codes: %w", err)
+	}
+
+	var codes []string
+	for _, record := range records {
+		if len(record) > 0 && record[0] != "" {
+			codes = append(codes, record[0])
+		}
+	}
+	return codes, nil
+}
@@ -0,0 +1,181 @@
+package appstore
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SubscriptionPriceChange describes a price to schedule for a subscription in a territory
+type SubscriptionPriceChange struct {
+	Territory    string
+	PricePointID string
+	// StartDate is formatted as YYYY-MM-DD, an empty start date applies the price immediately
+	StartDate string
+	// PreserveCurrentPrice keeps existing subscribers on their current price
+	PreserveCurrentPrice bool
+}
+
+// SubscriptionPricesAPI handles subscription price and price point operations
+type SubscriptionPricesAPI struct {
+	client *Client
+}
+
+// NewSubscriptionPricesAPI creates a new SubscriptionPrices API client
+func NewSubscriptionPricesAPI(client *Client) *SubscriptionPricesAPI {
+	return &SubscriptionPricesAPI{client: client}
+}
+
+// Prices retrieves the current and scheduled prices of a subscription
+func (s *SubscriptionPricesAPI) Prices(subscriptionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptions/"+subscriptionId+"/prices", params)
+}
+
+// CurrentPrices returns the customer price of a subscription in effect in
+// each territory, keyed by territory ID. Prices scheduled to start in the
+// future are ignored.
+func (s *SubscriptionPricesAPI) CurrentPrices(subscriptionId string) (map[string]string, error) {
+	today := time.Now().UTC().Format("2006-01-02")
+	prices := make(map[string]string)
+	startDates := make(map[string]string)
+
+	query := map[string]string{
+		"include": "subscriptionPricePoint,territory",
+		"limit":   "200",
+	}
+	for query != nil {
+		response, err := s.Prices(subscriptionId, query)
+		if err != nil {
+			return nil, err
+		}
+		included := includedResources(response)
+		for _, price := range resourceList(response) {
+			startDate := stringAttribute(price, "startDate")
+			if startDate > today {
+				continue
+			}
+			territory := relationshipID(price, "territory")
+			if current, ok := startDates[territory]; ok && current > startDate {
+				continue
+			}
+			pricePoint := included["subscriptionPricePoints/"+relationshipID(price, "subscriptionPricePoint")]
+			startDates[territory] = startDate
+			prices[territory] = stringAttribute(pricePoint, "customerPrice")
+		}
+		query = nextPageParams(response)
+	}
+
+	return prices, nil
+}
+
+// PricePoints retrieves the price points available to a subscription in a territory
+func (s *SubscriptionPricesAPI) PricePoints(subscriptionId, territory string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	query := map[string]string{"filter[territory]": territory}
+	for k, v := range params {
+		query[k] = v
+	}
+
+	return s.client.GetHTTPClient().Get("/subscriptions/"+subscriptionId+"/pricePoints", query)
+}
+
+// FindPricePoint returns the ID of the price point of a subscription in a
+// territory whose customer price equals customerPrice, e.g. "9.99"
+func (s *SubscriptionPricesAPI) FindPricePoint(subscriptionId, territory, customerPrice string) (string, error) {
+	want, err := strconv.ParseFloat(customerPrice, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid customer price %q", customerPrice)
+	}
+
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := s.PricePoints(subscriptionId, territory, query)
+		if err != nil {
+			return "", err
+		}
+		for _, pricePoint := range resourceList(response) {
+			price, err := strconv.ParseFloat(stringAttribute(pricePoint, "customerPrice"), 64)
+			if err == nil && price == want {
+				return resourceID(pricePoint), nil
+			}
+		}
+		query = nextPageParams(response)
+	}
+
+	return "", fmt.Errorf("no price point of %s in territory %s", customerPrice, territory)
+}
+
+// CreatePrice schedules a price for a subscription in a territory
+func (s *SubscriptionPricesAPI) CreatePrice(subscriptionId string, change SubscriptionPriceChange) (map[string]interface{}, error) {
+	if change.Territory == "" {
+		return nil, fmt.Errorf("territory is required")
+	}
+	if change.PricePointID == "" {
+		return nil, fmt.Errorf("price point id is required")
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]interface{}{
+		"preserveCurrentPrice": change.PreserveCurrentPrice,
+	}
+	if change.StartDate != "" {
+		attributes["startDate"] = change.StartDate
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "subscriptionPrices",
+			"attributes": attributes,
+			"relationships": map[string]interface{}{
+				"subscription": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptions",
+						"id":   subscriptionId,
+					},
+				},
+				"subscriptionPricePoint": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptionPricePoints",
+						"id":   change.PricePointID,
+					},
+				},
+				"territory": map[string]interface{}{
+					"data": map[string]string{
+						"type": "territories",
+						"id":   change.Territory,
+					},
+				},
+			},
+		},
+	}
+
+	return s.client.GetHTTPClient().PostJSON("/subscriptionPrices", data)
+}
+
+// DeletePrice deletes a scheduled subscription price by ID
+func (s *SubscriptionPricesAPI) DeletePrice(priceId string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Delete("/subscriptionPrices/"+priceId, nil)
+}
+
+// SchedulePriceChanges schedules every price change, continuing past
+// failures, and returns the territories whose change failed with their errors
+func (s *SubscriptionPricesAPI) SchedulePriceChanges(subscriptionId string, changes []SubscriptionPriceChange) map[string]error {
+	failures := make(map[string]error)
+	for _, change := range changes {
+		if _, err := s.CreatePrice(subscriptionId, change); err != nil {
+			failures[change.Territory] = err
+		}
+	}
+	return failures
+}
@@ -0,0 +1,177 @@
+package appstore
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SubscriptionOfferPrice is the price point of a subscription offer in a territory
+type SubscriptionOfferPrice struct {
+	Territory    string
+	PricePointID string
+}
+
+// PromotionalOffer describes a promotional offer for existing or lapsed subscribers
+type PromotionalOffer struct {
+	Name string
+	// OfferCode is the identifier the app passes to StoreKit
+	OfferCode       string
+	OfferMode       SubscriptionOfferMode
+	Duration        SubscriptionOfferDuration
+	NumberOfPeriods int
+	Prices          []SubscriptionOfferPrice
+}
+
+// SubscriptionPromotionalOffersAPI handles subscription promotional offer operations
+type SubscriptionPromotionalOffersAPI struct {
+	client *Client
+}
+
+// NewSubscriptionPromotionalOffersAPI creates a new SubscriptionPromotionalOffers API client
+func NewSubscriptionPromotionalOffersAPI(client *Client) *SubscriptionPromotionalOffersAPI {
+	return &SubscriptionPromotionalOffersAPI{client: client}
+}
+
+// All retrieves the promotional offers of a subscription
+func (s *SubscriptionPromotionalOffersAPI) All(subscriptionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptions/"+subscriptionId+"/promotionalOffers", params)
+}
+
+// Get retrieves a promotional offer by ID
+func (s *SubscriptionPromotionalOffersAPI) Get(offerId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptionPromotionalOffers/"+offerId, params)
+}
+
+// Prices retrieves the prices of a promotional offer
+func (s *SubscriptionPromotionalOffersAPI) Prices(offerId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptionPromotionalOffers/"+offerId+"/prices", params)
+}
+
+// Create creates a promotional offer for a subscription, with its prices
+func (s *SubscriptionPromotionalOffersAPI) Create(subscriptionId string, offer PromotionalOffer) (map[string]interface{}, error) {
+	if offer.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if offer.OfferCode == "" {
+		return nil, fmt.Errorf("offer code is required")
+	}
+	if offer.OfferMode == "" {
+		return nil, fmt.Errorf("offer mode is required")
+	}
+	if offer.Duration == "" {
+		return nil, fmt.Errorf("duration is required")
+	}
+	if len(offer.Prices) == 0 {
+		return nil, fmt.Errorf("at least one price is required")
+	}
+	if offer.NumberOfPeriods <= 0 {
+		offer.NumberOfPeriods = 1
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	pricesData, included := subscriptionOfferPrices("subscriptionPromotionalOfferPrices", offer.Prices)
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionPromotionalOffers",
+			"attributes": map[string]interface{}{
+				"name":            offer.Name,
+				"offerCode":       offer.OfferCode,
+				"offerMode":       offer.OfferMode,
+				"duration":        offer.Duration,
+				"numberOfPeriods": offer.NumberOfPeriods,
+			},
+			"relationships": map[string]interface{}{
+				"subscription": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptions",
+						"id":   subscriptionId,
+					},
+				},
+				"prices": map[string]interface{}{
+					"data": pricesData,
+				},
+			},
+		},
+		"included": included,
+	}
+
+	return s.client.GetHTTPClient().PostJSON("/subscriptionPromotionalOffers", data)
+}
+
+// UpdatePrices replaces the prices of a promotional offer
+func (s *SubscriptionPromotionalOffersAPI) UpdatePrices(offerId string, prices []SubscriptionOfferPrice) (map[string]interface{}, error) {
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("at least one price is required")
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	pricesData, included := subscriptionOfferPrices("subscriptionPromotionalOfferPrices", prices)
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptionPromotionalOffers",
+			"id":   offerId,
+			"relationships": map[string]interface{}{
+				"prices": map[string]interface{}{
+					"data": pricesData,
+				},
+			},
+		},
+		"included": included,
+	}
+
+	return s.client.GetHTTPClient().PatchJSON("/subscriptionPromotionalOffers/"+offerId, data)
+}
+
+// Delete deletes a promotional offer by ID
+func (s *SubscriptionPromotionalOffersAPI) Delete(offerId string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Delete("/subscriptionPromotionalOffers/"+offerId, nil)
+}
+
+// subscriptionOfferPrices builds the price linkages and the included price
+// resources of the given type, which are created inline using local IDs
+func subscriptionOfferPrices(priceType string, prices []SubscriptionOfferPrice) ([]map[string]string, []map[string]interface{}) {
+	linkages := make([]map[string]string, len(prices))
+	included := make([]map[string]interface{}, len(prices))
+	for i, price := range prices {
+		localId := "${price-" + strconv.Itoa(i) + "}"
+		linkages[i] = map[string]string{
+			"type": priceType,
+			"id":   localId,
+		}
+		included[i] = map[string]interface{}{
+			"type": priceType,
+			"id":   localId,
+			"relationships": map[string]interface{}{
+				"territory": map[string]interface{}{
+					"data": map[string]string{
+						"type": "territories",
+						"id":   price.Territory,
+					},
+				},
+				"subscriptionPricePoint": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptionPricePoints",
+						"id":   price.PricePointID,
+					},
+				},
+			},
+		}
+	}
+	return linkages, included
+}
@@ -0,0 +1,237 @@
+package appstore
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFamilySharingIrreversible is returned when enabling Family Sharing
+// without acknowledging that it can never be turned off again
+var ErrFamilySharingIrreversible = errors.New("family sharing cannot be disabled once enabled")
+
+// SubscriptionPeriod represents the renewal period of an auto-renewable subscription
+type SubscriptionPeriod string
+
+// Subscription periods
+const (
+	SubscriptionPeriodOneWeek     SubscriptionPeriod = "ONE_WEEK"
+	SubscriptionPeriodOneMonth    SubscriptionPeriod = "ONE_MONTH"
+	SubscriptionPeriodTwoMonths   SubscriptionPeriod = "TWO_MONTHS"
+	SubscriptionPeriodThreeMonths SubscriptionPeriod = "THREE_MONTHS"
+	SubscriptionPeriodSixMonths   SubscriptionPeriod = "SIX_MONTHS"
+	SubscriptionPeriodOneYear     SubscriptionPeriod = "ONE_YEAR"
+)
+
+// SubscriptionState represents the review and sale state of a subscription
+type SubscriptionState string
+
+// Subscription states
+const (
+	SubscriptionStateMissingMetadata          SubscriptionState = "MISSING_METADATA"
+	SubscriptionStateReadyToSubmit            SubscriptionState = "READY_TO_SUBMIT"
+	SubscriptionStateWaitingForReview         SubscriptionState = "WAITING_FOR_REVIEW"
+	SubscriptionStateInReview                 SubscriptionState = "IN_REVIEW"
+	SubscriptionStateDeveloperActionNeeded    SubscriptionState = "DEVELOPER_ACTION_NEEDED"
+	SubscriptionStatePendingBinaryApproval    SubscriptionState = "PENDING_BINARY_APPROVAL"
+	SubscriptionStateApproved                 SubscriptionState = "APPROVED"
+	SubscriptionStateDeveloperRemovedFromSale SubscriptionState = "DEVELOPER_REMOVED_FROM_SALE"
+	SubscriptionStateRemovedFromSale          SubscriptionState = "REMOVED_FROM_SALE"
+	SubscriptionStateRejected                 SubscriptionState = "REJECTED"
+)
+
+// Subscription represents an auto-renewable subscription
+type Subscription struct {
+	ID                 string             `json:"-"`
+	Name               string             `json:"name"`
+	ProductID          string             `json:"productId"`
+	State              SubscriptionState  `json:"state"`
+	SubscriptionPeriod SubscriptionPeriod `json:"subscriptionPeriod"`
+	GroupLevel         int                `json:"groupLevel"`
+	FamilySharable     bool               `json:"familySharable"`
+	ReviewNote         string             `json:"reviewNote"`
+}
+
+// SubscriptionAttributes holds the attributes of a new subscription
+type SubscriptionAttributes struct {
+	Name               string             `json:"name"`
+	ProductID          string             `json:"productId"`
+	SubscriptionPeriod SubscriptionPeriod `json:"subscriptionPeriod,omitempty"`
+	// GroupLevel ranks the subscription within its group, 1 being the highest level of service
+	GroupLevel int    `json:"groupLevel,omitempty"`
+	ReviewNote string `json:"reviewNote,omitempty"`
+}
+
+// SubscriptionUpdate holds the subscription attributes to change, nil fields are left unchanged
+type SubscriptionUpdate struct {
+	Name               *string             `json:"name,omitempty"`
+	SubscriptionPeriod *SubscriptionPeriod `json:"subscriptionPeriod,omitempty"`
+	GroupLevel         *int                `json:"groupLevel,omitempty"`
+	ReviewNote         *string             `json:"reviewNote,omitempty"`
+}
+
+// SubscriptionsAPI handles auto-renewable subscription operations
+type SubscriptionsAPI struct {
+	client *Client
+}
+
+// NewSubscriptionsAPI creates a new Subscriptions API client
+func NewSubscriptionsAPI(client *Client) *SubscriptionsAPI {
+	return &SubscriptionsAPI{client: client}
+}
+
+// All retrieves the subscriptions of a subscription group
+func (s *SubscriptionsAPI) All(groupId string, params map[string]string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Get("/subscriptionGroups/"+groupId+"/subscriptions", params)
+}
+
+// List retrieves every subscription of a subscription group, following pagination
+func (s *SubscriptionsAPI) List(groupId string) ([]Subscription, error) {
+	var subscriptions []Subscription
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := s.All(groupId, query)
+		if err != nil {
+			return subscriptions, err
+		}
+		for _, resource := range resourceList(response) {
+			subscription, err := parseSubscription(resource)
+			if err != nil {
+				return subscriptions, err
+			}
+			subscriptions = append(subscriptions, subscription)
+		}
+		query = nextPageParams(response)
+	}
+	return subscriptions, nil
+}
+
+// Get retrieves a subscription by ID
+func (s *SubscriptionsAPI) Get(subscriptionId string) (Subscription, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return Subscription{}, err
+	}
+	response, err := s.client.GetHTTPClient().Get("/subscriptions/"+subscriptionId, nil)
+	if err != nil {
+		return Subscription{}, err
+	}
+	return parseSubscriptionResponse(response)
+}
+
+// Create creates a new subscription within a subscription group
+func (s *SubscriptionsAPI) Create(groupId string, attributes SubscriptionAttributes) (Subscription, error) {
+	if attributes.Name == "" {
+		return Subscription{}, fmt.Errorf("name is required")
+	}
+	if attributes.ProductID == "" {
+		return Subscription{}, fmt.Errorf("product id is required")
+	}
+	if err := s.client.EnsureAuth(); err != nil {
+		return Subscription{}, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "subscriptions",
+			"attributes": attributes,
+			"relationships": map[string]interface{}{
+				"group": map[string]interface{}{
+					"data": map[string]string{
+						"type": "subscriptionGroups",
+						"id":   groupId,
+					},
+				},
+			},
+		},
+	}
+
+	response, err := s.client.GetHTTPClient().PostJSON("/subscriptions", data)
+	if err != nil {
+		return Subscription{}, err
+	}
+	return parseSubscriptionResponse(response)
+}
+
+// Update changes the attributes of a subscription
+func (s *SubscriptionsAPI) Update(subscriptionId string, update SubscriptionUpdate) (Subscription, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return Subscription{}, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "subscriptions",
+			"id":         subscriptionId,
+			"attributes": update,
+		},
+	}
+
+	response, err := s.client.GetHTTPClient().PatchJSON("/subscriptions/"+subscriptionId, data)
+	if err != nil {
+		return Subscription{}, err
+	}
+	return parseSubscriptionResponse(response)
+}
+
+// EnableFamilySharing turns on Family Sharing for a subscription. Apple does
+// not allow turning it off again, so the call fails with
+// ErrFamilySharingIrreversible unless acknowledgeIrreversible is set.
+// Subscriptions that already have Family Sharing are returned unchanged.
+func (s *SubscriptionsAPI) EnableFamilySharing(subscriptionId string, acknowledgeIrreversible bool) (Subscription, error) {
+	subscription, err := s.Get(subscriptionId)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if subscription.FamilySharable {
+		return subscription, nil
+	}
+	if !acknowledgeIrreversible {
+		return subscription, ErrFamilySharingIrreversible
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "subscriptions",
+			"id":   subscriptionId,
+			"attributes": map[string]bool{
+				"familySharable": true,
+			},
+		},
+	}
+
+	response, err := s.client.GetHTTPClient().PatchJSON("/subscriptions/"+subscriptionId, data)
+	if err != nil {
+		return Subscription{}, err
+	}
+	return parseSubscriptionResponse(response)
+}
+
+// Delete deletes a subscription by ID. Only subscriptions that were never
+// submitted for review can be deleted.
+func (s *SubscriptionsAPI) Delete(subscriptionId string) (map[string]interface{}, error) {
+	if err := s.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return s.client.GetHTTPClient().Delete("/subscriptions/"+subscriptionId, nil)
+}
+
+// parseSubscriptionResponse converts a single subscription response to a Subscription
+func parseSubscriptionResponse(response map[string]interface{}) (Subscription, error) {
+	resource, err := responseResource(response)
+	if err != nil {
+		return Subscription{}, err
+	}
+	return parseSubscription(resource)
+}
+
+// parseSubscription converts a subscriptions resource object to a Subscription
+func parseSubscription(resource map[string]interface{}) (Subscription, error) {
+	var subscription Subscription
+	if err := decodeAttributes(resource, &subscription); err != nil {
+		return Subscription{}, err
+	}
+	subscription.ID = resourceID(resource)
+	return subscription, nil
+}
@@ -0,0 +1,34 @@
+package appstore
+
+// TokenProvider supplies the tokens that authenticate requests, for keys
+// that cannot be handed to the client, such as keys held by Vault, a KMS, or
+// a signing service. A *jwtutil.TokenCache is the TokenProvider of keys
+// configured with a secret.
+type TokenProvider interface {
+	// Token returns a token valid for at least a few more minutes. It is
+	// called before every request, so it should cache tokens.
+	Token() (string, error)
+	// Renew returns a token to replace rejected, which App Store Connect
+	// rejected with 401, or false when no other token can be supplied
+	Renew(rejected string) (string, bool, error)
+}
+
+// TokenFunc adapts a function returning tokens, such as pre-minted tokens
+// or tokens requested from a remote signer, to a TokenProvider. A rejected
+// token is renewed by calling the function again.
+type TokenFunc func() (string, error)
+
+// Token returns the token of the function
+func (f TokenFunc) Token() (string, error) {
+	return f()
+}
+
+// Renew calls the function again, and reports false when it returns the
+// rejected token
+func (f TokenFunc) Renew(rejected string) (string, bool, error) {
+	token, err := f()
+	if err != nil {
+		return "", false, err
+	}
+	return token, token != rejected, nil
+}
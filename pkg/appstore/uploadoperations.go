@@ -0,0 +1,28 @@
+package appstore
+
+import (
+	"context"
+
+	"appstore-connect-api/pkg/assetupload"
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// reserveAndUpload reserves an asset of resourceType with the given
+// relationships, uploads every part of the file, and commits the asset
+// without a checksum. It returns the committed asset response.
+func (c *Client) reserveAndUpload(resourceType, path string, relationships map[string]jsonapi.Relationship) (map[string]interface{}, error) {
+	uploader := assetupload.New(c)
+	reservation, err := uploader.Reserve(assetupload.Asset{
+		ResourceType:  resourceType,
+		Path:          path,
+		Relationships: relationships,
+		OmitChecksum:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := uploader.UploadParts(context.Background(), reservation); err != nil {
+		return nil, err
+	}
+	return uploader.Commit(reservation)
+}
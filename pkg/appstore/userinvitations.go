@@ -0,0 +1,57 @@
+package appstore
+
+import "fmt"
+
+// UserInvitationsAPI handles user invitation-related operations
+type UserInvitationsAPI struct {
+	client *Client
+}
+
+// NewUserInvitationsAPI creates a new UserInvitations API client
+func NewUserInvitationsAPI(client *Client) *UserInvitationsAPI {
+	return &UserInvitationsAPI{client: client}
+}
+
+// All retrieves all pending user invitations
+func (u *UserInvitationsAPI) All(params map[string]string) (map[string]interface{}, error) {
+	if err := u.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return u.client.GetHTTPClient().Get("/userInvitations", params)
+}
+
+// Invite invites a new user to the team with the given roles
+func (u *UserInvitationsAPI) Invite(email, firstName, lastName string, roles []UserRole, allAppsVisible bool) (map[string]interface{}, error) {
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+	if err := ValidateUserRoles(roles); err != nil {
+		return nil, err
+	}
+	if err := u.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "userInvitations",
+			"attributes": map[string]interface{}{
+				"email":          email,
+				"firstName":      firstName,
+				"lastName":       lastName,
+				"roles":          roles,
+				"allAppsVisible": allAppsVisible,
+			},
+		},
+	}
+
+	return u.client.GetHTTPClient().PostJSON("/userInvitations", data)
+}
+
+// Cancel cancels a pending user invitation by ID
+func (u *UserInvitationsAPI) Cancel(invitationId string) (map[string]interface{}, error) {
+	if err := u.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return u.client.GetHTTPClient().Delete("/userInvitations/"+invitationId, nil)
+}
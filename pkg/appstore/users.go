@@ -0,0 +1,192 @@
+package appstore
+
+import "fmt"
+
+// UserRole represents a role that can be assigned to a team member
+type UserRole string
+
+// User roles supported by App Store Connect
+const (
+	UserRoleAdmin                       UserRole = "ADMIN"
+	UserRoleFinance                     UserRole = "FINANCE"
+	UserRoleAccountHolder               UserRole = "ACCOUNT_HOLDER"
+	UserRoleSales                       UserRole = "SALES"
+	UserRoleMarketing                   UserRole = "MARKETING"
+	UserRoleAppManager                  UserRole = "APP_MANAGER"
+	UserRoleDeveloper                   UserRole = "DEVELOPER"
+	UserRoleAccessToReports             UserRole = "ACCESS_TO_REPORTS"
+	UserRoleCustomerSupport             UserRole = "CUSTOMER_SUPPORT"
+	UserRoleCreateApps                  UserRole = "CREATE_APPS"
+	UserRoleCloudManagedDeveloperID     UserRole = "CLOUD_MANAGED_DEVELOPER_ID"
+	UserRoleCloudManagedAppDistribution UserRole = "CLOUD_MANAGED_APP_DISTRIBUTION"
+	UserRoleGenerateIndividualKeys      UserRole = "GENERATE_INDIVIDUAL_KEYS"
+)
+
+// userRoles lists every known user role
+var userRoles = []UserRole{
+	UserRoleAdmin,
+	UserRoleFinance,
+	UserRoleAccountHolder,
+	UserRoleSales,
+	UserRoleMarketing,
+	UserRoleAppManager,
+	UserRoleDeveloper,
+	UserRoleAccessToReports,
+	UserRoleCustomerSupport,
+	UserRoleCreateApps,
+	UserRoleCloudManagedDeveloperID,
+	UserRoleCloudManagedAppDistribution,
+	UserRoleGenerateIndividualKeys,
+}
+
+// IsValid reports whether the role is a known App Store Connect role
+func (r UserRole) IsValid() bool {
+	for _, role := range userRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateUserRoles checks that at least one role is given and all roles are known
+func ValidateUserRoles(roles []UserRole) error {
+	if len(roles) == 0 {
+		return fmt.Errorf("at least one role is required")
+	}
+	for _, role := range roles {
+		if !role.IsValid() {
+			return fmt.Errorf("invalid user role: %s", role)
+		}
+	}
+	return nil
+}
+
+// UsersAPI handles user-related operations
+type UsersAPI struct {
+	client *Client
+}
+
+// NewUsersAPI creates a new Users API client
+func NewUsersAPI(client *Client) *UsersAPI {
+	return &UsersAPI{client: client}
+}
+
+// All retrieves all users
+func (u *UsersAPI) All(params map[string]string) (map[string]interface{}, error) {
+	if err := u.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return u.client.GetHTTPClient().Get("/users", params)
+}
+
+// Get retrieves a user by ID
+func (u *UsersAPI) Get(userId string, params map[string]string) (map[string]interface{}, error) {
+	if err := u.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return u.client.GetHTTPClient().Get("/users/"+userId, params)
+}
+
+// Update changes the roles and app visibility of a user
+func (u *UsersAPI) Update(userId string, roles []UserRole, allAppsVisible bool) (map[string]interface{}, error) {
+	if err := ValidateUserRoles(roles); err != nil {
+		return nil, err
+	}
+	if err := u.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "users",
+			"id":   userId,
+			"attributes": map[string]interface{}{
+				"roles":          roles,
+				"allAppsVisible": allAppsVisible,
+			},
+		},
+	}
+
+	return u.client.GetHTTPClient().PatchJSON("/users/"+userId, data)
+}
+
+// Delete removes a user from the team
+func (u *UsersAPI) Delete(userId string) (map[string]interface{}, error) {
+	if err := u.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return u.client.GetHTTPClient().Delete("/users/"+userId, nil)
+}
+
+// visibleAppsBatchSize is the maximum number of apps sent per relationship request
+const visibleAppsBatchSize = 50
+
+// ListVisibleApps lists the apps a user can see
+func (u *UsersAPI) ListVisibleApps(userId string, params map[string]string) (map[string]interface{}, error) {
+	if err := u.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return u.client.GetHTTPClient().Get("/users/"+userId+"/relationships/visibleApps", params)
+}
+
+// AddVisibleApps grants a user visibility of the given apps, in batches
+func (u *UsersAPI) AddVisibleApps(userId string, appIds []string) error {
+	if err := u.client.EnsureAuth(); err != nil {
+		return err
+	}
+	for _, batch := range batchIds(appIds, visibleAppsBatchSize) {
+		if _, err := u.client.GetHTTPClient().PostJSON("/users/"+userId+"/relationships/visibleApps", appLinkages(batch)); err != nil {
+			return fmt.Errorf("failed to add visible apps: %w", err)
+		}
+	}
+	return nil
+}
+
+// RemoveVisibleApps revokes a user's visibility of the given apps, in batches
+func (u *UsersAPI) RemoveVisibleApps(userId string, appIds []string) error {
+	if err := u.client.EnsureAuth(); err != nil {
+		return err
+	}
+	for _, batch := range batchIds(appIds, visibleAppsBatchSize) {
+		if _, err := u.client.GetHTTPClient().DeleteJSON("/users/"+userId+"/relationships/visibleApps", appLinkages(batch)); err != nil {
+			return fmt.Errorf("failed to remove visible apps: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReplaceVisibleApps replaces the full set of apps a user can see
+func (u *UsersAPI) ReplaceVisibleApps(userId string, appIds []string) (map[string]interface{}, error) {
+	if err := u.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return u.client.GetHTTPClient().PatchJSON("/users/"+userId+"/relationships/visibleApps", appLinkages(appIds))
+}
+
+// appLinkages builds a relationship linkage document for the given app IDs
+func appLinkages(appIds []string) map[string]interface{} {
+	appsData := make([]map[string]string, len(appIds))
+	for i, id := range appIds {
+		appsData[i] = map[string]string{
+			"type": "apps",
+			"id":   id,
+		}
+	}
+	return map[string]interface{}{
+		"data": appsData,
+	}
+}
+
+// batchIds splits IDs into batches of at most size elements
+func batchIds(ids []string, size int) [][]string {
+	var batches [][]string
+	for len(ids) > size {
+		batches = append(batches, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		batches = append(batches, ids)
+	}
+	return batches
+}
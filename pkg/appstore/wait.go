@@ -0,0 +1,78 @@
+package appstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const defaultWaitInterval = 30 * time.Second
+
+// ErrWaitTimeout is returned by WaitFor when its timeout expires first
+var ErrWaitTimeout = errors.New("timed out waiting")
+
+// WaitOptions configures how WaitFor polls
+type WaitOptions struct {
+	// Interval is the delay between checks, 30 seconds when zero
+	Interval time.Duration
+	// MaxInterval enables backoff: the delay doubles after every check
+	// until it reaches MaxInterval. The delay stays at Interval when zero.
+	MaxInterval time.Duration
+	// Timeout ends the wait with ErrWaitTimeout, no timeout when zero
+	Timeout time.Duration
+}
+
+// WaitFor calls check until it reports done, an error, or ctx is done, and
+// returns the last value of check. check receives the context of the wait,
+// which ends with Timeout, and should send its requests with it. The
+// helpers that wait for builds, build runs, and App Store versions are
+// built on it, and it serves for other asynchronous operations:
+//
+//	report, err := appstore.WaitFor(ctx, appstore.WaitOptions{Interval: time.Minute, Timeout: time.Hour},
+//		func(ctx context.Context) ([]byte, bool, error) {
+//			report, err := reportsAPI.WithContext(ctx).DownloadSalesReport(params)
+//			if httpclient.IsNotFound(err) {
+//				return nil, false, nil // not available yet
+//			}
+//			return report, err == nil, err
+//		})
+func WaitFor[T any](ctx context.Context, options WaitOptions, check func(ctx context.Context) (T, bool, error)) (T, error) {
+	interval := options.Interval
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+	parent := ctx
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	for {
+		value, done, err := check(ctx)
+		if err != nil || done {
+			return value, waitError(parent, ctx, options.Timeout, err)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return value, waitError(parent, ctx, options.Timeout, ctx.Err())
+		case <-timer.C:
+		}
+		if options.MaxInterval > interval {
+			interval = min(interval*2, options.MaxInterval)
+		}
+	}
+}
+
+// waitError returns ErrWaitTimeout for errors caused by the timeout of
+// WaitFor rather than by its caller's context, and err otherwise
+func waitError(parent, ctx context.Context, timeout time.Duration, err error) error {
+	if err != nil && ctx.Err() == context.DeadlineExceeded && parent.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrWaitTimeout, timeout)
+	}
+	return err
+}
@@ -0,0 +1,85 @@
+package appstore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+func TestWaitFor(t *testing.T) {
+	checks := 0
+	got, err := appstore.WaitFor(context.Background(), appstore.WaitOptions{Interval: time.Millisecond, MaxInterval: 4 * time.Millisecond},
+		func(ctx context.Context) (int, bool, error) {
+			checks++
+			return checks, checks == 3, nil
+		})
+	if err != nil {
+		t.Fatalf("WaitFor: %v", err)
+	}
+	if got != 3 {
+		t.Errorf("got %d, want the value of the third check", got)
+	}
+
+	// An error of check ends the wait
+	failure := errors.New("build processing failed")
+	_, err = appstore.WaitFor(context.Background(), appstore.WaitOptions{Interval: time.Millisecond},
+		func(ctx context.Context) (string, bool, error) {
+			return "", false, failure
+		})
+	if !errors.Is(err, failure) {
+		t.Errorf("got %v, want the error of check", err)
+	}
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	_, err := appstore.WaitFor(context.Background(), appstore.WaitOptions{Interval: time.Millisecond, Timeout: 20 * time.Millisecond},
+		func(ctx context.Context) (string, bool, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("check received a context without the deadline of the wait")
+			}
+			return "PROCESSING", false, nil
+		})
+	if !errors.Is(err, appstore.ErrWaitTimeout) {
+		t.Errorf("got %v, want ErrWaitTimeout", err)
+	}
+
+	// Requests of check cut off by the timeout also report it
+	_, err = appstore.WaitFor(context.Background(), appstore.WaitOptions{Interval: time.Millisecond, Timeout: 20 * time.Millisecond},
+		func(ctx context.Context) (string, bool, error) {
+			<-ctx.Done()
+			return "", false, ctx.Err()
+		})
+	if !errors.Is(err, appstore.ErrWaitTimeout) {
+		t.Errorf("got %v for a check cut off by the timeout, want ErrWaitTimeout", err)
+	}
+}
+
+func TestWaitForCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	checks := 0
+	_, err := appstore.WaitFor(ctx, appstore.WaitOptions{Interval: time.Millisecond, Timeout: time.Minute},
+		func(ctx context.Context) (string, bool, error) {
+			checks++
+			if checks == 2 {
+				cancel()
+			}
+			return "PROCESSING", false, nil
+		})
+	if !errors.Is(err, context.Canceled) || errors.Is(err, appstore.ErrWaitTimeout) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+
+	// The deadline of the caller's context is not the timeout of the wait
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = appstore.WaitFor(ctx, appstore.WaitOptions{Interval: time.Millisecond, Timeout: time.Minute},
+		func(ctx context.Context) (string, bool, error) {
+			return "PROCESSING", false, nil
+		})
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, appstore.ErrWaitTimeout) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,205 @@
+package appstore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+const defaultWatchInterval = 5 * time.Minute
+
+// WatchEventType represents the kind of a watch event
+type WatchEventType string
+
+// Watch event types
+const (
+	WatchAdded   WatchEventType = "ADDED"
+	WatchChanged WatchEventType = "CHANGED"
+	WatchRemoved WatchEventType = "REMOVED"
+)
+
+// WatchEvent is a change of a watched resource between two polls
+type WatchEvent struct {
+	Type WatchEventType
+	// Source is the name of the WatchSource the resource belongs to
+	Source string
+	ID     string
+	// Old is the resource object of the previous poll, nil for added resources
+	Old map[string]interface{}
+	// New is the resource object of the latest poll, nil for removed resources
+	New map[string]interface{}
+	// Attributes lists the names of the changed attributes of changed resources
+	Attributes []string
+}
+
+// WatchSource is a list endpoint polled by a Watcher. Every page of the list
+// is fetched on each poll.
+type WatchSource struct {
+	Name   string
+	Path   string
+	Params map[string]string
+}
+
+// WatchDevices watches the registered devices
+func WatchDevices() WatchSource {
+	return WatchSource{Name: "devices", Path: "/devices"}
+}
+
+// WatchProfiles watches the provisioning profiles
+func WatchProfiles() WatchSource {
+	return WatchSource{Name: "profiles", Path: "/profiles"}
+}
+
+// WatchBuilds watches the builds of an app, including their processing state
+func WatchBuilds(appId string) WatchSource {
+	return WatchSource{Name: "builds", Path: "/builds", Params: map[string]string{"filter[app]": appId}}
+}
+
+// WatchCustomerReviews watches the customer reviews of an app
+func WatchCustomerReviews(appId string) WatchSource {
+	return WatchSource{Name: "customerReviews", Path: "/apps/" + appId + "/customerReviews"}
+}
+
+// WatchAppStoreVersions watches the App Store versions of an app, including their states
+func WatchAppStoreVersions(appId string) WatchSource {
+	return WatchSource{Name: "appStoreVersions", Path: "/apps/" + appId + "/appStoreVersions"}
+}
+
+// WatcherConfig holds the watcher configuration
+type WatcherConfig struct {
+	Sources []WatchSource
+	// Interval is the delay between polls, five minutes when zero
+	Interval time.Duration
+	// EmitInitial reports every resource of the first poll as added,
+	// otherwise the first poll only records the snapshot
+	EmitInitial bool
+}
+
+// Watcher polls resources and reports the differences to its last snapshot
+// as events, since App Store Connect has no webhooks for portal changes
+type Watcher struct {
+	client    *Client
+	config    WatcherConfig
+	snapshots []map[string]map[string]interface{}
+}
+
+// NewWatcher creates a new resource watcher
+func NewWatcher(client *Client, config WatcherConfig) (*Watcher, error) {
+	if len(config.Sources) == 0 {
+		return nil, fmt.Errorf("at least one source is required")
+	}
+	for _, source := range config.Sources {
+		if source.Name == "" || source.Path == "" {
+			return nil, fmt.Errorf("source name and path are required")
+		}
+	}
+	if config.Interval <= 0 {
+		config.Interval = defaultWatchInterval
+	}
+
+	return &Watcher{
+		client:    client,
+		config:    config,
+		snapshots: make([]map[string]map[string]interface{}, len(config.Sources)),
+	}, nil
+}
+
+// Run polls the sources and sends their events to events until the context
+// is cancelled or a poll fails. Run does not close events.
+func (w *Watcher) Run(ctx context.Context, events chan<- WatchEvent) error {
+	client := w.client.WithContext(ctx)
+	for {
+		polled, err := w.poll(client)
+		for _, event := range polled {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(w.config.Interval):
+		}
+	}
+}
+
+// Poll fetches every source once and returns the events since the previous
+// poll. A source that fails to load keeps its previous snapshot.
+func (w *Watcher) Poll() ([]WatchEvent, error) {
+	return w.poll(w.client)
+}
+
+// poll fetches every source once with client
+func (w *Watcher) poll(client *Client) ([]WatchEvent, error) {
+	if err := client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	var events []WatchEvent
+	for i, source := range w.config.Sources {
+		resources, err := client.listResources(source.Path, source.Params)
+		if err != nil {
+			return events, fmt.Errorf("failed to list %s: %w", source.Name, err)
+		}
+
+		snapshot := make(map[string]map[string]interface{}, len(resources))
+		for _, resource := range resources {
+			snapshot[resourceID(resource)] = resource
+		}
+		previous := w.snapshots[i]
+		w.snapshots[i] = snapshot
+		if previous == nil && !w.config.EmitInitial {
+			continue
+		}
+		events = append(events, diffSnapshots(source.Name, previous, snapshot)...)
+	}
+	return events, nil
+}
+
+// diffSnapshots returns the events between two snapshots of a source, in ID order
+func diffSnapshots(source string, previous, current map[string]map[string]interface{}) []WatchEvent {
+	var events []WatchEvent
+	for _, id := range sortedKeys(current) {
+		resource := current[id]
+		old, ok := previous[id]
+		if !ok {
+			events = append(events, WatchEvent{Type: WatchAdded, Source: source, ID: id, New: resource})
+			continue
+		}
+		if changed := changedAttributes(old, resource); len(changed) > 0 {
+			events = append(events, WatchEvent{Type: WatchChanged, Source: source, ID: id, Old: old, New: resource, Attributes: changed})
+		}
+	}
+	for _, id := range sortedKeys(previous) {
+		if _, ok := current[id]; !ok {
+			events = append(events, WatchEvent{Type: WatchRemoved, Source: source, ID: id, Old: previous[id]})
+		}
+	}
+	return events
+}
+
+// changedAttributes returns the names of the attributes that differ between two resource objects
+func changedAttributes(old, resource map[string]interface{}) []string {
+	oldAttributes, _ := old["attributes"].(map[string]interface{})
+	attributes, _ := resource["attributes"].(map[string]interface{})
+
+	var changed []string
+	for _, name := range sortedKeys(attributes) {
+		if !reflect.DeepEqual(oldAttributes[name], attributes[name]) {
+			changed = append(changed, name)
+		}
+	}
+	for _, name := range sortedKeys(oldAttributes) {
+		if _, ok := attributes[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
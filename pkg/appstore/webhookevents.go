@@ -0,0 +1,138 @@
+package appstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// WebhookSignatureHeader is the header of webhook requests holding the
+// HMAC-SHA256 signature of the body, as "hmacsha256=<hex digest>"
+const WebhookSignatureHeader = "X-Apple-Signature"
+
+// maxWebhookBody limits the size of the webhook requests read by the handler
+const maxWebhookBody = 1 << 20
+
+// Webhook payload types, the type of the data object of an event
+const (
+	WebhookPayloadPing                         = "webhookPingCreated"
+	WebhookPayloadAppStoreVersionStateUpdated  = "appStoreVersionAppVersionStateUpdated"
+	WebhookPayloadBuildUploadStateUpdated      = "buildUploadStateUpdated"
+	WebhookPayloadExternalBuildStateUpdated    = "buildBetaDetailExternalBuildStateUpdated"
+	WebhookPayloadFeedbackScreenshotSubmission = "betaFeedbackScreenshotSubmissionCreated"
+	WebhookPayloadFeedbackCrashSubmission      = "betaFeedbackCrashSubmissionCreated"
+)
+
+// WebhookEvent is an event delivered to a webhook
+type WebhookEvent struct {
+	ID string `json:"-"`
+	// Type is the payload type, such as WebhookPayloadBuildUploadStateUpdated
+	Type    string `json:"-"`
+	Version int    `json:"-"`
+	// OldValue and NewValue are the states of state update events, such as
+	// WAITING_FOR_REVIEW and IN_REVIEW
+	OldValue  string `json:"oldValue"`
+	NewValue  string `json:"newValue"`
+	Timestamp string `json:"timestamp"`
+	// Instance identifies the resource of the event, such as the
+	// appStoreVersions resource whose state changed
+	Instance jsonapi.Linkage `json:"-"`
+	// Attributes holds every attribute of the event, including those of
+	// event types without a field
+	Attributes map[string]interface{} `json:"-"`
+}
+
+// ParseWebhookEvent decodes the body of a webhook request. Verify its
+// signature first with VerifyWebhookSignature.
+func ParseWebhookEvent(body []byte) (WebhookEvent, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return WebhookEvent{}, fmt.Errorf("failed to parse webhook event: %w", err)
+	}
+	resource, err := jsonapi.Data(payload)
+	if err != nil {
+		return WebhookEvent{}, err
+	}
+
+	var event WebhookEvent
+	if err := jsonapi.Unmarshal(resource, &event); err != nil {
+		return WebhookEvent{}, err
+	}
+	event.Type = jsonapi.ResourceType(resource)
+	if version, ok := resource["version"].(float64); ok {
+		event.Version = int(version)
+	}
+	event.Instance, _ = jsonapi.ToOneLinkage(resource, "instance")
+	event.Attributes, _ = resource["attributes"].(map[string]interface{})
+	return event, nil
+}
+
+// VerifyWebhookSignature verifies the signature header of a webhook request
+// against the HMAC-SHA256 of its body with the webhook's secret
+func VerifyWebhookSignature(secret string, body []byte, signature string) error {
+	if secret == "" {
+		// anyone can sign with an empty key
+		return fmt.Errorf("secret is required")
+	}
+	if signature == "" {
+		return fmt.Errorf("webhook request is not signed")
+	}
+	digest, err := hex.DecodeString(strings.TrimPrefix(signature, "hmacsha256="))
+	if err != nil {
+		return fmt.Errorf("invalid webhook signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(digest, mac.Sum(nil)) {
+		return fmt.Errorf("webhook signature mismatch")
+	}
+	return nil
+}
+
+// NewWebhookHandler returns an http.Handler for a webhook's endpoint. It
+// verifies the signature of every request with secret and calls handle with
+// its event. Requests with an invalid signature are answered with 401, and
+// events that handle fails are answered with 500, so Apple redelivers them.
+func NewWebhookHandler(secret string, handle func(ctx context.Context, event WebhookEvent) error) (http.Handler, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("secret is required")
+	}
+	if handle == nil {
+		return nil, fmt.Errorf("handler is required")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if err := VerifyWebhookSignature(secret, body, r.Header.Get(WebhookSignatureHeader)); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		event, err := ParseWebhookEvent(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := handle(r.Context(), event); err != nil {
+			http.Error(w, "failed to handle event", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}), nil
+}
@@ -0,0 +1,118 @@
+package appstore_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+)
+
+const webhookSecret = "s3cret"
+
+const buildUploadEvent = `{"data": {
+	"type": "buildUploadStateUpdated",
+	"id": "EVENT1",
+	"version": 1,
+	"attributes": {"oldValue": "PROCESSING", "newValue": "COMPLETE", "timestamp": "2026-01-31T10:00:00Z"},
+	"relationships": {"instance": {"data": {"type": "buildUploads", "id": "UPLOAD1"}}}
+}}`
+
+// sign returns the signature header of a webhook body
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "hmacsha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		wantErr   bool
+	}{
+		{name: "valid", secret: webhookSecret, signature: sign(webhookSecret, buildUploadEvent)},
+		{name: "other secret", secret: webhookSecret, signature: sign("other", buildUploadEvent), wantErr: true},
+		{name: "other body", secret: webhookSecret, signature: sign(webhookSecret, "{}"), wantErr: true},
+		{name: "not hex", secret: webhookSecret, signature: "hmacsha256=xyz", wantErr: true},
+		{name: "unsigned", secret: webhookSecret, wantErr: true},
+		{name: "empty secret", signature: sign("", buildUploadEvent), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := appstore.VerifyWebhookSignature(tt.secret, []byte(buildUploadEvent), tt.signature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var events []appstore.WebhookEvent
+	var handleErr error
+	handler, err := appstore.NewWebhookHandler(webhookSecret, func(ctx context.Context, event appstore.WebhookEvent) error {
+		events = append(events, event)
+		return handleErr
+	})
+	if err != nil {
+		t.Fatalf("NewWebhookHandler: %v", err)
+	}
+
+	deliver := func(method, signature string) int {
+		r := httptest.NewRequest(method, "/webhook", strings.NewReader(buildUploadEvent))
+		if signature != "" {
+			r.Header.Set(appstore.WebhookSignatureHeader, signature)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if got := deliver(http.MethodPost, sign("other", buildUploadEvent)); got != http.StatusUnauthorized {
+		t.Errorf("got status %d for an invalid signature, want 401", got)
+	}
+	if got := deliver(http.MethodPost, ""); got != http.StatusUnauthorized {
+		t.Errorf("got status %d for an unsigned request, want 401", got)
+	}
+	if got := deliver(http.MethodGet, sign(webhookSecret, buildUploadEvent)); got != http.StatusMethodNotAllowed {
+		t.Errorf("got status %d for a GET request, want 405", got)
+	}
+	if len(events) != 0 {
+		t.Fatalf("handled %d rejected events", len(events))
+	}
+
+	if got := deliver(http.MethodPost, sign(webhookSecret, buildUploadEvent)); got != http.StatusOK {
+		t.Errorf("got status %d, want 200", got)
+	}
+	if len(events) != 1 {
+		t.Fatalf("handled %d events, want 1", len(events))
+	}
+	event := events[0]
+	if event.Type != appstore.WebhookPayloadBuildUploadStateUpdated || event.NewValue != "COMPLETE" || event.Instance.ID != "UPLOAD1" {
+		t.Errorf("got event %+v", event)
+	}
+
+	// Failed events are answered with 500 so Apple redelivers them
+	handleErr = errors.New("database unavailable")
+	if got := deliver(http.MethodPost, sign(webhookSecret, buildUploadEvent)); got != http.StatusInternalServerError {
+		t.Errorf("got status %d for a failed event, want 500", got)
+	}
+}
+
+func TestNewWebhookHandlerValidation(t *testing.T) {
+	handle := func(ctx context.Context, event appstore.WebhookEvent) error { return nil }
+	if _, err := appstore.NewWebhookHandler("", handle); err == nil {
+		t.Error("created a handler without a secret")
+	}
+	if _, err := appstore.NewWebhookHandler(webhookSecret, nil); err == nil {
+		t.Error("created a handler without a handle function")
+	}
+}
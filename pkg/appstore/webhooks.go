@@ -0,0 +1,219 @@
+package appstore
+
+import (
+	"fmt"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// WebhookEventType is an event a webhook is notified of
+type WebhookEventType string
+
+// Webhook event types
+const (
+	WebhookEventAppStoreVersionStateUpdated  WebhookEventType = "APP_STORE_VERSION_APP_VERSION_STATE_UPDATED"
+	WebhookEventBuildUploadStateUpdated      WebhookEventType = "BUILD_UPLOAD_STATE_UPDATED"
+	WebhookEventExternalBuildStateUpdated    WebhookEventType = "BUILD_BETA_DETAIL_EXTERNAL_BUILD_STATE_UPDATED"
+	WebhookEventFeedbackScreenshotSubmission WebhookEventType = "BETA_FEEDBACK_SCREENSHOT_SUBMISSION_CREATED"
+	WebhookEventFeedbackCrashSubmission      WebhookEventType = "BETA_FEEDBACK_CRASH_SUBMISSION_CREATED"
+)
+
+// Webhook is an endpoint App Store Connect notifies of the events of an app
+type Webhook struct {
+	ID         string             `json:"-"`
+	Name       string             `json:"name"`
+	URL        string             `json:"url"`
+	Enabled    bool               `json:"enabled"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+}
+
+// WebhookConfig configures a webhook. Empty fields are left unchanged by
+// Update.
+type WebhookConfig struct {
+	Name string
+	URL  string
+	// Secret signs the events sent to the webhook, see VerifyWebhookSignature
+	Secret     string
+	EventTypes []WebhookEventType
+	// Enabled turns event delivery on or off, webhooks are created enabled
+	// when it is nil
+	Enabled *bool
+}
+
+// attributes returns the attributes of the set fields
+func (w WebhookConfig) attributes() map[string]interface{} {
+	attributes := make(map[string]interface{})
+	if w.Name != "" {
+		attributes["name"] = w.Name
+	}
+	if w.URL != "" {
+		attributes["url"] = w.URL
+	}
+	if w.Secret != "" {
+		attributes["secret"] = w.Secret
+	}
+	if len(w.EventTypes) > 0 {
+		attributes["eventTypes"] = w.EventTypes
+	}
+	if w.Enabled != nil {
+		attributes["enabled"] = *w.Enabled
+	}
+	return attributes
+}
+
+// WebhooksAPI handles the webhooks App Store Connect notifies of build
+// processing, app review, and TestFlight feedback events
+type WebhooksAPI struct {
+	client *Client
+}
+
+// NewWebhooksAPI creates a new Webhooks API client
+func NewWebhooksAPI(client *Client) *WebhooksAPI {
+	return &WebhooksAPI{client: client}
+}
+
+// List retrieves every webhook of an app, following pagination
+func (w *WebhooksAPI) List(appId string) ([]Webhook, error) {
+	if appId == "" {
+		return nil, fmt.Errorf("app id is required")
+	}
+	if err := w.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	var webhooks []Webhook
+	query := map[string]string{"limit": "200"}
+	for query != nil {
+		response, err := w.client.GetHTTPClient().Get("/apps/"+appId+"/webhooks", query)
+		if err != nil {
+			return webhooks, err
+		}
+		page, err := unmarshalResources[Webhook](resourceList(response))
+		if err != nil {
+			return webhooks, err
+		}
+		webhooks = append(webhooks, page...)
+		query = nextPageParams(response)
+	}
+	return webhooks, nil
+}
+
+// Get retrieves a webhook by ID
+func (w *WebhooksAPI) Get(webhookId string) (Webhook, error) {
+	if err := w.client.EnsureAuth(); err != nil {
+		return Webhook{}, err
+	}
+	response, err := w.client.GetHTTPClient().Get("/webhooks/"+webhookId, nil)
+	if err != nil {
+		return Webhook{}, err
+	}
+	return decodeWebhook(response)
+}
+
+// Create registers a webhook for the events of an app
+func (w *WebhooksAPI) Create(appId string, config WebhookConfig) (Webhook, error) {
+	if appId == "" {
+		return Webhook{}, fmt.Errorf("app id is required")
+	}
+	if config.Name == "" {
+		return Webhook{}, fmt.Errorf("name is required")
+	}
+	if config.URL == "" {
+		return Webhook{}, fmt.Errorf("url is required")
+	}
+	if config.Secret == "" {
+		return Webhook{}, fmt.Errorf("secret is required")
+	}
+	if len(config.EventTypes) == 0 {
+		return Webhook{}, fmt.Errorf("event types are required")
+	}
+	if err := w.client.EnsureAuth(); err != nil {
+		return Webhook{}, err
+	}
+
+	attributes := config.attributes()
+	if config.Enabled == nil {
+		attributes["enabled"] = true
+	}
+	response, err := w.client.GetHTTPClient().PostJSON("/webhooks", jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "webhooks",
+		Attributes: attributes,
+		Relationships: map[string]jsonapi.Relationship{
+			"app": jsonapi.ToOne("apps", appId),
+		},
+	}))
+	if err != nil {
+		return Webhook{}, err
+	}
+	return decodeWebhook(response)
+}
+
+// Update changes the set fields of a webhook's configuration, such as to
+// rotate its secret or disable it
+func (w *WebhooksAPI) Update(webhookId string, config WebhookConfig) (Webhook, error) {
+	if webhookId == "" {
+		return Webhook{}, fmt.Errorf("webhook id is required")
+	}
+	if err := w.client.EnsureAuth(); err != nil {
+		return Webhook{}, err
+	}
+	response, err := w.client.GetHTTPClient().PatchJSON("/webhooks/"+webhookId, jsonapi.NewDocument(jsonapi.Resource{
+		Type:       "webhooks",
+		ID:         webhookId,
+		Attributes: config.attributes(),
+	}))
+	if err != nil {
+		return Webhook{}, err
+	}
+	return decodeWebhook(response)
+}
+
+// Delete deletes a webhook by ID
+func (w *WebhooksAPI) Delete(webhookId string) error {
+	if webhookId == "" {
+		return fmt.Errorf("webhook id is required")
+	}
+	if err := w.client.EnsureAuth(); err != nil {
+		return err
+	}
+	_, err := w.client.GetHTTPClient().Delete("/webhooks/"+webhookId, nil)
+	return err
+}
+
+// Ping sends a test event to a webhook, delivered as a WebhookPayloadPing
+// event, and returns the ID of the ping
+func (w *WebhooksAPI) Ping(webhookId string) (string, error) {
+	if webhookId == "" {
+		return "", fmt.Errorf("webhook id is required")
+	}
+	if err := w.client.EnsureAuth(); err != nil {
+		return "", err
+	}
+	response, err := w.client.GetHTTPClient().PostJSON("/webhookPings", jsonapi.NewDocument(jsonapi.Resource{
+		Type: "webhookPings",
+		Relationships: map[string]jsonapi.Relationship{
+			"webhook": jsonapi.ToOne("webhooks", webhookId),
+		},
+	}))
+	if err != nil {
+		return "", err
+	}
+	resource, err := responseResource(response)
+	if err != nil {
+		return "", err
+	}
+	return resourceID(resource), nil
+}
+
+// decodeWebhook converts a webhooks response to a Webhook
+func decodeWebhook(response map[string]interface{}) (Webhook, error) {
+	resource, err := responseResource(response)
+	if err != nil {
+		return Webhook{}, err
+	}
+	var webhook Webhook
+	if err := jsonapi.Unmarshal(resource, &webhook); err != nil {
+		return Webhook{}, err
+	}
+	return webhook, nil
+}
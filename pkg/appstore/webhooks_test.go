@@ -0,0 +1,56 @@
+package appstore_test
+
+import (
+	"fmt"
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+func TestWebhooksList(t *testing.T) {
+	// More webhooks than fit on a page of 200
+	var webhookIDs []string
+	var resources []appstoretest.Resource
+	for i := 1; i <= 250; i++ {
+		id := fmt.Sprintf("WEBHOOK%04d", i)
+		webhookIDs = append(webhookIDs, id)
+		resources = append(resources, appstoretest.Resource{
+			Type: "webhooks",
+			ID:   id,
+			Attributes: map[string]interface{}{
+				"name":       "Build notifications",
+				"url":        "https://example.com/webhook",
+				"enabled":    true,
+				"eventTypes": []string{"BUILD_UPLOAD_STATE_UPDATED"},
+			},
+		})
+	}
+	resources = append(resources, appstoretest.Resource{
+		Type:          "apps",
+		ID:            "APP0001",
+		Relationships: map[string]jsonapi.Relationship{"webhooks": jsonapi.ToMany("webhooks", webhookIDs)},
+	})
+	server := appstoretest.NewServer(resources...)
+	defer server.Close()
+
+	webhooks, err := appstore.NewWebhooksAPI(newClient(t, server, nil)).List("APP0001")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(webhooks) != 250 {
+		t.Fatalf("got %d webhooks, want 250", len(webhooks))
+	}
+	last := webhooks[249]
+	if last.ID != "WEBHOOK0250" || !last.Enabled || len(last.EventTypes) != 1 || last.EventTypes[0] != appstore.WebhookEventBuildUploadStateUpdated {
+		t.Errorf("got webhook %+v", last)
+	}
+	if requests := server.Requests(); len(requests) != 2 {
+		t.Errorf("got %d requests, want 2 pages", len(requests))
+	}
+
+	if _, err := appstore.NewWebhooksAPI(newClient(t, server, nil)).List(""); err == nil {
+		t.Error("listed webhooks without an app id")
+	}
+}
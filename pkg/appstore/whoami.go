@@ -0,0 +1,77 @@
+package appstore
+
+import (
+	"fmt"
+
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// KeyInfo describes the API key the client is configured with and what it can access
+type KeyInfo struct {
+	Issuer        string `json:"issuer"`
+	KeyID         string `json:"keyId"`
+	CanReadUsers  bool   `json:"canReadUsers"`
+	CanReadApps   bool   `json:"canReadApps"`
+	AccountHolder string `json:"accountHolder,omitempty"`
+}
+
+// WhoAmI probes the API with the current key, which is the fallback key
+// once the primary key has been rejected, and reports its access. The
+// issuer ID identifies the team; the account holder's username is included
+// when the key is allowed to read users.
+func (c *Client) WhoAmI() (info KeyInfo, err error) {
+	// report the key that answered, since a probe rejected with 401 switches
+	// to the fallback key
+	defer func() {
+		key := c.keys[c.activeIndex()]
+		info.Issuer, info.KeyID = key.issuer, key.keyID
+	}()
+
+	if err := c.EnsureAuth(); err != nil {
+		return info, err
+	}
+
+	users, allowed, err := c.probe("/users", map[string]string{
+		"filter[roles]": string(UserRoleAccountHolder),
+		"limit":         "1",
+	})
+	if err != nil {
+		return info, err
+	}
+	info.CanReadUsers = allowed
+	if data, ok := users["data"].([]interface{}); ok && len(data) > 0 {
+		if user, ok := data[0].(map[string]interface{}); ok {
+			if attributes, ok := user["attributes"].(map[string]interface{}); ok {
+				if username, ok := attributes["username"].(string); ok {
+					info.AccountHolder = username
+				}
+			}
+		}
+	}
+
+	_, allowed, err = c.probe("/apps", map[string]string{"limit": "1"})
+	if err != nil {
+		return info, err
+	}
+	info.CanReadApps = allowed
+
+	return info, nil
+}
+
+// probe performs a GET request and reports whether the key may access the path.
+// A 403 response means access is denied; any other failure is returned as an error.
+func (c *Client) probe(path string, params map[string]string) (map[string]interface{}, bool, error) {
+	response, err := c.httpClient.Get(path, params)
+	if err == nil {
+		return response, true, nil
+	}
+
+	switch {
+	case httpclient.IsForbidden(err):
+		return response, false, nil
+	case httpclient.IsUnauthorized(err):
+		return response, false, fmt.Errorf("credentials were rejected: %w", err)
+	default:
+		return response, false, fmt.Errorf("failed to probe %s: %w", path, err)
+	}
+}
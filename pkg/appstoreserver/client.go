@@ -0,0 +1,156 @@
+package appstoreserver
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"appstore-connect-api/pkg/httpclient"
+	"appstore-connect-api/pkg/jwtutil"
+)
+
+const (
+	productionBaseURI = "https://api.storekit.itunes.apple.com/inApps"
+	sandboxBaseURI    = "https://api.storekit-sandbox.itunes.apple.com/inApps"
+	defaultAPIVersion = "v1"
+)
+
+// Environment represents the App Store Server API environment
+type Environment string
+
+// Environments
+const (
+	EnvironmentProduction Environment = "Production"
+	EnvironmentSandbox    Environment = "Sandbox"
+)
+
+// Config holds the client configuration
+type Config struct {
+	Issuer      string
+	KeyID       string
+	Secret      string // Can be a file path or the private key content
+	BundleID    string
+	Environment Environment
+	// HTTPClient optionally replaces the HTTP client that sends requests
+	HTTPClient *http.Client
+	// Transport optionally sends requests through a proxy or with custom TLS
+	// settings, ignored when HTTPClient is set
+	Transport *httpclient.TransportConfig
+	// UserAgent is the User-Agent header of every request
+	UserAgent string
+	// Headers are default headers of every request
+	Headers map[string]string
+}
+
+// Client represents the App Store Server API client
+type Client struct {
+	config     Config
+	httpClient *httpclient.Client
+	tokens     *jwtutil.TokenCache
+}
+
+// NewClient creates a new App Store Server API client
+func NewClient(config Config) (*Client, error) {
+	// Validate required fields
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("issuer is required")
+	}
+	if config.KeyID == "" {
+		return nil, fmt.Errorf("key id is required")
+	}
+	if config.Secret == "" {
+		return nil, fmt.Errorf("secret is required")
+	}
+	if config.BundleID == "" {
+		return nil, fmt.Errorf("bundle id is required")
+	}
+
+	// Select the environment, production by default
+	baseURL := productionBaseURI
+	switch config.Environment {
+	case "", EnvironmentProduction:
+		config.Environment = EnvironmentProduction
+	case EnvironmentSandbox:
+		baseURL = sandboxBaseURI
+	default:
+		return nil, fmt.Errorf("unknown environment: %s", config.Environment)
+	}
+
+	// Read secret from file if it's a file path
+	privateKey := config.Secret
+	if _, err := os.Stat(config.Secret); err == nil {
+		content, err := os.ReadFile(config.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret file: %w", err)
+		}
+		privateKey = string(content)
+	}
+
+	// Create JWT generator, server API tokens carry the bundle ID
+	jwtGenerator, err := jwtutil.NewGenerator(jwtutil.JWTConfig{
+		Issuer:     config.Issuer,
+		KeyID:      config.KeyID,
+		PrivateKey: privateKey,
+		BundleID:   config.BundleID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWT generator: %w", err)
+	}
+
+	// Create HTTP client
+	httpClient := httpclient.NewClient(httpclient.Config{
+		BaseURL:    baseURL,
+		APIVersion: defaultAPIVersion,
+		HTTPClient: config.HTTPClient,
+		Transport:  config.Transport,
+		UserAgent:  config.UserAgent,
+		Headers:    config.Headers,
+	})
+
+	client := &Client{
+		config:     config,
+		httpClient: httpClient,
+		tokens:     jwtutil.NewTokenCache(jwtGenerator),
+	}
+	httpClient.SetUnauthorizedHandler(client.reauthorize)
+	return client, nil
+}
+
+// Environment returns the environment the client targets
+func (c *Client) Environment() Environment {
+	return c.config.Environment
+}
+
+// GetToken returns a JWT token, cached until shortly before it expires
+func (c *Client) GetToken() (string, error) {
+	token, err := c.tokens.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return token, nil
+}
+
+// EnsureAuth ensures the client has an auth header with a JWT token that is
+// not about to expire
+func (c *Client) EnsureAuth() error {
+	token, err := c.GetToken()
+	if err != nil {
+		return err
+	}
+	if c.httpClient.GetHeaders()["Authorization"] != "Bearer "+token {
+		c.httpClient.SetToken(token)
+	}
+	return nil
+}
+
+// reauthorize returns a newly signed token to send a request rejected with
+// 401 again with, in case the rejected token expired early
+func (c *Client) reauthorize(rejected string) (string, bool) {
+	token, ok, err := c.tokens.Renew(rejected)
+	return token, ok && err == nil
+}
+
+// GetHTTPClient returns the underlying HTTP client
+func (c *Client) GetHTTPClient() *httpclient.Client {
+	return c.httpClient
+}
@@ -0,0 +1,33 @@
+package appstoreserver
+
+import "fmt"
+
+// ConsumptionRequest holds the consumption information of a refund request.
+// The integer fields take the values defined by the App Store Server API,
+// where 0 means undeclared.
+type ConsumptionRequest struct {
+	CustomerConsented        bool   `json:"customerConsented"`
+	ConsumptionStatus        int    `json:"consumptionStatus"`
+	Platform                 int    `json:"platform"`
+	SampleContentProvided    bool   `json:"sampleContentProvided"`
+	DeliveryStatus           int    `json:"deliveryStatus"`
+	AppAccountToken          string `json:"appAccountToken"`
+	AccountTenure            int    `json:"accountTenure"`
+	PlayTime                 int    `json:"playTime"`
+	LifetimeDollarsRefunded  int    `json:"lifetimeDollarsRefunded"`
+	LifetimeDollarsPurchased int    `json:"lifetimeDollarsPurchased"`
+	UserStatus               int    `json:"userStatus"`
+	RefundPreference         int    `json:"refundPreference"`
+}
+
+// SendConsumptionInformation answers a CONSUMPTION_REQUEST notification
+// for a transaction. The customer must have consented to sharing the data.
+func (c *Client) SendConsumptionInformation(transactionId string, request ConsumptionRequest) (map[string]interface{}, error) {
+	if !request.CustomerConsented {
+		return nil, fmt.Errorf("customer consent is required")
+	}
+	if err := c.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.GetHTTPClient().PutJSON("/transactions/consumption/"+transactionId, request)
+}
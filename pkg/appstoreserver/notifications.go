@@ -0,0 +1,174 @@
+package appstoreserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// SendAttemptResultSuccess is the result of a notification delivered to the server
+const SendAttemptResultSuccess = "SUCCESS"
+
+// SendAttempt is one attempt of the App Store to deliver a notification
+type SendAttempt struct {
+	AttemptDate       int64  `json:"attemptDate"`
+	SendAttemptResult string `json:"sendAttemptResult"`
+}
+
+// NotificationDelivery is a notification payload with its delivery attempts
+type NotificationDelivery struct {
+	SignedPayload string        `json:"signedPayload"`
+	SendAttempts  []SendAttempt `json:"sendAttempts"`
+}
+
+// Delivered reports whether any attempt delivered the notification
+func (d NotificationDelivery) Delivered() bool {
+	for _, attempt := range d.SendAttempts {
+		if attempt.SendAttemptResult == SendAttemptResultSuccess {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationHistoryRequest filters the notification history, StartDate
+// and EndDate are required and at most 180 days in the past
+type NotificationHistoryRequest struct {
+	StartDate           time.Time
+	EndDate             time.Time
+	NotificationType    string
+	NotificationSubtype string
+	TransactionID       string
+	OnlyFailures        bool
+}
+
+// body builds the request body, with dates in milliseconds since the epoch
+func (r NotificationHistoryRequest) body() map[string]interface{} {
+	body := map[string]interface{}{
+		"startDate": r.StartDate.UnixMilli(),
+		"endDate":   r.EndDate.UnixMilli(),
+	}
+	if r.NotificationType != "" {
+		body["notificationType"] = r.NotificationType
+	}
+	if r.NotificationSubtype != "" {
+		body["notificationSubtype"] = r.NotificationSubtype
+	}
+	if r.TransactionID != "" {
+		body["transactionId"] = r.TransactionID
+	}
+	if r.OnlyFailures {
+		body["onlyFailures"] = true
+	}
+	return body
+}
+
+// RequestTestNotification asks the App Store to send a TEST notification to
+// the server notification URL and returns the token identifying it
+func (c *Client) RequestTestNotification() (string, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return "", err
+	}
+	response, err := c.GetHTTPClient().PostJSON("/notifications/test", map[string]interface{}{})
+	if err != nil {
+		return "", err
+	}
+	token, _ := response["testNotificationToken"].(string)
+	if token == "" {
+		return "", fmt.Errorf("response has no test notification token")
+	}
+	return token, nil
+}
+
+// GetTestNotificationStatus retrieves the delivery attempts of a test notification
+func (c *Client) GetTestNotificationStatus(testNotificationToken string) (NotificationDelivery, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return NotificationDelivery{}, err
+	}
+	response, err := c.GetHTTPClient().Get("/notifications/test/"+testNotificationToken, nil)
+	if err != nil {
+		return NotificationDelivery{}, err
+	}
+	var delivery NotificationDelivery
+	err = decodeResponse(response, &delivery)
+	return delivery, err
+}
+
+// WaitForTestNotification polls the status of a test notification every
+// interval until it is delivered, failing once timeout elapses
+func (c *Client) WaitForTestNotification(testNotificationToken string, interval, timeout time.Duration) (NotificationDelivery, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		delivery, err := c.GetTestNotificationStatus(testNotificationToken)
+		if err == nil && delivery.Delivered() {
+			return delivery, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			if err != nil {
+				return delivery, fmt.Errorf("test notification not delivered: %w", err)
+			}
+			if n := len(delivery.SendAttempts); n > 0 {
+				return delivery, fmt.Errorf("test notification not delivered: %s", delivery.SendAttempts[n-1].SendAttemptResult)
+			}
+			return delivery, fmt.Errorf("test notification not delivered within %s", timeout)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// GetNotificationHistory retrieves a page of the notification history.
+// Pass the pagination token of the previous page to continue.
+func (c *Client) GetNotificationHistory(request NotificationHistoryRequest, paginationToken string) (map[string]interface{}, error) {
+	if request.StartDate.IsZero() || request.EndDate.IsZero() {
+		return nil, fmt.Errorf("start date and end date are required")
+	}
+	if err := c.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	path := "/notifications/history"
+	if paginationToken != "" {
+		path += "?paginationToken=" + url.QueryEscape(paginationToken)
+	}
+	return c.GetHTTPClient().PostJSON(path, request.body())
+}
+
+// NotificationHistory retrieves every notification matching request, following pagination
+func (c *Client) NotificationHistory(request NotificationHistoryRequest) ([]NotificationDelivery, error) {
+	var history []NotificationDelivery
+	paginationToken := ""
+	for {
+		response, err := c.GetNotificationHistory(request, paginationToken)
+		if err != nil {
+			return history, err
+		}
+
+		var page struct {
+			NotificationHistory []NotificationDelivery `json:"notificationHistory"`
+			HasMore             bool                   `json:"hasMore"`
+			PaginationToken     string                 `json:"paginationToken"`
+		}
+		if err := decodeResponse(response, &page); err != nil {
+			return history, err
+		}
+		history = append(history, page.NotificationHistory...)
+
+		if !page.HasMore || page.PaginationToken == "" {
+			return history, nil
+		}
+		paginationToken = page.PaginationToken
+	}
+}
+
+// decodeResponse decodes a response into out, a pointer to a struct with json tags
+func decodeResponse(response map[string]interface{}, out interface{}) error {
+	content, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+	if err := json.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package appstoreserver
+
+// SubscriptionStatus represents the status of an auto-renewable subscription
+type SubscriptionStatus int
+
+// Subscription statuses
+const (
+	SubscriptionStatusActive             SubscriptionStatus = 1
+	SubscriptionStatusExpired            SubscriptionStatus = 2
+	SubscriptionStatusBillingRetry       SubscriptionStatus = 3
+	SubscriptionStatusBillingGracePeriod SubscriptionStatus = 4
+	SubscriptionStatusRevoked            SubscriptionStatus = 5
+)
+
+// GetAllSubscriptionStatuses retrieves the statuses of every subscription
+// of a customer, identified by any of their transaction IDs
+func (c *Client) GetAllSubscriptionStatuses(transactionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.GetHTTPClient().Get("/subscriptions/"+transactionId, params)
+}
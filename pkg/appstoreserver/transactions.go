@@ -0,0 +1,113 @@
+package appstoreserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// historyAPIVersion is the API version serving the transaction history
+const historyAPIVersion = "v2"
+
+// Transaction holds the commonly used fields of a decoded signed transaction
+type Transaction struct {
+	TransactionID         string `json:"transactionId"`
+	OriginalTransactionID string `json:"originalTransactionId"`
+	BundleID              string `json:"bundleId"`
+	ProductID             string `json:"productId"`
+	Type                  string `json:"type"`
+	PurchaseDate          int64  `json:"purchaseDate"`
+	ExpiresDate           int64  `json:"expiresDate"`
+	Quantity              int    `json:"quantity"`
+	AppAccountToken       string `json:"appAccountToken"`
+	InAppOwnershipType    string `json:"inAppOwnershipType"`
+	RevocationDate        int64  `json:"revocationDate"`
+	RevocationReason      *int   `json:"revocationReason"`
+	Environment           string `json:"environment"`
+	Storefront            string `json:"storefront"`
+	Price                 int64  `json:"price"`
+	Currency              string `json:"currency"`
+}
+
+// GetTransactionInfo retrieves the signed information of a transaction
+func (c *Client) GetTransactionInfo(transactionId string) (map[string]interface{}, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.GetHTTPClient().Get("/transactions/"+transactionId, nil)
+}
+
+// GetTransactionHistory retrieves a page of the transaction history of a
+// customer, identified by any of their transaction IDs. Pass the revision
+// of the previous page in params to continue.
+func (c *Client) GetTransactionHistory(transactionId string, params map[string]string) (map[string]interface{}, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	return c.GetHTTPClient().WithAPIVersion(historyAPIVersion).Get("/history/"+transactionId, params)
+}
+
+// TransactionHistory retrieves every signed transaction of a customer,
+// following revisions until no more pages remain
+func (c *Client) TransactionHistory(transactionId string, params map[string]string) ([]string, error) {
+	query := make(map[string]string, len(params))
+	for k, v := range params {
+		query[k] = v
+	}
+
+	var signedTransactions []string
+	for {
+		response, err := c.GetTransactionHistory(transactionId, query)
+		if err != nil {
+			return signedTransactions, err
+		}
+		signedTransactions = append(signedTransactions, stringList(response["signedTransactions"])...)
+
+		hasMore, _ := response["hasMore"].(bool)
+		revision, _ := response["revision"].(string)
+		if !hasMore || revision == "" {
+			return signedTransactions, nil
+		}
+		query["revision"] = revision
+	}
+}
+
+// DecodeUnverifiedPayload decodes the payload of a JWS signed by the App
+// Store, such as a signed transaction, into out. The signature is NOT
+// verified, so only use it on data received directly from the App Store
+// Server API over TLS.
+func DecodeUnverifiedPayload(signed string, out interface{}) error {
+	parts := strings.Split(signed, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid JWS: expected 3 parts, got %d", len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("failed to decode JWS payload: %w", err)
+	}
+	if err := json.Unmarshal(payload, out); err != nil {
+		return fmt.Errorf("failed to parse JWS payload: %w", err)
+	}
+	return nil
+}
+
+// DecodeTransaction decodes a signed transaction without verifying it, see DecodeUnverifiedPayload
+func DecodeTransaction(signedTransaction string) (Transaction, error) {
+	var transaction Transaction
+	err := DecodeUnverifiedPayload(signedTransaction, &transaction)
+	return transaction, err
+}
+
+// stringList converts a JSON array of strings
+func stringList(value interface{}) []string {
+	var list []string
+	if items, ok := value.([]interface{}); ok {
+		for _, item := range items {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+	}
+	return list
+}
@@ -0,0 +1,193 @@
+package appstoretest
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// fixtureDate is the date of fixture resources and of resources created by the server
+const fixtureDate = "2026-01-01T00:00:00.000+0000"
+
+// fixtureExpirationDate is the expiration date of fixture certificates and profiles
+const fixtureExpirationDate = "2027-01-01T00:00:00.000+0000"
+
+// uniqueAttributes is the attribute creating a duplicate of which fails with
+// 409, by resource type
+var uniqueAttributes = map[string]string{
+	"devices":   "udid",
+	"bundleIds": "identifier",
+}
+
+// Resource is a JSON:API resource object held by a server
+type Resource struct {
+	Type          string                          `json:"type"`
+	ID            string                          `json:"id"`
+	Attributes    map[string]interface{}          `json:"attributes,omitempty"`
+	Relationships map[string]jsonapi.Relationship `json:"relationships,omitempty"`
+}
+
+// linkages returns the linkage of a relationship of the resource
+func (r Resource) linkages(name string) []jsonapi.Linkage {
+	relationship, ok := r.Relationships[name]
+	if !ok || relationship.Data == nil {
+		return nil
+	}
+	content, err := json.Marshal(relationship.Data)
+	if err != nil {
+		return nil
+	}
+	var linkages []jsonapi.Linkage
+	if json.Unmarshal(content, &linkages) == nil {
+		return linkages
+	}
+	var linkage jsonapi.Linkage
+	if json.Unmarshal(content, &linkage) == nil && linkage.ID != "" {
+		return []jsonapi.Linkage{linkage}
+	}
+	return nil
+}
+
+// Device returns a registered device. The platform is derived from the
+// device class, IPHONE, IPAD, MAC, APPLE_TV, or APPLE_WATCH.
+func Device(id, name, udid, deviceClass string) Resource {
+	platform := "IOS"
+	if deviceClass == "MAC" {
+		platform = "MAC_OS"
+	}
+	return Resource{
+		Type: "devices",
+		ID:   id,
+		Attributes: map[string]interface{}{
+			"name":        name,
+			"udid":        udid,
+			"platform":    platform,
+			"deviceClass": deviceClass,
+			"model":       deviceModels[deviceClass],
+			"status":      "ENABLED",
+			"addedDate":   fixtureDate,
+		},
+	}
+}
+
+// deviceModels is the model of fixture devices by device class
+var deviceModels = map[string]string{
+	"IPHONE":      "iPhone 15",
+	"IPAD":        "iPad Pro",
+	"MAC":         "MacBook Pro",
+	"APPLE_TV":    "Apple TV 4K",
+	"APPLE_WATCH": "Apple Watch Series 9",
+}
+
+// Certificate returns a signing certificate of a type, such as DISTRIBUTION
+// or DEVELOPMENT
+func Certificate(id, name, certificateType string) Resource {
+	return Resource{
+		Type: "certificates",
+		ID:   id,
+		Attributes: map[string]interface{}{
+			"name":               name,
+			"displayName":        name,
+			"certificateType":    certificateType,
+			"platform":           "IOS",
+			"serialNumber":       strings.ToUpper(hex.EncodeToString([]byte(id))),
+			"expirationDate":     fixtureExpirationDate,
+			"certificateContent": base64.StdEncoding.EncodeToString([]byte("certificate " + id)),
+		},
+	}
+}
+
+// BundleID returns a registered bundle ID
+func BundleID(id, name, identifier, platform string) Resource {
+	return Resource{
+		Type: "bundleIds",
+		ID:   id,
+		Attributes: map[string]interface{}{
+			"name":       name,
+			"identifier": identifier,
+			"platform":   platform,
+			"seedId":     "TEAMID1234",
+		},
+	}
+}
+
+// Profile returns an active provisioning profile of a type, such as
+// IOS_APP_STORE or IOS_APP_DEVELOPMENT, for a bundle ID, certificates, and
+// devices
+func Profile(id, name, profileType, bundleIdID string, certificateIDs, deviceIDs []string) Resource {
+	return Resource{
+		Type: "profiles",
+		ID:   id,
+		Attributes: map[string]interface{}{
+			"name":           name,
+			"platform":       "IOS",
+			"profileType":    profileType,
+			"profileState":   "ACTIVE",
+			"uuid":           fmt.Sprintf("00000000-0000-0000-0000-%012s", id),
+			"createdDate":    fixtureDate,
+			"expirationDate": fixtureExpirationDate,
+			"profileContent": base64.StdEncoding.EncodeToString([]byte("profile " + id)),
+		},
+		Relationships: map[string]jsonapi.Relationship{
+			"bundleId":     jsonapi.ToOne("bundleIds", bundleIdID),
+			"certificates": jsonapi.ToMany("certificates", certificateIDs),
+			"devices":      jsonapi.ToMany("devices", deviceIDs),
+		},
+	}
+}
+
+// Fixtures returns a team with devices, a development and a distribution
+// certificate, a bundle ID, and a development and an App Store profile
+func Fixtures() []Resource {
+	return []Resource{
+		Device("DEVICE0001", "QA iPhone", "00008110-000A1B2C3D4E5F60", "IPHONE"),
+		Device("DEVICE0002", "QA iPad", "00008103-000B2C3D4E5F6071", "IPAD"),
+		Device("DEVICE0003", "Build Mac", "00006001-000C3D4E5F607182", "MAC"),
+		Certificate("CERT0001", "Apple Development: Test Team", "DEVELOPMENT"),
+		Certificate("CERT0002", "Apple Distribution: Test Team", "DISTRIBUTION"),
+		BundleID("BUNDLE0001", "Example App", "com.example.app", "IOS"),
+		Profile("PROFILE0001", "com.example.app Development", "IOS_APP_DEVELOPMENT", "BUNDLE0001",
+			[]string{"CERT0001"}, []string{"DEVICE0001", "DEVICE0002"}),
+		Profile("PROFILE0002", "com.example.app AppStore", "IOS_APP_STORE", "BUNDLE0001",
+			[]string{"CERT0002"}, nil),
+	}
+}
+
+// completeResource sets the attributes App Store Connect sets on resources
+// it creates
+func completeResource(resource *Resource) {
+	if resource.Attributes == nil {
+		resource.Attributes = make(map[string]interface{})
+	}
+	var defaults map[string]interface{}
+	switch resource.Type {
+	case "devices":
+		defaults = Device(resource.ID, "", "", deviceClass(resource.Attributes)).Attributes
+	case "certificates":
+		certificateType, _ := resource.Attributes["certificateType"].(string)
+		defaults = Certificate(resource.ID, "Apple Distribution: Test Team", certificateType).Attributes
+		delete(resource.Attributes, "csrContent")
+	case "bundleIds":
+		defaults = BundleID(resource.ID, "", "", "").Attributes
+	case "profiles":
+		defaults = Profile(resource.ID, "", "", "", nil, nil).Attributes
+	}
+	for name, value := range defaults {
+		if current, ok := resource.Attributes[name]; !ok || current == "" {
+			resource.Attributes[name] = value
+		}
+	}
+}
+
+// deviceClass returns the device class of a device being registered, which
+// App Store Connect derives from the UDID
+func deviceClass(attributes map[string]interface{}) string {
+	if platform, _ := attributes["platform"].(string); platform == "MAC_OS" {
+		return "MAC"
+	}
+	return "IPHONE"
+}
@@ -0,0 +1,455 @@
+// Package appstoretest provides an in-memory App Store Connect server for
+// testing code that uses the appstore package without credentials or
+// network access.
+//
+// The server keeps resources by type and answers the generic JSON:API
+// operations on them: listing with filters and pagination, reading,
+// creating, updating, and deleting resources, and reading relationships.
+// Handle overrides individual endpoints with custom handlers.
+//
+//	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+//	defer server.Close()
+//	client, err := server.NewClient()
+//	devices, err := appstore.NewDeviceAPI(client).List(nil)
+package appstoretest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+// Key identifies the throwaway API key of the clients created by a server
+const (
+	Issuer = "appstoretest-issuer"
+	KeyID  = "APPSTORETEST"
+)
+
+// Request is a request received by a server
+type Request struct {
+	Method string
+	// Path is the request path without the API version, such as /devices
+	Path  string
+	Query url.Values
+	Body  []byte
+}
+
+// Server is an in-memory App Store Connect server
+type Server struct {
+	// URL is the base URL of the server
+	URL string
+
+	server   *httptest.Server
+	key      string
+	mu       sync.Mutex
+	store    map[string][]Resource
+	nextID   int
+	handlers map[string]http.HandlerFunc
+	requests []Request
+}
+
+// NewServer starts a server holding resources, such as Fixtures()
+func NewServer(resources ...Resource) *Server {
+	s := &Server{
+		key:      generateKey(),
+		store:    make(map[string][]Resource),
+		handlers: make(map[string]http.HandlerFunc),
+	}
+	s.Add(resources...)
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Config returns a client configuration with a throwaway key whose requests
+// are sent to the server
+func (s *Server) Config() appstore.Config {
+	target, _ := url.Parse(s.URL)
+	return appstore.Config{
+		Issuer: Issuer,
+		KeyID:  KeyID,
+		Secret: s.key,
+		HTTPClient: &http.Client{
+			Transport: redirectTransport{target: target, transport: s.server.Client().Transport},
+		},
+	}
+}
+
+// NewClient creates a client whose requests are sent to the server
+func (s *Server) NewClient() (*appstore.Client, error) {
+	return appstore.NewClient(s.Config())
+}
+
+// Handle overrides an endpoint, such as "POST /devices", with a handler. The
+// path is matched without the API version.
+func (s *Server) Handle(method, path string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method+" "+path] = handler
+}
+
+// Add stores resources, replacing stored resources of the same type and ID
+func (s *Server) Add(resources ...Resource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, resource := range resources {
+		s.put(resource)
+	}
+}
+
+// Resources returns the stored resources of a type
+func (s *Server) Resources(resourceType string) []Resource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Resource(nil), s.store[resourceType]...)
+}
+
+// Requests returns the requests received by the server, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// put stores a resource, the caller holds the lock
+func (s *Server) put(resource Resource) {
+	resources := s.store[resource.Type]
+	for i := range resources {
+		if resources[i].ID == resource.ID {
+			resources[i] = resource
+			return
+		}
+	}
+	s.store[resource.Type] = append(resources, resource)
+}
+
+// find returns the index of a stored resource, or -1, the caller holds the lock
+func (s *Server) find(resourceType, id string) int {
+	for i, resource := range s.store[resourceType] {
+		if resource.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// serveHTTP records a request and answers it
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "The request body could not be read.")
+		return
+	}
+	path := trimVersion(r.URL.Path)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Query: r.URL.Query(), Body: body})
+	handler := s.handlers[r.Method+" "+path]
+	s.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "NOT_AUTHORIZED", "Provide a properly configured and signed bearer token.")
+		return
+	}
+	if handler != nil {
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		handler(w, r)
+		return
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.list(w, r, s.store[segments[0]])
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		s.create(w, segments[0], body)
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		s.get(w, segments[0], segments[1])
+	case len(segments) == 2 && r.Method == http.MethodPatch:
+		s.update(w, segments[0], segments[1], body)
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		s.delete(w, segments[0], segments[1])
+	case len(segments) == 3 && r.Method == http.MethodGet:
+		s.related(w, r, segments[0], segments[1], segments[2])
+	case len(segments) == 4 && segments[2] == "relationships" && r.Method == http.MethodGet:
+		s.linkage(w, segments[0], segments[1], segments[3])
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("The path %s is not supported by the test server.", path))
+	}
+}
+
+// list answers a list request with the resources matching its filters, a
+// page at a time
+func (s *Server) list(w http.ResponseWriter, r *http.Request, resources []Resource) {
+	query := r.URL.Query()
+	var matching []Resource
+	for _, resource := range resources {
+		if matches(resource, query) {
+			matching = append(matching, resource)
+		}
+	}
+
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+	offset, _ := strconv.Atoi(query.Get("cursor"))
+	if offset < 0 || offset > len(matching) {
+		offset = len(matching)
+	}
+	end := offset + limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	links := map[string]string{"self": s.URL + r.URL.RequestURI()}
+	if end < len(matching) {
+		next := *r.URL
+		nextQuery := next.Query()
+		nextQuery.Set("cursor", strconv.Itoa(end))
+		next.RawQuery = nextQuery.Encode()
+		links["next"] = s.URL + next.RequestURI()
+	}
+	page := append([]Resource{}, matching[offset:end]...)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":  page,
+		"links": links,
+		"meta": map[string]interface{}{
+			"paging": map[string]int{"total": len(matching), "limit": limit},
+		},
+	})
+}
+
+// get answers a request for a single resource
+func (s *Server) get(w http.ResponseWriter, resourceType, id string) {
+	i := s.find(resourceType, id)
+	if i < 0 {
+		writeNotFound(w, resourceType, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": s.store[resourceType][i]})
+}
+
+// create stores the resource of a create request, answering 409 for
+// duplicates of resources with unique attributes
+func (s *Server) create(w http.ResponseWriter, resourceType string, body []byte) {
+	var document struct {
+		Data Resource `json:"data"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		writeError(w, http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "The request document is not valid JSON:API.")
+		return
+	}
+	resource := document.Data
+	if resource.Type != resourceType {
+		writeError(w, http.StatusConflict, "ENTITY_ERROR.INCORRECT_TYPE", fmt.Sprintf("The resource type %s does not match %s.", resource.Type, resourceType))
+		return
+	}
+	if attribute, ok := uniqueAttributes[resourceType]; ok {
+		for _, stored := range s.store[resourceType] {
+			if stored.Attributes[attribute] == resource.Attributes[attribute] {
+				writeError(w, http.StatusConflict, "ENTITY_ERROR.ATTRIBUTE.INVALID.DUPLICATE",
+					fmt.Sprintf("A resource with %s '%v' already exists on this team.", attribute, resource.Attributes[attribute]))
+				return
+			}
+		}
+	}
+
+	s.nextID++
+	resource.ID = fmt.Sprintf("TEST%06d", s.nextID)
+	completeResource(&resource)
+	s.put(resource)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"data": resource})
+}
+
+// update merges the attributes and relationships of an update request into
+// a stored resource
+func (s *Server) update(w http.ResponseWriter, resourceType, id string, body []byte) {
+	i := s.find(resourceType, id)
+	if i < 0 {
+		writeNotFound(w, resourceType, id)
+		return
+	}
+	var document struct {
+		Data Resource `json:"data"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		writeError(w, http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "The request document is not valid JSON:API.")
+		return
+	}
+
+	resource := s.store[resourceType][i]
+	resource.Attributes = mergeAttributes(resource.Attributes, document.Data.Attributes)
+	for name, relationship := range document.Data.Relationships {
+		if resource.Relationships == nil {
+			resource.Relationships = make(map[string]jsonapi.Relationship)
+		}
+		resource.Relationships[name] = relationship
+	}
+	s.store[resourceType][i] = resource
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": resource})
+}
+
+// delete removes a stored resource
+func (s *Server) delete(w http.ResponseWriter, resourceType, id string) {
+	i := s.find(resourceType, id)
+	if i < 0 {
+		writeNotFound(w, resourceType, id)
+		return
+	}
+	resources := s.store[resourceType]
+	s.store[resourceType] = append(resources[:i:i], resources[i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// related answers a request for the resources of a relationship
+func (s *Server) related(w http.ResponseWriter, r *http.Request, resourceType, id, name string) {
+	i := s.find(resourceType, id)
+	if i < 0 {
+		writeNotFound(w, resourceType, id)
+		return
+	}
+	var resources []Resource
+	for _, linkage := range s.store[resourceType][i].linkages(name) {
+		if j := s.find(linkage.Type, linkage.ID); j >= 0 {
+			resources = append(resources, s.store[linkage.Type][j])
+		}
+	}
+	s.list(w, r, resources)
+}
+
+// linkage answers a request for the linkage of a relationship
+func (s *Server) linkage(w http.ResponseWriter, resourceType, id, name string) {
+	i := s.find(resourceType, id)
+	if i < 0 {
+		writeNotFound(w, resourceType, id)
+		return
+	}
+	linkages := s.store[resourceType][i].linkages(name)
+	if linkages == nil {
+		linkages = []jsonapi.Linkage{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": linkages})
+}
+
+// matches reports whether a resource matches the filter parameters of a
+// query. A filter matches any of its comma-separated values.
+func matches(resource Resource, query url.Values) bool {
+	for key, values := range query {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") || len(values) == 0 {
+			continue
+		}
+		name := key[len("filter[") : len(key)-1]
+		value := resource.ID
+		if name != "id" {
+			value = fmt.Sprint(resource.Attributes[name])
+		}
+		found := false
+		for _, accepted := range strings.Split(values[0], ",") {
+			if value == accepted {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeAttributes returns stored attributes updated with changed ones
+func mergeAttributes(stored, changed map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(stored)+len(changed))
+	for k, v := range stored {
+		merged[k] = v
+	}
+	for k, v := range changed {
+		merged[k] = v
+	}
+	return merged
+}
+
+// trimVersion removes the API version from a request path, /v1/devices becomes /devices
+func trimVersion(path string) string {
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+	if len(segments) == 2 && len(segments[0]) > 1 && segments[0][0] == 'v' {
+		if _, err := strconv.Atoi(segments[0][1:]); err == nil {
+			return "/" + segments[1]
+		}
+	}
+	return path
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes a JSON:API error response
+func writeError(w http.ResponseWriter, status int, code, detail string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []map[string]string{{
+			"status": strconv.Itoa(status),
+			"code":   code,
+			"title":  http.StatusText(status),
+			"detail": detail,
+		}},
+	})
+}
+
+// writeNotFound writes the error response for a resource that does not exist
+func writeNotFound(w http.ResponseWriter, resourceType, id string) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("There is no resource of type '%s' with id '%s'", resourceType, id))
+}
+
+// redirectTransport sends requests for any host to the server
+type redirectTransport struct {
+	target    *url.URL
+	transport http.RoundTripper
+}
+
+// RoundTrip sends a request to the server
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	redirected := req.Clone(req.Context())
+	redirected.URL.Scheme = t.target.Scheme
+	redirected.URL.Host = t.target.Host
+	redirected.Host = t.target.Host
+	return t.transport.RoundTrip(redirected)
+}
+
+// generateKey returns a new PEM encoded P-256 private key
+func generateKey() string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(fmt.Sprintf("appstoretest: failed to generate key: %v", err))
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		panic(fmt.Sprintf("appstoretest: failed to encode key: %v", err))
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
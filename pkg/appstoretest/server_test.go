@@ -0,0 +1,124 @@
+package appstoretest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// newClient creates a client of a server
+func newClient(t *testing.T, server *appstoretest.Server) *appstore.Client {
+	t.Helper()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestServerListsFilteredResources(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	client := newClient(t, server)
+
+	devices, err := appstore.NewDeviceAPI(client).List(map[string]string{"filter[platform]": "IOS"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("got %d IOS devices, want 2", len(devices))
+	}
+	for _, device := range devices {
+		if device.Platform != "IOS" {
+			t.Errorf("device %s has platform %s", device.ID, device.Platform)
+		}
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].Method != http.MethodGet || requests[0].Path != "/devices" {
+		t.Fatalf("got requests %+v, want GET /devices", requests)
+	}
+	if got := requests[0].Query.Get("filter[platform]"); got != "IOS" {
+		t.Errorf("got filter %q, want IOS", got)
+	}
+}
+
+func TestServerPaginates(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	client := newClient(t, server)
+
+	response, err := client.GetHTTPClient().Get("/devices", map[string]string{"limit": "2"})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if data := response["data"].([]interface{}); len(data) != 2 {
+		t.Errorf("got %d devices on the first page, want 2", len(data))
+	}
+	links := response["links"].(map[string]interface{})
+	if links["next"] == nil {
+		t.Fatal("first page has no next link")
+	}
+}
+
+func TestServerCreatesUpdatesAndDeletes(t *testing.T) {
+	server := appstoretest.NewServer()
+	defer server.Close()
+	devices := appstore.NewDeviceAPI(newClient(t, server))
+
+	response, err := devices.Register("CI iPhone", appstore.BundleIdPlatformIOS, "00008110-000A1B2C3D4E5F99")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	id := response["data"].(map[string]interface{})["id"].(string)
+
+	_, err = devices.Register("CI iPhone", appstore.BundleIdPlatformIOS, "00008110-000A1B2C3D4E5F99")
+	if !httpclient.IsAlreadyExists(err) {
+		t.Errorf("registering a duplicate UDID: got %v, want 409", err)
+	}
+
+	if _, err := devices.Update(id, "Renamed iPhone", ""); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	stored := server.Resources("devices")
+	if len(stored) != 1 || stored[0].Attributes["name"] != "Renamed iPhone" {
+		t.Fatalf("got stored devices %+v, want one renamed device", stored)
+	}
+	if stored[0].Attributes["status"] != "ENABLED" {
+		t.Errorf("update without status changed it to %v", stored[0].Attributes["status"])
+	}
+
+	if _, err := appstore.NewProfilesAPI(newClient(t, server)).Delete("MISSING"); !httpclient.IsNotFound(err) {
+		t.Errorf("deleting a missing profile: got %v, want 404", err)
+	}
+}
+
+func TestServerHandleOverridesEndpoint(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	server.Handle(http.MethodGet, "/devices", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := appstore.NewDeviceAPI(newClient(t, server)).List(nil)
+	if !httpclient.IsServerError(err) {
+		t.Fatalf("got %v, want the 503 of the handler", err)
+	}
+}
+
+func TestServerRejectsUnauthenticatedRequests(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/v1/devices")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("got status %d, want 401", resp.StatusCode)
+	}
+}
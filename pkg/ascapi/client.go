@@ -0,0 +1,53 @@
+package ascapi
+
+import (
+	"fmt"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// Client calls the generated App Store Connect endpoints
+type Client struct {
+	client *appstore.Client
+}
+
+// NewClient creates a client for the generated endpoints that shares the
+// credentials of an App Store Connect client
+func NewClient(client *appstore.Client) *Client {
+	return &Client{client: client}
+}
+
+// do performs a JSON request against a versioned path and decodes the
+// response into a T
+func do[T any](c *Client, method, version, path string, params map[string]string, body interface{}) (*T, error) {
+	if err := c.client.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	httpClient := c.client.GetHTTPClient().WithAPIVersion(version)
+
+	var out T
+	var err error
+	switch method {
+	case "GET":
+		out, err = httpclient.Get[T](httpClient, path, params)
+	case "POST":
+		out, err = httpclient.Post[T](httpClient, path, body)
+	case "PUT":
+		out, err = httpclient.Put[T](httpClient, path, body)
+	case "PATCH":
+		out, err = httpclient.Patch[T](httpClient, path, body)
+	case "DELETE":
+		if body != nil {
+			out, err = httpclient.DeleteJSON[T](httpClient, path, body)
+		} else {
+			out, err = httpclient.Delete[T](httpClient, path, params)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported method %s", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
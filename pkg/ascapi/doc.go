@@ -0,0 +1,14 @@
+// Package ascapi provides typed models and endpoint stubs for the full App
+// Store Connect API, generated from Apple's OpenAPI specification by
+// cmd/ascgen. The generated files are committed along with the copy of the
+// specification in openapi.oas.json they were generated from, whose version
+// SpecVersion records. go generate regenerates them from that copy; to move
+// to a newer specification, download it with
+//
+//	go run appstore-connect-api/cmd/ascgen -save openapi.oas.json -out .
+//
+// in this directory. The hand-written APIs in package appstore remain the
+// higher-level entry point and share their client with this package.
+package ascapi
+
+//go:generate go run appstore-connect-api/cmd/ascgen -spec openapi.oas.json -out . -package ascapi
@@ -0,0 +1,444 @@
+// Package assetupload implements the App Store Connect asset upload protocol
+// shared by screenshots, app previews, app event images, review attachments,
+// routing app coverage files, and Game Center images:
+//
+//  1. reserve the asset by creating it with its file name and size, which
+//     returns upload operations, one per part of the file
+//  2. upload every part to its presigned URL
+//  3. commit the asset with uploaded set and the MD5 checksum of the file
+//  4. poll the asset delivery state until Apple has processed the file
+//
+// Parts are uploaded in parallel and retried individually. A Reservation
+// records the parts already uploaded and can be stored to resume an upload
+// after the process restarts.
+package assetupload
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"appstore-connect-api/pkg/httpclient"
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+const (
+	defaultConcurrency  = 4
+	defaultRetries      = 3
+	defaultRetryDelay   = time.Second
+	defaultPollInterval = 2 * time.Second
+)
+
+// Asset delivery states
+const (
+	StateAwaitingUpload = "AWAITING_UPLOAD"
+	StateUploadComplete = "UPLOAD_COMPLETE"
+	StateComplete       = "COMPLETE"
+	StateFailed         = "FAILED"
+)
+
+// API is the part of the App Store Connect client the uploader uses,
+// implemented by *appstore.Client
+type API interface {
+	EnsureAuth() error
+	GetHTTPClient() *httpclient.Client
+}
+
+// Asset describes a file to upload
+type Asset struct {
+	// ResourceType is the asset resource type, such as appScreenshots
+	ResourceType string
+	Path         string
+	// Attributes are sent with the reservation in addition to the file name and size
+	Attributes map[string]interface{}
+	// Relationships link the asset to its parent, such as an appScreenshotSet
+	Relationships map[string]jsonapi.Relationship
+	// OmitChecksum commits without sourceFileChecksum, for resource types
+	// that do not accept it such as Game Center images
+	OmitChecksum bool
+}
+
+// Operation is one part of an asset upload
+type Operation struct {
+	Method         string `json:"method"`
+	URL            string `json:"url"`
+	Length         int64  `json:"length"`
+	Offset         int64  `json:"offset"`
+	RequestHeaders []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"requestHeaders"`
+}
+
+// Reservation is a reserved asset and the progress of its upload. It can be
+// encoded as JSON and passed to Resume after a restart.
+type Reservation struct {
+	ResourceType string      `json:"resourceType"`
+	ID           string      `json:"id"`
+	Path         string      `json:"path"`
+	Operations   []Operation `json:"operations"`
+	// Uploaded marks the operations whose parts have been uploaded
+	Uploaded     []bool `json:"uploaded"`
+	OmitChecksum bool   `json:"omitChecksum,omitempty"`
+}
+
+// Uploader uploads assets
+type Uploader struct {
+	// Concurrency is the number of parts uploaded in parallel, 4 when zero
+	Concurrency int
+	// Retries is the number of retries of a failed part, 3 when zero
+	Retries int
+	// RetryDelay is the delay before the first retry of a part, doubled for
+	// every further retry, one second when zero
+	RetryDelay time.Duration
+	// PollInterval is the delay between delivery state checks, two seconds when zero
+	PollInterval time.Duration
+	// Progress is called after every uploaded part with the uploaded and total bytes
+	Progress func(uploaded, total int64)
+
+	api API
+	mu  sync.Mutex
+}
+
+// New creates a new asset uploader
+func New(api API) *Uploader {
+	return &Uploader{api: api}
+}
+
+// Upload reserves, uploads, and commits an asset, then waits until its
+// delivery state is complete. It returns the asset resource object.
+func (u *Uploader) Upload(ctx context.Context, asset Asset) (map[string]interface{}, error) {
+	reservation, err := u.Reserve(asset)
+	if err != nil {
+		return nil, err
+	}
+	return u.Resume(ctx, reservation)
+}
+
+// Resume uploads the remaining parts of a reservation, commits the asset, and
+// waits until its delivery state is complete
+func (u *Uploader) Resume(ctx context.Context, reservation *Reservation) (map[string]interface{}, error) {
+	if err := u.UploadParts(ctx, reservation); err != nil {
+		return nil, err
+	}
+	if _, err := u.Commit(reservation); err != nil {
+		return nil, err
+	}
+	return u.WaitForDelivery(ctx, reservation.ResourceType, reservation.ID)
+}
+
+// Reserve creates an asset with the name and size of its file and returns
+// the reservation with its upload operations
+func (u *Uploader) Reserve(asset Asset) (*Reservation, error) {
+	if asset.ResourceType == "" {
+		return nil, fmt.Errorf("resource type is required")
+	}
+	info, err := os.Stat(asset.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read asset: %w", err)
+	}
+	if err := u.api.EnsureAuth(); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]interface{}{
+		"fileName": filepath.Base(asset.Path),
+		"fileSize": info.Size(),
+	}
+	for k, v := range asset.Attributes {
+		attributes[k] = v
+	}
+	response, err := u.api.GetHTTPClient().PostJSON("/"+asset.ResourceType, jsonapi.NewDocument(jsonapi.Resource{
+		Type:          asset.ResourceType,
+		Attributes:    attributes,
+		Relationships: asset.Relationships,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve asset: %w", err)
+	}
+	resource, err := jsonapi.Data(response)
+	if err != nil {
+		return nil, err
+	}
+
+	var reserved struct {
+		UploadOperations []Operation `json:"uploadOperations"`
+	}
+	if err := jsonapi.DecodeAttributes(resource, &reserved); err != nil {
+		return nil, err
+	}
+	if err := checkCoverage(reserved.UploadOperations, info.Size()); err != nil {
+		return nil, err
+	}
+
+	return &Reservation{
+		ResourceType: asset.ResourceType,
+		ID:           jsonapi.ResourceID(resource),
+		Path:         asset.Path,
+		Operations:   reserved.UploadOperations,
+		Uploaded:     make([]bool, len(reserved.UploadOperations)),
+		OmitChecksum: asset.OmitChecksum,
+	}, nil
+}
+
+// UploadParts uploads the parts of a reservation that have not been uploaded
+// yet in parallel, retrying each failed part. Parts uploaded before a failure
+// stay marked in the reservation.
+func (u *Uploader) UploadParts(ctx context.Context, reservation *Reservation) error {
+	file, err := os.Open(reservation.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open asset: %w", err)
+	}
+	defer file.Close()
+
+	if len(reservation.Uploaded) != len(reservation.Operations) {
+		reservation.Uploaded = make([]bool, len(reservation.Operations))
+	}
+	var total, uploaded int64
+	for i, operation := range reservation.Operations {
+		total += operation.Length
+		if reservation.Uploaded[i] {
+			uploaded += operation.Length
+		}
+	}
+
+	concurrency := u.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var firstErr error
+	slots := make(chan struct{}, concurrency)
+	for i := range reservation.Operations {
+		if reservation.Uploaded[i] {
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			operation := reservation.Operations[i]
+			err := u.uploadPart(ctx, file, operation)
+
+			u.mu.Lock()
+			defer u.mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to upload part at offset %d: %w", operation.Offset, err)
+				}
+				cancel()
+				return
+			}
+			reservation.Uploaded[i] = true
+			uploaded += operation.Length
+			if u.Progress != nil {
+				u.Progress(uploaded, total)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// uploadPart uploads one part, retrying with exponential backoff
+func (u *Uploader) uploadPart(ctx context.Context, file io.ReaderAt, operation Operation) error {
+	part := make([]byte, operation.Length)
+	if _, err := file.ReadAt(part, operation.Offset); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to read part: %w", err)
+	}
+	headers := make(map[string]string, len(operation.RequestHeaders))
+	for _, header := range operation.RequestHeaders {
+		headers[header.Name] = header.Value
+	}
+
+	retries := u.Retries
+	if retries <= 0 {
+		retries = defaultRetries
+	}
+	delay := u.RetryDelay
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	for attempt := 0; ; attempt++ {
+		err := u.api.GetHTTPClient().WithContext(ctx).UploadURL(operation.Method, operation.URL, headers, part)
+		if err == nil || attempt == retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// Commit marks the asset of a reservation as uploaded, with the MD5 checksum
+// of its file unless the reservation omits it. It fails when Apple rejects
+// the committed file, such as for a checksum mismatch.
+func (u *Uploader) Commit(reservation *Reservation) (map[string]interface{}, error) {
+	for i, uploaded := range reservation.Uploaded {
+		if !uploaded {
+			return nil, fmt.Errorf("part at offset %d has not been uploaded", reservation.Operations[i].Offset)
+		}
+	}
+
+	attributes := map[string]interface{}{"uploaded": true}
+	if !reservation.OmitChecksum {
+		checksum, err := fileMD5(reservation.Path)
+		if err != nil {
+			return nil, err
+		}
+		attributes["sourceFileChecksum"] = checksum
+	}
+	if err := u.api.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	path := "/" + reservation.ResourceType + "/" + reservation.ID
+	response, err := u.api.GetHTTPClient().PatchJSON(path, jsonapi.NewDocument(jsonapi.Resource{
+		Type:       reservation.ResourceType,
+		ID:         reservation.ID,
+		Attributes: attributes,
+	}))
+	if err != nil {
+		return response, fmt.Errorf("failed to commit asset: %w", err)
+	}
+
+	// Apple verifies the checksum and parts on commit and may fail the
+	// delivery right away
+	if resource, ok := response["data"].(map[string]interface{}); ok {
+		if _, err := deliveryState(resource); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+// WaitForDelivery polls an asset until its delivery state is complete and
+// returns its resource object. Assets without a delivery state are returned
+// immediately.
+func (u *Uploader) WaitForDelivery(ctx context.Context, resourceType, id string) (map[string]interface{}, error) {
+	interval := u.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	for {
+		if err := u.api.EnsureAuth(); err != nil {
+			return nil, err
+		}
+		response, err := u.api.GetHTTPClient().WithContext(ctx).Get("/"+resourceType+"/"+id, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get asset: %w", err)
+		}
+		resource, err := jsonapi.Data(response)
+		if err != nil {
+			return nil, err
+		}
+
+		state, err := deliveryState(resource)
+		if err != nil {
+			return resource, err
+		}
+		if state == "" || state == StateComplete {
+			return resource, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return resource, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// deliveryState returns the delivery state of an asset resource object, empty
+// for assets without one, and an error with the delivery errors of a failed asset
+func deliveryState(resource map[string]interface{}) (string, error) {
+	var delivery struct {
+		AssetDeliveryState *struct {
+			State  string `json:"state"`
+			Errors []struct {
+				Code        string `json:"code"`
+				Description string `json:"description"`
+			} `json:"errors"`
+		} `json:"assetDeliveryState"`
+	}
+	if err := jsonapi.DecodeAttributes(resource, &delivery); err != nil {
+		return "", err
+	}
+	state := delivery.AssetDeliveryState
+	if state == nil {
+		return "", nil
+	}
+	if state.State == StateFailed {
+		descriptions := make([]string, len(state.Errors))
+		for i, deliveryErr := range state.Errors {
+			descriptions[i] = deliveryErr.Code + ": " + deliveryErr.Description
+		}
+		return state.State, fmt.Errorf("asset delivery failed: %s", strings.Join(descriptions, "; "))
+	}
+	return state.State, nil
+}
+
+// checkCoverage verifies that upload operations split a file of size bytes
+// into parts without gaps or overlaps, so that every byte is uploaded once
+func checkCoverage(operations []Operation, size int64) error {
+	sorted := make([]Operation, len(operations))
+	copy(sorted, operations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Offset < sorted[j].Offset })
+
+	var next int64
+	for _, operation := range sorted {
+		if operation.Offset < 0 || operation.Length < 0 || operation.Offset+operation.Length > size {
+			return fmt.Errorf("upload operation out of range: offset %d, length %d", operation.Offset, operation.Length)
+		}
+		if operation.Offset != next {
+			return fmt.Errorf("upload operations do not cover the file: expected offset %d, got %d", next, operation.Offset)
+		}
+		next += operation.Length
+	}
+	if next != size {
+		return fmt.Errorf("upload operations do not cover the file: %d of %d bytes", next, size)
+	}
+	return nil
+}
+
+// fileMD5 returns the hex MD5 checksum of a file
+func fileMD5(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open asset: %w", err)
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read asset: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
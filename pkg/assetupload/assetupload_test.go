@@ -0,0 +1,316 @@
+package assetupload_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"appstore-connect-api/pkg/assetupload"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// api is an assetupload.API of an HTTP client
+type api struct {
+	client *httpclient.Client
+}
+
+func (a api) EnsureAuth() error                 { return nil }
+func (a api) GetHTTPClient() *httpclient.Client { return a.client }
+
+// assetServer serves the asset endpoints of App Store Connect and the
+// presigned upload URLs, splitting assets into parts of partSize bytes
+type assetServer struct {
+	*httptest.Server
+	partSize int
+
+	mu sync.Mutex
+	// parts are the offset and length of each upload operation, replacing
+	// the split into parts of partSize bytes when set
+	parts [][2]int
+	// failures is the number of failed attempts of each part upload path
+	failures map[string]int
+	// data is the uploaded file, assembled from its parts
+	data    []byte
+	uploads []string
+	commit  map[string]interface{}
+	// states are the delivery states returned by commits and polls, the
+	// last one repeated
+	states []string
+}
+
+func newAssetServer(t *testing.T, partSize int) *assetServer {
+	s := &assetServer{partSize: partSize, failures: make(map[string]int), states: []string{assetupload.StateComplete}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// client returns the uploader API of the server
+func (s *assetServer) client() api {
+	return api{client: httpclient.NewClient(httpclient.Config{BaseURL: s.URL, APIVersion: "v1"})}
+}
+
+func (s *assetServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/appScreenshots":
+		var document struct {
+			Data struct {
+				Attributes struct {
+					FileSize int `json:"fileSize"`
+				} `json:"attributes"`
+			} `json:"data"`
+		}
+		json.Unmarshal(body, &document)
+		s.data = make([]byte, document.Data.Attributes.FileSize)
+		parts := s.parts
+		if parts == nil {
+			for offset := 0; offset < len(s.data); offset += s.partSize {
+				length := s.partSize
+				if offset+length > len(s.data) {
+					length = len(s.data) - offset
+				}
+				parts = append(parts, [2]int{offset, length})
+			}
+		}
+		var operations []map[string]interface{}
+		for _, part := range parts {
+			operations = append(operations, map[string]interface{}{
+				"method": "PUT",
+				"url":    fmt.Sprintf("%s/upload/%d", s.URL, part[0]),
+				"offset": part[0],
+				"length": part[1],
+				"requestHeaders": []map[string]string{
+					{"name": "Content-Type", "value": "image/png"},
+				},
+			})
+		}
+		s.writeAsset(w, http.StatusCreated, map[string]interface{}{"uploadOperations": operations})
+
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/upload/"):
+		if s.failures[r.URL.Path] > 0 {
+			s.failures[r.URL.Path]--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var offset int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/upload/"), "%d", &offset)
+		if r.Header.Get("Content-Type") != "image/png" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		copy(s.data[offset:], body)
+		s.uploads = append(s.uploads, r.URL.Path)
+
+	case r.Method == http.MethodPatch && r.URL.Path == "/v1/appScreenshots/SHOT1":
+		var document struct {
+			Data struct {
+				Attributes map[string]interface{} `json:"attributes"`
+			} `json:"data"`
+		}
+		json.Unmarshal(body, &document)
+		s.commit = document.Data.Attributes
+		s.writeAsset(w, http.StatusOK, s.nextState())
+
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/appScreenshots/SHOT1":
+		s.writeAsset(w, http.StatusOK, s.nextState())
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// nextState returns the attributes with the next delivery state
+func (s *assetServer) nextState() map[string]interface{} {
+	state := s.states[0]
+	if len(s.states) > 1 {
+		s.states = s.states[1:]
+	}
+	delivery := map[string]interface{}{"state": state}
+	if state == assetupload.StateFailed {
+		delivery["errors"] = []map[string]string{{"code": "IMAGE_INCORRECT_DIMENSIONS", "description": "The dimensions are incorrect."}}
+	}
+	return map[string]interface{}{"assetDeliveryState": delivery}
+}
+
+// writeAsset writes an appScreenshots resource response
+func (s *assetServer) writeAsset(w http.ResponseWriter, status int, attributes map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{"type": "appScreenshots", "id": "SHOT1", "attributes": attributes},
+	})
+}
+
+// writeFile writes an asset file of size bytes
+func writeFile(t *testing.T, size int) (string, []byte) {
+	t.Helper()
+	content := make([]byte, size)
+	for i := range content {
+		content[i] = byte(i)
+	}
+	path := filepath.Join(t.TempDir(), "screenshot.png")
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path, content
+}
+
+// newUploader creates an uploader that retries and polls without delay
+func newUploader(server *assetServer) *assetupload.Uploader {
+	uploader := assetupload.New(server.client())
+	uploader.RetryDelay = time.Millisecond
+	uploader.PollInterval = time.Millisecond
+	return uploader
+}
+
+func TestUpload(t *testing.T) {
+	server := newAssetServer(t, 4)
+	server.failures["/upload/4"] = 2
+	server.states = []string{assetupload.StateUploadComplete, assetupload.StateUploadComplete, assetupload.StateComplete}
+	path, content := writeFile(t, 10)
+
+	uploader := newUploader(server)
+	var progress []int64
+	uploader.Progress = func(uploaded, total int64) {
+		if total != 10 {
+			t.Errorf("got total %d, want 10", total)
+		}
+		progress = append(progress, uploaded)
+	}
+
+	resource, err := uploader.Upload(context.Background(), assetupload.Asset{
+		ResourceType: "appScreenshots",
+		Path:         path,
+	})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if id, _ := resource["id"].(string); id != "SHOT1" {
+		t.Errorf("got asset %v, want SHOT1", resource)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if !bytes.Equal(server.data, content) {
+		t.Errorf("got uploaded file %v, want %v", server.data, content)
+	}
+	sum := md5.Sum(content)
+	if server.commit["uploaded"] != true || server.commit["sourceFileChecksum"] != hex.EncodeToString(sum[:]) {
+		t.Errorf("got commit attributes %v, want uploaded with the MD5 checksum", server.commit)
+	}
+	if len(progress) != 3 || progress[2] != 10 {
+		t.Errorf("got progress %v, want three parts up to 10 bytes", progress)
+	}
+}
+
+func TestResume(t *testing.T) {
+	server := newAssetServer(t, 4)
+	server.failures["/upload/8"] = 10
+	path, content := writeFile(t, 10)
+
+	uploader := newUploader(server)
+	uploader.Retries = 1
+	reservation, err := uploader.Reserve(assetupload.Asset{ResourceType: "appScreenshots", Path: path})
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if err := uploader.UploadParts(context.Background(), reservation); err == nil {
+		t.Fatal("UploadParts succeeded with a failing part")
+	}
+	if _, err := uploader.Commit(reservation); err == nil {
+		t.Error("committed an incomplete upload")
+	}
+
+	// The reservation is stored and resumed after a restart
+	stored, err := json.Marshal(reservation)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var resumed assetupload.Reservation
+	if err := json.Unmarshal(stored, &resumed); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	server.mu.Lock()
+	server.failures["/upload/8"] = 0
+	server.uploads = nil
+	server.mu.Unlock()
+
+	if _, err := newUploader(server).Resume(context.Background(), &resumed); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if len(server.uploads) != 1 || server.uploads[0] != "/upload/8" {
+		t.Errorf("resume uploaded %v, want only the failed part", server.uploads)
+	}
+	if !bytes.Equal(server.data, content) {
+		t.Errorf("got uploaded file %v, want %v", server.data, content)
+	}
+}
+
+func TestReserveCoverage(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts [][2]int
+	}{
+		{name: "gap", parts: [][2]int{{0, 4}, {6, 4}}},
+		{name: "overlap", parts: [][2]int{{0, 6}, {4, 6}}},
+		{name: "short", parts: [][2]int{{0, 4}, {4, 4}}},
+		{name: "out of range", parts: [][2]int{{0, 4}, {4, 8}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newAssetServer(t, 4)
+			server.parts = tt.parts
+			path, _ := writeFile(t, 10)
+
+			_, err := newUploader(server).Reserve(assetupload.Asset{ResourceType: "appScreenshots", Path: path})
+			if err == nil {
+				t.Errorf("reserved operations %v of a 10 byte file", tt.parts)
+			}
+		})
+	}
+
+	// Operations in any order that cover the file are accepted
+	server := newAssetServer(t, 4)
+	server.parts = [][2]int{{6, 4}, {0, 6}}
+	path, _ := writeFile(t, 10)
+	if _, err := newUploader(server).Reserve(assetupload.Asset{ResourceType: "appScreenshots", Path: path}); err != nil {
+		t.Errorf("Reserve: %v", err)
+	}
+}
+
+func TestCommitFailed(t *testing.T) {
+	server := newAssetServer(t, 4)
+	server.states = []string{assetupload.StateFailed}
+	path, _ := writeFile(t, 10)
+
+	uploader := newUploader(server)
+	reservation, err := uploader.Reserve(assetupload.Asset{ResourceType: "appScreenshots", Path: path})
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if err := uploader.UploadParts(context.Background(), reservation); err != nil {
+		t.Fatalf("UploadParts: %v", err)
+	}
+	_, err = uploader.Commit(reservation)
+	if err == nil || !strings.Contains(err.Error(), "IMAGE_INCORRECT_DIMENSIONS") {
+		t.Errorf("got %v, want the delivery error of the commit", err)
+	}
+}
@@ -0,0 +1,114 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending a request while the circuit
+// breaker is open because App Store Connect keeps failing
+var ErrCircuitOpen = errors.New("circuit breaker open: App Store Connect is unavailable")
+
+// BreakerConfig configures a circuit breaker, which stops sending requests
+// after consecutive 5xx responses or network errors, such as during an
+// Apple outage, and fails fast with ErrCircuitOpen instead
+type BreakerConfig struct {
+	// Threshold is the number of consecutive failures opening the circuit, 5
+	// when zero. Retried attempts count as failures of their own.
+	Threshold int
+	// Cooldown is how long the circuit stays open, 1 minute when zero. A
+	// single trial request is then sent, closing the circuit if it succeeds
+	// and opening it again otherwise.
+	Cooldown time.Duration
+	// StateChanged is optionally called when the circuit opens or closes
+	StateChanged func(open bool)
+}
+
+// breaker is the state of a circuit breaker, shared by copies of a client
+type breaker struct {
+	config   BreakerConfig
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+	// trial is set while the trial request of an open circuit is in flight
+	trial bool
+}
+
+// SetBreaker enables a circuit breaker, see BreakerConfig
+func (c *Client) SetBreaker(config BreakerConfig) {
+	c.breaker = &breaker{config: config}
+}
+
+// CircuitOpen reports whether the circuit breaker is open
+func (c *Client) CircuitOpen() bool {
+	if c.breaker == nil {
+		return false
+	}
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+	return c.breaker.open
+}
+
+// allow returns ErrCircuitOpen when a request must not be sent
+func (b *breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return nil
+	}
+	if b.trial || time.Since(b.openedAt) < b.cooldown() {
+		return ErrCircuitOpen
+	}
+	b.trial = true
+	return nil
+}
+
+// record updates the breaker with the outcome of a request, which failed
+// with err or was answered with statusCode
+func (b *breaker) record(statusCode int, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	wasOpen := b.open
+	switch {
+	case errors.Is(err, context.Canceled):
+		// Cancelled by the caller, which says nothing about App Store Connect
+	case err != nil || statusCode >= 500:
+		b.failures++
+		if b.trial || b.failures >= b.threshold() {
+			b.open, b.openedAt = true, time.Now()
+		}
+	default:
+		b.open, b.failures = false, 0
+	}
+	b.trial = false
+	open := b.open
+	b.mu.Unlock()
+
+	if open != wasOpen && b.config.StateChanged != nil {
+		b.config.StateChanged(open)
+	}
+}
+
+// threshold returns the number of consecutive failures opening the circuit
+func (b *breaker) threshold() int {
+	if b.config.Threshold <= 0 {
+		return 5
+	}
+	return b.config.Threshold
+}
+
+// cooldown returns how long the circuit stays open
+func (b *breaker) cooldown() time.Duration {
+	if b.config.Cooldown <= 0 {
+		return time.Minute
+	}
+	return b.config.Cooldown
+}
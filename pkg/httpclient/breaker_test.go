@@ -0,0 +1,113 @@
+package httpclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// newBreakerClient returns a client of a server whose /devices endpoint
+// fails the first failures requests, with a circuit breaker opening after
+// two failures, and the recorded state changes of the breaker
+func newBreakerClient(t *testing.T, server *appstoretest.Server, failures int) (*httpclient.Client, *failing, func() []bool) {
+	handler := &failing{status: http.StatusServiceUnavailable, failures: failures}
+	server.Handle(http.MethodGet, "/devices", handler.serveHTTP)
+
+	var mu sync.Mutex
+	var changes []bool
+	client := newClient(t, server, func(config *appstore.Config) {
+		config.Breaker = &httpclient.BreakerConfig{
+			Threshold: 2,
+			Cooldown:  20 * time.Millisecond,
+			StateChanged: func(open bool) {
+				mu.Lock()
+				defer mu.Unlock()
+				changes = append(changes, open)
+			},
+		}
+	})
+	return client, handler, func() []bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]bool(nil), changes...)
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	server := appstoretest.NewServer()
+	defer server.Close()
+	client, handler, changes := newBreakerClient(t, server, 2)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Do(http.MethodGet, "/devices", nil, nil); !httpclient.IsServerError(err) {
+			t.Fatalf("got %v, want a server error", err)
+		}
+	}
+	if !client.CircuitOpen() {
+		t.Fatal("circuit is closed after two failures")
+	}
+
+	// An open circuit fails fast, also for copies of the client
+	if _, err := client.WithContext(context.Background()).Do(http.MethodGet, "/devices", nil, nil); !errors.Is(err, httpclient.ErrCircuitOpen) {
+		t.Errorf("got %v, want ErrCircuitOpen", err)
+	}
+	if got := handler.count(); got != 2 {
+		t.Errorf("got %d requests, want none sent while the circuit is open", got)
+	}
+
+	// After the cooldown, a successful trial request closes the circuit
+	time.Sleep(30 * time.Millisecond)
+	if _, err := client.Do(http.MethodGet, "/devices", nil, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if client.CircuitOpen() {
+		t.Error("circuit is open after a successful trial request")
+	}
+	if got := changes(); len(got) != 2 || !got[0] || got[1] {
+		t.Errorf("got state changes %v, want open then closed", got)
+	}
+}
+
+func TestBreakerFailedTrial(t *testing.T) {
+	server := appstoretest.NewServer()
+	defer server.Close()
+	client, handler, _ := newBreakerClient(t, server, 3)
+
+	for i := 0; i < 2; i++ {
+		client.Do(http.MethodGet, "/devices", nil, nil)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	// A failed trial request opens the circuit again for another cooldown
+	if _, err := client.Do(http.MethodGet, "/devices", nil, nil); !httpclient.IsServerError(err) {
+		t.Fatalf("got %v for the trial request, want a server error", err)
+	}
+	if _, err := client.Do(http.MethodGet, "/devices", nil, nil); !errors.Is(err, httpclient.ErrCircuitOpen) {
+		t.Errorf("got %v after a failed trial request, want ErrCircuitOpen", err)
+	}
+	if got := handler.count(); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+}
+
+func TestBreakerIgnoresClientErrors(t *testing.T) {
+	server := appstoretest.NewServer()
+	defer server.Close()
+	client, _, _ := newBreakerClient(t, server, 0)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Do(http.MethodGet, "/devices/MISSING", nil, nil); !httpclient.IsNotFound(err) {
+			t.Fatalf("got %v, want 404", err)
+		}
+	}
+	if client.CircuitOpen() {
+		t.Error("circuit opened after client errors")
+	}
+}
@@ -0,0 +1,222 @@
+package httpclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores the bodies of successful GET responses by key
+type Cache interface {
+	// Get returns the body stored for key, if it has not expired
+	Get(key string) ([]byte, bool)
+	// Set stores body for key for the duration of ttl
+	Set(key string, body []byte, ttl time.Duration)
+	// Delete removes every entry whose key matches
+	Delete(match func(key string) bool)
+}
+
+// CacheConfig configures caching of GET responses
+type CacheConfig struct {
+	Cache Cache
+	// TTL is the lifetime of cached responses of paths without an entry in TTLs
+	TTL time.Duration
+	// TTLs overrides TTL by path prefix, such as "/devices" or "/apps/123/builds".
+	// The longest matching prefix wins, and a zero duration disables caching.
+	TTLs map[string]time.Duration
+	// Namespace prefixes every key, to share a cache between clients of different accounts
+	Namespace string
+}
+
+// ttl returns the lifetime of responses of a request path
+func (c *CacheConfig) ttl(path string) time.Duration {
+	ttl, longest := c.TTL, -1
+	for prefix, prefixTTL := range c.TTLs {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longest {
+			ttl, longest = prefixTTL, len(prefix)
+		}
+	}
+	return ttl
+}
+
+// SetCache enables caching of GET responses. Writes through the client
+// invalidate cached responses of the resource type they modify.
+func (c *Client) SetCache(config CacheConfig) {
+	c.config.Cache = &config
+}
+
+// InvalidateCache removes the cached responses of the resource type of path,
+// including those of related paths such as /apps/{id}/builds for /builds
+func (c *Client) InvalidateCache(path string) {
+	if c.config.Cache == nil {
+		return
+	}
+	segment := "/" + strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+	c.config.Cache.Cache.Delete(func(key string) bool {
+		return strings.Contains(key, segment+"/") || strings.Contains(key, segment+"?") || strings.HasSuffix(key, segment)
+	})
+}
+
+// ClearCache removes every cached response
+func (c *Client) ClearCache() {
+	if c.config.Cache == nil {
+		return
+	}
+	c.config.Cache.Cache.Delete(func(key string) bool {
+		return true
+	})
+}
+
+// cached returns the cached body of a GET request
+func (c *Client) cached(fullURL string) ([]byte, bool) {
+	if c.config.Cache == nil {
+		return nil, false
+	}
+	return c.config.Cache.Cache.Get(c.config.Cache.Namespace + fullURL)
+}
+
+// store caches the body of a GET request for the TTL of its path
+func (c *Client) store(path, fullURL string, body []byte) {
+	if c.config.Cache == nil {
+		return
+	}
+	if ttl := c.config.Cache.ttl(path); ttl > 0 {
+		c.config.Cache.Cache.Set(c.config.Cache.Namespace+fullURL, body, ttl)
+	}
+}
+
+// cacheEntry is a cached response body with its expiration time
+type cacheEntry struct {
+	Key     string    `json:"key"`
+	Body    []byte    `json:"body"`
+	Expires time.Time `json:"expires"`
+}
+
+// MemoryCache is a Cache holding entries in memory
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// NewMemoryCache creates a new in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]cacheEntry)}
+}
+
+// Get returns the body stored for key, if it has not expired
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.Expires) {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+// Set stores body for key for the duration of ttl
+func (m *MemoryCache) Set(key string, body []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = cacheEntry{Key: key, Body: body, Expires: time.Now().Add(ttl)}
+}
+
+// Delete removes every entry whose key matches
+func (m *MemoryCache) Delete(match func(key string) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.entries {
+		if match(key) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// FileCache is a Cache storing one JSON file per entry in a directory, so
+// entries survive restarts and can be shared between processes
+type FileCache struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileCache creates a cache in dir, creating the directory if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// Get returns the body stored for key, if it has not expired
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, ok := f.read(f.path(key))
+	if !ok || entry.Key != key {
+		return nil, false
+	}
+	if time.Now().After(entry.Expires) {
+		os.Remove(f.path(key))
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+// Set stores body for key for the duration of ttl. Entries that fail to be
+// written are skipped, since the cache is only an optimization.
+func (f *FileCache) Set(key string, body []byte, ttl time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	content, err := json.Marshal(cacheEntry{Key: key, Body: body, Expires: time.Now().Add(ttl)})
+	if err != nil {
+		return
+	}
+	tmp := f.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, content, 0o600); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, f.path(key)); err != nil {
+		os.Remove(tmp)
+	}
+}
+
+// Delete removes every entry whose key matches
+func (f *FileCache) Delete(match func(key string) bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	paths, _ := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	for _, path := range paths {
+		if entry, ok := f.read(path); !ok || match(entry.Key) {
+			os.Remove(path)
+		}
+	}
+}
+
+// path returns the file of an entry
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// read reads an entry file
+func (f *FileCache) read(path string) (cacheEntry, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
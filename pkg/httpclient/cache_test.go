@@ -0,0 +1,99 @@
+package httpclient_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+func TestCache(t *testing.T) {
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	client := newClient(t, server, func(config *appstore.Config) {
+		config.Cache = &httpclient.CacheConfig{
+			Cache: httpclient.NewMemoryCache(),
+			TTL:   time.Minute,
+			TTLs:  map[string]time.Duration{"/profiles": 0},
+		}
+	})
+
+	get := func(path string, params map[string]string) map[string]interface{} {
+		t.Helper()
+		response, err := client.Get(path, params)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		return response
+	}
+
+	get("/devices", nil)
+	get("/devices", nil)
+	if got := countRequests(server, http.MethodGet, "/devices"); got != 1 {
+		t.Errorf("got %d requests for a cached response, want 1", got)
+	}
+	get("/devices", map[string]string{"filter[platform]": "IOS"})
+	if got := countRequests(server, http.MethodGet, "/devices"); got != 2 {
+		t.Errorf("got %d requests after changing the query, want 2", got)
+	}
+
+	get("/profiles", nil)
+	get("/profiles", nil)
+	if got := countRequests(server, http.MethodGet, "/profiles"); got != 2 {
+		t.Errorf("got %d requests for a path with a zero TTL, want 2", got)
+	}
+
+	// A write invalidates the responses of its resource type
+	_, err := client.PostJSON("/devices", map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "devices",
+			"attributes": map[string]string{"name": "New iPhone", "udid": "00008110-000D4E5F60718293", "platform": "IOS"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("POST /devices: %v", err)
+	}
+	devices, _ := get("/devices", nil)["data"].([]interface{})
+	if len(devices) != 4 {
+		t.Errorf("got %d devices after registering one, want 4", len(devices))
+	}
+
+	client.ClearCache()
+	get("/bundleIds", nil)
+	get("/bundleIds", nil)
+	client.ClearCache()
+	get("/bundleIds", nil)
+	if got := countRequests(server, http.MethodGet, "/bundleIds"); got != 2 {
+		t.Errorf("got %d requests around ClearCache, want 2", got)
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := httpclient.NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	cache.Set("acct/v1/devices", []byte(`{"data":[]}`), time.Minute)
+	cache.Set("acct/v1/apps", []byte(`{"data":[]}`), -time.Second)
+
+	// Entries survive a restart
+	reopened, err := httpclient.NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	if body, ok := reopened.Get("acct/v1/devices"); !ok || string(body) != `{"data":[]}` {
+		t.Errorf("got %q, %v, want the stored body", body, ok)
+	}
+	if _, ok := reopened.Get("acct/v1/apps"); ok {
+		t.Error("got an expired entry")
+	}
+
+	reopened.Delete(func(key string) bool { return key == "acct/v1/devices" })
+	if _, ok := cache.Get("acct/v1/devices"); ok {
+		t.Error("got a deleted entry")
+	}
+}
@@ -2,11 +2,15 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -15,78 +19,264 @@ type Config struct {
 	BaseURL    string
 	APIVersion string
 	Token      string
-	Headers    map[string]string
+	// Headers are sent with every request, such as headers routing requests
+	// through an internal gateway
+	Headers map[string]string
+	// UserAgent is the User-Agent header of every request, which identifies
+	// the tool in request logs. Without it, Go's default applies.
+	UserAgent string
+	// Cache optionally caches GET responses
+	Cache *CacheConfig
+	// DefaultLimits sets the limit parameter of Get requests without one by
+	// the last path segment, such as "devices" for both /devices and
+	// /apps/{id}/devices. It is not keyed by the resource type of the
+	// response, and GetRaw and streamed downloads send their params as
+	// given. Without it Apple's default page size of a few resources applies.
+	DefaultLimits map[string]int
+	// Retry optionally retries requests rejected with 429, and GET requests
+	// that failed with a 5xx status, see RetryConfig
+	Retry *RetryConfig
+	// HTTPClient sends the requests, for example with a transport for a
+	// proxy or certificate pinning. Without it, a client with a 30 second
+	// timeout is used. Downloads use a copy without the overall timeout.
+	HTTPClient *http.Client
+	// Transport optionally sets a proxy and TLS settings of the default HTTP
+	// client. It is ignored when HTTPClient is set.
+	Transport *TransportConfig
+	// Observer is optionally notified of every API request, see Observer
+	Observer Observer
+	// Breaker optionally fails requests fast while App Store Connect keeps
+	// failing, see BreakerConfig
+	Breaker *BreakerConfig
+	// DryRun logs write requests, such as POST, PATCH, and DELETE requests
+	// and asset uploads, instead of sending them, and answers them with a
+	// synthetic success, to preview changes. GET requests are sent.
+	DryRun bool
+	// DryRunOutput receives a line per skipped request, os.Stderr when nil
+	DryRunOutput io.Writer
+	// Logger optionally logs retries, rate limit waits, token renewals, and
+	// failed requests, see Logger
+	Logger Logger
 }
 
-// Client represents an HTTP client for App Store Connect API
+// Client represents an HTTP client for App Store Connect API. It is safe for
+// concurrent use: requests may be sent from several goroutines, also while
+// SetToken and SetHeaders renew the token or headers, which apply to the
+// requests started afterwards. The other setters, such as SetRetry and
+// SetObserver, configure the client and must be called before it is shared.
 type Client struct {
-	config     Config
-	httpClient *http.Client
+	config       Config
+	httpClient   *http.Client
+	unauthorized func(rejected string) (string, bool)
+	// ctx is the context of requests sent by the client, see WithContext
+	ctx context.Context
+	// auth is the token and headers, shared by copies of the client
+	auth *authState
+	// breaker is the optional circuit breaker, shared by copies of the client
+	breaker *breaker
+	// rateLimit is the last reported rate limit, shared by copies of the client
+	rateLimit *rateLimitState
+	// downloadClient sends downloads, see newDownloadClient
+	downloadClient *http.Client
+}
+
+// authState is the token and headers sent with every request, shared by
+// copies of a client. headers is replaced rather than modified, so a
+// snapshot stays valid after it is unlocked.
+type authState struct {
+	mu      sync.RWMutex
+	token   string
+	headers map[string]string
 }
 
 // NewClient creates a new HTTP client
 func NewClient(config Config) *Client {
-	return &Client{
-		config: config,
-		httpClient: &http.Client{
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
 			Timeout: 30 * time.Second,
-		},
+		}
+		if config.Transport != nil {
+			httpClient.Transport = config.Transport.transport()
+		}
+	}
+	// Copy headers, so SetHeaders does not modify the caller's map
+	headers := make(map[string]string, len(config.Headers)+1)
+	for k, v := range config.Headers {
+		headers[k] = v
+	}
+	if config.UserAgent != "" {
+		headers["User-Agent"] = config.UserAgent
+	}
+	auth := &authState{token: config.Token, headers: headers}
+	// The token and headers are read from auth only
+	config.Token, config.Headers = "", nil
+
+	client := &Client{
+		config:         config,
+		httpClient:     httpClient,
+		downloadClient: newDownloadClient(httpClient),
+		auth:           auth,
+		rateLimit:      &rateLimitState{},
 	}
+	if config.Breaker != nil {
+		client.SetBreaker(*config.Breaker)
+	}
+	return client
 }
 
-// SetToken sets the JWT token
+// SetToken sets the JWT token of the client and its copies
 func (c *Client) SetToken(token string) {
-	c.config.Token = token
+	c.auth.mu.Lock()
+	c.auth.token = token
+	c.auth.mu.Unlock()
 }
 
-// SetHeaders sets additional headers
+// SetHeaders sets additional headers of the client and its copies
 func (c *Client) SetHeaders(headers map[string]string) {
-	if c.config.Headers == nil {
-		c.config.Headers = make(map[string]string)
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+	merged := make(map[string]string, len(c.auth.headers)+len(headers))
+	for k, v := range c.auth.headers {
+		merged[k] = v
 	}
 	for k, v := range headers {
-		c.config.Headers[k] = v
+		merged[k] = v
 	}
+	c.auth.headers = merged
 }
 
 // GetHeaders returns all headers including authorization
 func (c *Client) GetHeaders() map[string]string {
-	headers := make(map[string]string)
-	for k, v := range c.config.Headers {
+	c.auth.mu.RLock()
+	defer c.auth.mu.RUnlock()
+	headers := make(map[string]string, len(c.auth.headers)+1)
+	for k, v := range c.auth.headers {
 		headers[k] = v
 	}
-	if c.config.Token != "" {
-		headers["Authorization"] = "Bearer " + c.config.Token
+	if c.auth.token != "" {
+		headers["Authorization"] = "Bearer " + c.auth.token
 	}
 	return headers
 }
 
+// sentToken returns the token a response's request was sent with, which a
+// concurrent request may already have renewed, or the current token
+func (c *Client) sentToken(resp *http.Response) string {
+	if resp.Request != nil {
+		if token, ok := strings.CutPrefix(resp.Request.Header.Get("Authorization"), "Bearer "); ok {
+			return token
+		}
+	}
+	c.auth.mu.RLock()
+	defer c.auth.mu.RUnlock()
+	return c.auth.token
+}
+
+// WithAPIVersion returns a copy of the client that targets a different API
+// version, sharing the underlying HTTP client and credentials
+func (c *Client) WithAPIVersion(version string) *Client {
+	clone := *c
+	clone.config.APIVersion = version
+	return &clone
+}
+
+// WithContext returns a copy of the client whose requests are sent with
+// ctx, so they are cancelled when it is done or exceeds its deadline. The
+// copy shares the underlying HTTP client and credentials.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
+}
+
+// Context returns the context of requests sent by the client
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 // BuildURL builds the full URL for API requests
 func (c *Client) BuildURL(path string) string {
 	return fmt.Sprintf("%s/%s%s", c.config.BaseURL, c.config.APIVersion, path)
 }
 
+// SetTransport replaces the transport that sends requests, for example with
+// a recording transport in tests. The HTTP client is copied first, so a
+// Config.HTTPClient shared with other code is left unchanged.
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+	c.downloadClient = newDownloadClient(c.httpClient)
+}
+
+// SetUnauthorizedHandler sets a handler called with the rejected token when
+// a request is rejected with 401 Unauthorized. When it returns a new token,
+// for example a newly signed one or one of other credentials, the request is
+// sent again with that token, up to twice.
+func (c *Client) SetUnauthorizedHandler(handler func(rejected string) (string, bool)) {
+	c.unauthorized = handler
+}
+
 // Get performs a GET request
 func (c *Client) Get(path string, params map[string]string) (map[string]interface{}, error) {
-	// Build URL
-	fullURL := c.BuildURL(path)
-	if len(params) > 0 {
-		values := url.Values{}
-		for k, v := range params {
-			values.Add(k, v)
+	var result map[string]interface{}
+	err := c.get(path, params, &result)
+	return result, err
+}
+
+// get performs a GET request, serving it from the cache when possible, and
+// decodes the response body into result
+func (c *Client) get(path string, params map[string]string, result interface{}) error {
+	fullURL := c.BuildURL(path) + encodeQuery(c.withDefaultLimit(path, params))
+
+	// Serve from cache
+	if body, ok := c.cached(fullURL); ok {
+		if err := json.Unmarshal(body, result); err == nil {
+			return nil
 		}
-		fullURL += "?" + values.Encode()
 	}
 
-	// Create request
-	req, err := http.NewRequest("GET", fullURL, nil)
+	resp, body, err := c.send("GET", fullURL, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return err
+	}
+	err = decodeInto(resp, body, result)
+	if err == nil && len(body) > 0 {
+		c.store(path, fullURL, body)
 	}
+	return err
+}
 
-	// Set headers
-	for k, v := range c.GetHeaders() {
-		req.Header.Set(k, v)
+// GetRaw performs a GET request and returns the raw response body, for
+// endpoints that respond with non-JSON content such as gzip report files
+func (c *Client) GetRaw(path string, params map[string]string, accept string) ([]byte, error) {
+	var headers map[string]string
+	if accept != "" {
+		headers = map[string]string{"Accept": accept}
+	}
+	resp, body, err := c.send("GET", c.BuildURL(path)+encodeQuery(params), nil, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return body, newAPIError(resp, body)
+	}
+
+	return body, nil
+}
+
+// DownloadURL performs an unauthenticated GET request against an absolute
+// URL, such as a presigned download link, and returns the response body
+func (c *Client) DownloadURL(rawURL string) ([]byte, error) {
+	// Create request
+	req, err := http.NewRequestWithContext(c.Context(), "GET", rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Send request
@@ -102,39 +292,28 @@ func (c *Client) Get(path string, params map[string]string) (map[string]interfac
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Parse JSON
-	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
-	}
-
 	if resp.StatusCode >= 400 {
-		return result, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return body, fmt.Errorf("download failed with status %d", resp.StatusCode)
 	}
 
-	return result, nil
+	return body, nil
 }
 
-// PostJSON performs a POST request with JSON body
-func (c *Client) PostJSON(path string, body interface{}) (map[string]interface{}, error) {
-	// Build URL
-	fullURL := c.BuildURL(path)
-
-	// Marshal body
-	jsonBody, err := json.Marshal(body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+// UploadURL sends content to an absolute URL with the given method and
+// headers, without authentication, as required by asset upload operations
+func (c *Client) UploadURL(method, rawURL string, headers map[string]string, content []byte) error {
+	if c.skip(method) {
+		c.logDryRun(method, rawURL, []byte(fmt.Sprintf("(%d bytes)", len(content))))
+		return nil
 	}
 
 	// Create request
-	req, err := http.NewRequest("POST", fullURL, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(c.Context(), method, rawURL, bytes.NewReader(content))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
-	headers := c.GetHeaders()
-	headers["Content-Type"] = "application/json"
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
@@ -142,43 +321,207 @@ func (c *Client) PostJSON(path string, body interface{}) (map[string]interface{}
 	// Send request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	// Drain response
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// Parse JSON
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("upload failed with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// PostJSON performs a POST request with JSON body
+func (c *Client) PostJSON(path string, body interface{}) (map[string]interface{}, error) {
+	return c.sendJSON("POST", path, body)
+}
+
+// PatchJSON performs a PATCH request with JSON body
+func (c *Client) PatchJSON(path string, body interface{}) (map[string]interface{}, error) {
+	return c.sendJSON("PATCH", path, body)
+}
+
+// PutJSON performs a PUT request with JSON body
+func (c *Client) PutJSON(path string, body interface{}) (map[string]interface{}, error) {
+	return c.sendJSON("PUT", path, body)
+}
+
+// Delete performs a DELETE request
+func (c *Client) Delete(path string, params map[string]string) (map[string]interface{}, error) {
 	var result map[string]interface{}
-	if err := json.Unmarshal(responseBody, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	err := c.deleteInto(path, params, &result)
+	return result, err
+}
+
+// deleteInto performs a DELETE request and decodes the response body into
+// result
+func (c *Client) deleteInto(path string, params map[string]string, result interface{}) error {
+	// Invalidate cached responses once the write completes
+	defer c.InvalidateCache(path)
+
+	resp, body, err := c.send("DELETE", c.BuildURL(path)+encodeQuery(params), nil, nil)
+	if err != nil {
+		return err
 	}
+	return decodeInto(resp, body, result)
+}
+
+// DeleteJSON performs a DELETE request with JSON body
+func (c *Client) DeleteJSON(path string, body interface{}) (map[string]interface{}, error) {
+	return c.sendJSON("DELETE", path, body)
+}
 
+// Do performs a request with any method against an API path, for endpoints
+// without a dedicated method. A []byte body is sent as is, any other non-nil
+// body as JSON. The response body has been read, so the response can be
+// used after the connection is released; for error statuses the response is
+// returned along with an *APIError.
+func (c *Client) Do(method, path string, body interface{}, params map[string]string) (*http.Response, error) {
+	var content []byte
+	var headers map[string]string
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		content = b
+	default:
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		content, headers = jsonBody, map[string]string{"Content-Type": "application/json"}
+	}
+	if method != http.MethodGet {
+		// Invalidate cached responses once the write completes
+		defer c.InvalidateCache(path)
+	}
+
+	resp, responseBody, err := c.send(method, c.BuildURL(path)+encodeQuery(params), content, headers)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	resp.ContentLength = int64(len(responseBody))
 	if resp.StatusCode >= 400 {
-		return result, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return resp, newAPIError(resp, responseBody)
 	}
+	return resp, nil
+}
 
-	return result, nil
+// sendJSON performs a write request with JSON body
+func (c *Client) sendJSON(method, path string, body interface{}) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := c.sendJSONInto(method, path, body, &result)
+	return result, err
 }
 
-// Delete performs a DELETE request
-func (c *Client) Delete(path string, params map[string]string) (map[string]interface{}, error) {
-	// Build URL
-	fullURL := c.BuildURL(path)
-	if len(params) > 0 {
-		values := url.Values{}
-		for k, v := range params {
-			values.Add(k, v)
+// sendJSONInto performs a write request with JSON body and decodes the
+// response body into result
+func (c *Client) sendJSONInto(method, path string, body interface{}, result interface{}) error {
+	// Invalidate cached responses once the write completes
+	defer c.InvalidateCache(path)
+
+	// Marshal body
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	resp, responseBody, err := c.send(method, c.BuildURL(path), jsonBody, map[string]string{"Content-Type": "application/json"})
+	if err != nil {
+		return err
+	}
+	return decodeInto(resp, responseBody, result)
+}
+
+// maxReauthorizations is how often a request rejected with 401 is sent again
+// with a token from the unauthorized handler: with a newly signed token, and
+// then with other credentials
+const maxReauthorizations = 2
+
+// send performs an authenticated request and reads the response body. A
+// request rejected with 401 is sent again when the unauthorized handler
+// returns a new token.
+func (c *Client) send(method, fullURL string, body []byte, headers map[string]string) (*http.Response, []byte, error) {
+	if c.skip(method) {
+		resp, responseBody := c.dryRunResponse(method, fullURL, body)
+		return resp, responseBody, nil
+	}
+
+	ctx, finish := c.startRequest(method, fullURL)
+	attempts := 0
+	resp, responseBody, err := c.sendWithRetry(ctx, method, fullURL, body, headers, &attempts)
+	for i := 0; i < maxReauthorizations; i++ {
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || c.unauthorized == nil {
+			break
+		}
+		token, ok := c.unauthorized(c.sentToken(resp))
+		if !ok {
+			break
 		}
-		fullURL += "?" + values.Encode()
+		c.SetToken(token)
+		c.logReauthorization(ctx, method, fullURL)
+		resp, responseBody, err = c.sendWithRetry(ctx, method, fullURL, body, headers, &attempts)
+	}
+	finish(resp, attempts, err)
+	return resp, responseBody, err
+}
+
+// sendWithRetry performs a request, retrying it as configured by
+// Config.Retry while it is throttled or fails, see RetryConfig, and adds the
+// number of requests sent to sent
+func (c *Client) sendWithRetry(ctx context.Context, method, fullURL string, body []byte, headers map[string]string, sent *int) (*http.Response, []byte, error) {
+	attempts := c.config.Retry.maxAttempts()
+	for attempt := 1; ; attempt++ {
+		*sent++
+		resp, responseBody, err := c.sendOnce(ctx, method, fullURL, body, headers)
+		if err != nil || attempt >= attempts || !c.config.Retry.retryable(method, resp.StatusCode) {
+			return resp, responseBody, err
+		}
+		delay := c.config.Retry.delay(attempt, resp)
+		c.logRetry(ctx, method, fullURL, resp, attempt, delay)
+		if err := c.sleep(delay); err != nil {
+			return resp, responseBody, err
+		}
+	}
+}
+
+// sendOnce performs a single authenticated request and reads the response body
+func (c *Client) sendOnce(ctx context.Context, method, fullURL string, body []byte, headers map[string]string) (*http.Response, []byte, error) {
+	resp, err := c.do(ctx, c.httpClient, method, fullURL, body, headers)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return resp, responseBody, nil
+}
+
+// do performs a single authenticated request with httpClient and returns
+// the response with its body unread
+func (c *Client) do(ctx context.Context, httpClient *http.Client, method, fullURL string, body []byte, headers map[string]string) (*http.Response, error) {
+	// Fail fast while the circuit is open
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
 	}
 
 	// Create request
-	req, err := http.NewRequest("DELETE", fullURL, nil)
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -187,29 +530,73 @@ func (c *Client) Delete(path string, params map[string]string) (map[string]inter
 	for k, v := range c.GetHeaders() {
 		req.Header.Set(k, v)
 	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
 
 	// Send request
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
+		c.breaker.record(0, err)
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
-	defer resp.Body.Close()
+	c.breaker.record(resp.StatusCode, nil)
+	c.rateLimit.update(resp)
+	return resp, nil
+}
 
-	// Read response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+// decodeInto parses a JSON response body into result and returns an
+// APIError for error statuses, also when their body is not JSON, such as
+// the HTML page of a proxy
+func decodeInto(resp *http.Response, body []byte, result interface{}) error {
+	// Parse JSON, responses such as 204 No Content have no body
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, result); err != nil && resp.StatusCode < 400 {
+			return fmt.Errorf("failed to parse JSON: %w", err)
+		}
 	}
 
-	// Parse JSON
-	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp, body)
 	}
 
-	if resp.StatusCode >= 400 {
-		return result, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	return nil
+}
+
+// SetDefaultLimits sets the default limit of GET requests by resource type,
+// see Config.DefaultLimits
+func (c *Client) SetDefaultLimits(limits map[string]int) {
+	c.config.DefaultLimits = limits
+}
+
+// withDefaultLimit returns params with the default limit of the resource
+// type of path, unless params already has a limit
+func (c *Client) withDefaultLimit(path string, params map[string]string) map[string]string {
+	if _, ok := params["limit"]; ok {
+		return params
+	}
+	limit, ok := c.config.DefaultLimits[path[strings.LastIndex(path, "/")+1:]]
+	if !ok || limit <= 0 {
+		return params
 	}
 
-	return result, nil
+	withLimit := make(map[string]string, len(params)+1)
+	for k, v := range params {
+		withLimit[k] = v
+	}
+	withLimit["limit"] = strconv.Itoa(limit)
+	return withLimit
+}
+
+// encodeQuery encodes params as a query string including the leading "?",
+// or returns an empty string without params
+func encodeQuery(params map[string]string) string {
+	if len(params) == 0 {
+		return ""
+	}
+	values := url.Values{}
+	for k, v := range params {
+		values.Add(k, v)
+	}
+	return "?" + values.Encode()
 }
@@ -0,0 +1,468 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDownloadConcurrency = 4
+	defaultDownloadPartSize    = 8 << 20
+	defaultDownloadRetries     = 3
+	// downloadHeaderTimeout bounds the wait for the response headers of a
+	// download, whose body is only bounded by the request context
+	downloadHeaderTimeout = 30 * time.Second
+	downloadIdleTimeout   = 90 * time.Second
+)
+
+// DownloadOptions configures DownloadFile
+type DownloadOptions struct {
+	// Concurrency is the number of parallel range requests, 4 when zero
+	Concurrency int
+	// PartSize is the size of each range request, 8 MiB when zero
+	PartSize int64
+	// Retries is the number of retries of a failed part, 3 when zero
+	Retries int
+	// Checksum is the expected hex digest of the file, MD5, SHA-1, or SHA-256
+	// by its length. The file is not verified when it is empty.
+	Checksum string
+	// Progress is called as data is written with the downloaded and total
+	// bytes. The total is -1 when the server does not report the size.
+	Progress func(downloaded, total int64)
+}
+
+// downloadState is the progress of a ranged download, stored next to the
+// partial file so an interrupted download resumes with the missing parts.
+// The ETag and Last-Modified validators detect a file changed in between.
+type downloadState struct {
+	Size         int64  `json:"size"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	PartSize     int64  `json:"partSize"`
+	Done         []bool `json:"done"`
+}
+
+// errNoRanges reports a server ignoring range requests
+var errNoRanges = errors.New("range requests not supported")
+
+// DownloadFile downloads an absolute URL, such as a presigned download link,
+// to path without authentication. When the server supports range requests
+// the file is fetched in parallel parts, each retried on failure, and an
+// interrupted download resumes from the parts already written. Otherwise it
+// is streamed in a single request. The file only appears at path once it is
+// complete and matches the checksum, if one is given.
+func (c *Client) DownloadFile(ctx context.Context, rawURL, path string, options DownloadOptions) error {
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultDownloadConcurrency
+	}
+	if options.PartSize <= 0 {
+		options.PartSize = defaultDownloadPartSize
+	}
+	if options.Retries <= 0 {
+		options.Retries = defaultDownloadRetries
+	}
+	digest, err := checksumHash(options.Checksum)
+	if err != nil {
+		return err
+	}
+
+	partial := path + ".download"
+	remote, err := c.downloadSize(ctx, rawURL)
+	switch {
+	case errors.Is(err, errNoRanges):
+		err = c.downloadStream(ctx, rawURL, partial, options)
+	case err == nil:
+		err = c.downloadRanges(ctx, rawURL, partial, remote, options)
+	}
+	if err != nil {
+		return err
+	}
+
+	if digest != nil {
+		if err := verifyChecksum(partial, digest, options.Checksum); err != nil {
+			os.Remove(partial)
+			return err
+		}
+	}
+	if err := os.Rename(partial, path); err != nil {
+		return fmt.Errorf("failed to move download: %w", err)
+	}
+	return nil
+}
+
+// downloadSize requests the first byte of a URL and returns the size and
+// validators of the file, or errNoRanges when the server answers with the
+// whole file
+func (c *Client) downloadSize(ctx context.Context, rawURL string) (downloadState, error) {
+	var remote downloadState
+	resp, err := c.rangeRequest(ctx, rawURL, 0, 0, "")
+	if err != nil {
+		return remote, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// an empty file has no byte to request
+		return remote, errNoRanges
+	case resp.StatusCode >= 400:
+		return remote, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	case resp.StatusCode != http.StatusPartialContent:
+		return remote, errNoRanges
+	}
+	// Content-Range: bytes 0-0/1234
+	contentRange := resp.Header.Get("Content-Range")
+	slash := strings.LastIndex(contentRange, "/")
+	if slash < 0 {
+		return remote, errNoRanges
+	}
+	remote.Size, err = strconv.ParseInt(contentRange[slash+1:], 10, 64)
+	if err != nil {
+		return remote, errNoRanges
+	}
+	remote.ETag = resp.Header.Get("ETag")
+	remote.LastModified = resp.Header.Get("Last-Modified")
+	return remote, nil
+}
+
+// downloadRanges downloads the missing parts of a file in parallel. The
+// stored state is discarded unless the partial file and the remote file are
+// still the ones it describes.
+func (c *Client) downloadRanges(ctx context.Context, rawURL, partial string, remote downloadState, options DownloadOptions) error {
+	size := remote.Size
+	parts := int((size + options.PartSize - 1) / options.PartSize)
+	statePath := partial + ".json"
+	state := loadDownloadState(statePath)
+	if !state.resumes(remote, partial, options.PartSize, parts) {
+		state = remote
+		state.PartSize = options.PartSize
+		state.Done = make([]bool, parts)
+	}
+	validator := remote.validator()
+
+	file, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create download: %w", err)
+	}
+	defer file.Close()
+	if err := file.Truncate(size); err != nil {
+		return fmt.Errorf("failed to allocate download: %w", err)
+	}
+
+	var mu sync.Mutex
+	var downloaded int64
+	for i, done := range state.Done {
+		if done {
+			downloaded += partLength(i, size, options.PartSize)
+		}
+	}
+	progress := func(n int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		downloaded += n
+		if options.Progress != nil {
+			options.Progress(downloaded, size)
+		}
+	}
+	if options.Progress != nil {
+		options.Progress(downloaded, size)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var firstErr error
+	slots := make(chan struct{}, options.Concurrency)
+	for i := range state.Done {
+		if state.Done[i] {
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			start := int64(i) * options.PartSize
+			err := c.downloadPart(ctx, rawURL, validator, file, start, partLength(i, size, options.PartSize), options.Retries, progress)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to download part at offset %d: %w", start, err)
+				}
+				cancel()
+				return
+			}
+			// only record the part once its bytes are on disk
+			if err := file.Sync(); err != nil {
+				return
+			}
+			state.Done[i] = true
+			saveDownloadState(statePath, state)
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to write download: %w", err)
+	}
+	os.Remove(statePath)
+	return nil
+}
+
+// downloadPart writes one range of a file, retrying with exponential backoff
+// and continuing each retry after the bytes already written. A validator
+// makes the server answer with the whole file, failing the part, once the
+// file has changed.
+func (c *Client) downloadPart(ctx context.Context, rawURL, validator string, file io.WriterAt, start, length int64, retries int, progress func(int64)) error {
+	written := int64(0)
+	delay := time.Second
+	for attempt := 0; ; attempt++ {
+		err := func() error {
+			resp, err := c.rangeRequest(ctx, rawURL, start+written, start+length-1, validator)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusPartialContent {
+				return fmt.Errorf("download failed with status %d", resp.StatusCode)
+			}
+
+			buf := make([]byte, 32<<10)
+			for written < length {
+				n, err := resp.Body.Read(buf)
+				if n > 0 {
+					if int64(n) > length-written {
+						n = int(length - written)
+					}
+					if _, err := file.WriteAt(buf[:n], start+written); err != nil {
+						return fmt.Errorf("failed to write download: %w", err)
+					}
+					written += int64(n)
+					progress(int64(n))
+				}
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return fmt.Errorf("failed to read response: %w", err)
+				}
+			}
+			if written < length {
+				return io.ErrUnexpectedEOF
+			}
+			return nil
+		}()
+		if err == nil || attempt == retries || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// downloadStream downloads a file in a single request
+func (c *Client) downloadStream(ctx context.Context, rawURL, partial string, options DownloadOptions) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.downloadClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	file, err := os.Create(partial)
+	if err != nil {
+		return fmt.Errorf("failed to create download: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = resp.Body
+	if options.Progress != nil {
+		reader = &progressReader{reader: resp.Body, total: resp.ContentLength, progress: options.Progress}
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	return nil
+}
+
+// rangeRequest sends an unauthenticated GET request for the bytes from start
+// to end inclusive, conditional on the validator when one is given, leaving
+// the status check to the caller
+func (c *Client) rangeRequest(ctx context.Context, rawURL string, start, end int64, validator string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	if validator != "" {
+		req.Header.Set("If-Range", validator)
+	}
+	resp, err := c.downloadClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	return resp, nil
+}
+
+// newDownloadClient returns a copy of httpClient without its overall
+// timeout, which would also cut off reading a large body. Instead, a
+// download is bounded by its context, and by header and idle connection
+// timeouts when the transport is an *http.Transport without them.
+func newDownloadClient(httpClient *http.Client) *http.Client {
+	download := *httpClient
+	download.Timeout = 0
+
+	transport, ok := download.Transport.(*http.Transport)
+	if download.Transport == nil {
+		transport, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if ok && (transport.ResponseHeaderTimeout == 0 || transport.IdleConnTimeout == 0) {
+		transport = transport.Clone()
+		if transport.ResponseHeaderTimeout == 0 {
+			transport.ResponseHeaderTimeout = downloadHeaderTimeout
+		}
+		if transport.IdleConnTimeout == 0 {
+			transport.IdleConnTimeout = downloadIdleTimeout
+		}
+		download.Transport = transport
+	}
+	return &download
+}
+
+// progressReader reports the bytes read through it
+type progressReader struct {
+	reader   io.Reader
+	read     int64
+	total    int64
+	progress func(downloaded, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.read += int64(n)
+		r.progress(r.read, r.total)
+	}
+	return n, err
+}
+
+// partLength returns the length of part i of a file
+func partLength(i int, size, partSize int64) int64 {
+	start := int64(i) * partSize
+	if size-start < partSize {
+		return size - start
+	}
+	return partSize
+}
+
+// resumes reports whether a stored state continues the download of remote
+// into the partial file
+func (s downloadState) resumes(remote downloadState, partial string, partSize int64, parts int) bool {
+	if s.Size != remote.Size || s.ETag != remote.ETag || s.LastModified != remote.LastModified {
+		return false
+	}
+	if s.PartSize != partSize || len(s.Done) != parts {
+		return false
+	}
+	info, err := os.Stat(partial)
+	return err == nil && info.Size() == s.Size
+}
+
+// validator returns the If-Range value of a file, preferring a strong ETag
+// since weak ones are not allowed there
+func (s downloadState) validator() string {
+	if s.ETag != "" && !strings.HasPrefix(s.ETag, "W/") {
+		return s.ETag
+	}
+	return s.LastModified
+}
+
+// loadDownloadState reads the state of an interrupted download, if any
+func loadDownloadState(path string) downloadState {
+	var state downloadState
+	if content, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(content, &state)
+	}
+	return state
+}
+
+// saveDownloadState stores the state of a download, ignoring errors since
+// it only allows resuming
+func saveDownloadState(path string, state downloadState) {
+	if content, err := json.Marshal(state); err == nil {
+		os.WriteFile(path, content, 0o644)
+	}
+}
+
+// checksumHash returns the hash matching the length of a hex checksum
+func checksumHash(checksum string) (hash.Hash, error) {
+	switch len(checksum) {
+	case 0:
+		return nil, nil
+	case 32:
+		return md5.New(), nil
+	case 40:
+		return sha1.New(), nil
+	case 64:
+		return sha256.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported checksum %q", checksum)
+}
+
+// verifyChecksum compares the digest of a file with a hex checksum
+func verifyChecksum(path string, digest hash.Hash, checksum string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open download: %w", err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(digest, file); err != nil {
+		return fmt.Errorf("failed to read download: %w", err)
+	}
+	if !strings.EqualFold(hex.EncodeToString(digest.Sum(nil)), checksum) {
+		return fmt.Errorf("download checksum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package httpclient_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// fileServer serves a file like a presigned download URL
+type fileServer struct {
+	*httptest.Server
+	content []byte
+
+	mu   sync.Mutex
+	etag string
+	// noRanges makes the server ignore range requests
+	noRanges bool
+	// failRange is a Range header answered with an error
+	failRange string
+	ranges    []string
+}
+
+func newFileServer(t *testing.T, size int) *fileServer {
+	s := &fileServer{content: make([]byte, size), etag: `"v1"`}
+	for i := range s.content {
+		s.content[i] = byte(i * 7)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fileServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	rangeHeader := r.Header.Get("Range")
+	s.ranges = append(s.ranges, rangeHeader)
+	etag, noRanges, fail := s.etag, s.noRanges, rangeHeader != "" && rangeHeader == s.failRange
+	s.mu.Unlock()
+
+	if fail {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if noRanges {
+		w.Write(s.content)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	http.ServeContent(w, r, "archive.zip", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), bytes.NewReader(s.content))
+}
+
+// partRequests returns the range requests for parts of the file, leaving out
+// the request for its size
+func (s *fileServer) partRequests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var parts []string
+	for _, rangeHeader := range s.ranges {
+		if rangeHeader != "bytes=0-0" {
+			parts = append(parts, rangeHeader)
+		}
+	}
+	s.ranges = nil
+	return parts
+}
+
+// checkDownload verifies that path holds the served file and nothing of the
+// download is left behind
+func checkDownload(t *testing.T, server *fileServer, path string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.Equal(content, server.content) {
+		t.Errorf("got a file of %d bytes different from the served one", len(content))
+	}
+	for _, leftover := range []string{path + ".download", path + ".download.json"} {
+		if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+			t.Errorf("%s was left behind", filepath.Base(leftover))
+		}
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	server := newFileServer(t, 10000)
+	client := httpclient.NewClient(httpclient.Config{BaseURL: server.URL})
+	path := filepath.Join(t.TempDir(), "archive.zip")
+	sum := sha256.Sum256(server.content)
+
+	var mu sync.Mutex
+	var downloaded, total int64
+	err := client.DownloadFile(context.Background(), server.URL+"/archive.zip", path, httpclient.DownloadOptions{
+		Concurrency: 3,
+		PartSize:    1000,
+		Checksum:    hex.EncodeToString(sum[:]),
+		Progress: func(n, size int64) {
+			mu.Lock()
+			defer mu.Unlock()
+			downloaded, total = n, size
+		},
+	})
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	checkDownload(t, server, path)
+	if parts := server.partRequests(); len(parts) != 10 {
+		t.Errorf("got %d part requests, want 10", len(parts))
+	}
+	if downloaded != 10000 || total != 10000 {
+		t.Errorf("got progress %d of %d, want 10000 of 10000", downloaded, total)
+	}
+}
+
+func TestDownloadFileChecksumMismatch(t *testing.T) {
+	server := newFileServer(t, 1000)
+	client := httpclient.NewClient(httpclient.Config{BaseURL: server.URL})
+	path := filepath.Join(t.TempDir(), "archive.zip")
+
+	err := client.DownloadFile(context.Background(), server.URL+"/archive.zip", path, httpclient.DownloadOptions{
+		Checksum: "00000000000000000000000000000000",
+	})
+	if err == nil {
+		t.Fatal("downloaded a file with a mismatched checksum")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("a mismatched download was moved into place")
+	}
+}
+
+func TestDownloadFileWithoutRanges(t *testing.T) {
+	server := newFileServer(t, 5000)
+	server.noRanges = true
+	client := httpclient.NewClient(httpclient.Config{BaseURL: server.URL})
+	path := filepath.Join(t.TempDir(), "archive.zip")
+
+	if err := client.DownloadFile(context.Background(), server.URL+"/archive.zip", path, httpclient.DownloadOptions{PartSize: 1000}); err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	checkDownload(t, server, path)
+}
+
+func TestDownloadFileResume(t *testing.T) {
+	tests := []struct {
+		name string
+		// change changes the file or the partial download after the
+		// interruption
+		change func(server *fileServer, path string)
+		// parts is the number of parts requested by the resumed download
+		parts int
+	}{
+		{name: "missing parts", change: func(*fileServer, string) {}, parts: 5},
+		{name: "changed file", change: func(server *fileServer, _ string) { server.etag = `"v2"` }, parts: 10},
+		{name: "missing partial file", change: func(_ *fileServer, path string) { os.Remove(path + ".download") }, parts: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFileServer(t, 10000)
+			client := httpclient.NewClient(httpclient.Config{BaseURL: server.URL})
+			path := filepath.Join(t.TempDir(), "archive.zip")
+			options := httpclient.DownloadOptions{Concurrency: 1, PartSize: 1000, Retries: 1}
+
+			// The sixth part fails, interrupting the download after five parts
+			server.failRange = "bytes=5000-5999"
+			if err := client.DownloadFile(context.Background(), server.URL+"/archive.zip", path, options); err == nil {
+				t.Fatal("DownloadFile succeeded with a failing part")
+			}
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Fatal("an interrupted download was moved into place")
+			}
+			server.partRequests()
+
+			server.mu.Lock()
+			server.failRange = ""
+			tt.change(server, path)
+			server.mu.Unlock()
+			if err := client.DownloadFile(context.Background(), server.URL+"/archive.zip", path, options); err != nil {
+				t.Fatalf("DownloadFile: %v", err)
+			}
+			checkDownload(t, server, path)
+			if parts := server.partRequests(); len(parts) != tt.parts {
+				t.Errorf("got part requests %v, want %d", parts, tt.parts)
+			}
+		})
+	}
+}
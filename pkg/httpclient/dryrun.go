@@ -0,0 +1,81 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// dryRunID is the ID of resources created in dry run mode
+const dryRunID = "DRY-RUN"
+
+// SetDryRun enables or disables dry run mode, see Config.DryRun
+func (c *Client) SetDryRun(enabled bool) {
+	c.config.DryRun = enabled
+}
+
+// DryRun reports whether write requests are logged instead of sent
+func (c *Client) DryRun() bool {
+	return c.config.DryRun
+}
+
+// skip reports whether a request is a write request skipped in dry run mode
+func (c *Client) skip(method string) bool {
+	return c.config.DryRun && method != http.MethodGet && method != http.MethodHead
+}
+
+// logDryRun writes the request that dry run mode skips
+func (c *Client) logDryRun(method, rawURL string, body []byte) {
+	output := c.config.DryRunOutput
+	if output == nil {
+		output = os.Stderr
+	}
+	if len(body) > 0 {
+		fmt.Fprintf(output, "dry run: %s %s %s\n", method, rawURL, body)
+	} else {
+		fmt.Fprintf(output, "dry run: %s %s\n", method, rawURL)
+	}
+}
+
+// dryRunResponse logs a write request and returns a synthetic successful
+// response instead of sending it. Requests with a resource object are
+// answered with that resource, created ones with the ID DRY-RUN, so callers
+// reading the result keep working. Other requests, such as deletions and
+// relationship updates, are answered with 204 No Content.
+func (c *Client) dryRunResponse(method, fullURL string, body []byte) (*http.Response, []byte) {
+	c.logDryRun(method, fullURL, body)
+
+	var document struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	var responseBody []byte
+	statusCode := http.StatusNoContent
+	if json.Unmarshal(body, &document) == nil && document.Data != nil && method != http.MethodDelete {
+		statusCode = http.StatusOK
+		if method == http.MethodPost {
+			statusCode = http.StatusCreated
+		}
+		if _, ok := document.Data["id"]; !ok {
+			document.Data["id"] = dryRunID
+		}
+		responseBody, _ = json.Marshal(document)
+	}
+
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(responseBody)),
+		ContentLength: int64(len(responseBody)),
+	}
+	if responseBody != nil {
+		resp.Header.Set("Content-Type", "application/json")
+	}
+	return resp, responseBody
+}
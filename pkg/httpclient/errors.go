@@ -0,0 +1,203 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by APIError with errors.Is
+var (
+	ErrNotFound      = errors.New("resource not found")
+	ErrAlreadyExists = errors.New("resource already exists")
+	ErrConflict      = errors.New("request conflicts with the current state")
+	ErrUnauthorized  = errors.New("credentials rejected")
+	ErrForbidden     = errors.New("access forbidden")
+	ErrRateLimited   = errors.New("rate limit exceeded")
+	ErrServer        = errors.New("server error")
+)
+
+// ErrorDetail is a JSON:API error object of an error response
+type ErrorDetail struct {
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"`
+	// Code is a dot-separated error code, such as ENTITY_ERROR.ATTRIBUTE.INVALID
+	Code   string       `json:"code"`
+	Title  string       `json:"title"`
+	Detail string       `json:"detail"`
+	Source *ErrorSource `json:"source,omitempty"`
+	// Meta holds additional information, such as associatedErrors of
+	// requests failing for several reasons
+	Meta map[string]interface{} `json:"meta,omitempty"`
+}
+
+// ErrorSource identifies the part of a request an error object relates to
+type ErrorSource struct {
+	// Pointer is a JSON pointer to a member of the request document, such as /data/attributes/name
+	Pointer string `json:"pointer,omitempty"`
+	// Parameter is the name of a query parameter, such as filter[platform]
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// APIError is returned for responses with an error status
+type APIError struct {
+	StatusCode int
+	Errors     []ErrorDetail
+	// RequestIDs identify the request when the failure is escalated to Apple
+	RequestIDs RequestIDs
+}
+
+// RequestIDs are the identifiers Apple assigns to a request, which Apple
+// developer support asks for to investigate a failure
+type RequestIDs struct {
+	// CorrelationKey is the X-Apple-Jingle-Correlation-Key header
+	CorrelationKey string
+	// RequestUUID is the X-Apple-Request-UUID header
+	RequestUUID string
+}
+
+// ResponseRequestIDs returns the request identifiers of a response's headers
+func ResponseRequestIDs(header http.Header) RequestIDs {
+	return RequestIDs{
+		CorrelationKey: header.Get("X-Apple-Jingle-Correlation-Key"),
+		RequestUUID:    header.Get("X-Apple-Request-UUID"),
+	}
+}
+
+// String returns the identifiers that are set, such as
+// "correlation key ABC, request UUID 123"
+func (r RequestIDs) String() string {
+	var ids []string
+	if r.CorrelationKey != "" {
+		ids = append(ids, "correlation key "+r.CorrelationKey)
+	}
+	if r.RequestUUID != "" {
+		ids = append(ids, "request UUID "+r.RequestUUID)
+	}
+	return strings.Join(ids, ", ")
+}
+
+// newAPIError creates an APIError from an error response and its body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, RequestIDs: ResponseRequestIDs(resp.Header)}
+	var response struct {
+		Errors []ErrorDetail `json:"errors"`
+	}
+	if json.Unmarshal(body, &response) == nil {
+		apiErr.Errors = response.Errors
+	}
+	return apiErr
+}
+
+// Error returns the status, the detail and source of the first error
+// object, and the request identifiers
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("API request failed with status %d", e.StatusCode)
+	if len(e.Errors) > 0 {
+		detail := e.Errors[0].Detail
+		if detail == "" {
+			detail = e.Errors[0].Title
+		}
+		if detail != "" {
+			message += ": " + detail
+		}
+		if source := e.Errors[0].Source; source != nil {
+			if source.Pointer != "" {
+				message += " (" + source.Pointer + ")"
+			} else if source.Parameter != "" {
+				message += " (" + source.Parameter + ")"
+			}
+		}
+	}
+	if ids := e.RequestIDs.String(); ids != "" {
+		message += " [" + ids + "]"
+	}
+	return message
+}
+
+// Code returns the code of the first error object, if any
+func (e *APIError) Code() string {
+	if len(e.Errors) == 0 {
+		return ""
+	}
+	return e.Errors[0].Code
+}
+
+// HasCode reports whether an error object has code or a code below it in
+// the hierarchy of dot-separated codes, so ENTITY_ERROR matches
+// ENTITY_ERROR.ATTRIBUTE.INVALID
+func (e *APIError) HasCode(code string) bool {
+	for _, detail := range e.Errors {
+		if detail.Code == code || strings.HasPrefix(detail.Code, code+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Is reports whether the error belongs to the class of a sentinel error
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrServer:
+		return e.StatusCode >= 500
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrAlreadyExists:
+		if e.StatusCode != http.StatusConflict {
+			return false
+		}
+		for _, detail := range e.Errors {
+			if strings.HasSuffix(detail.Code, ".DUPLICATE") || strings.Contains(detail.Detail, "already exists") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is a 404 response
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsAlreadyExists reports whether err is a 409 response for a resource that already exists
+func IsAlreadyExists(err error) bool {
+	return errors.Is(err, ErrAlreadyExists)
+}
+
+// IsConflict reports whether err is a 409 response, which includes resources
+// that already exist and requests conflicting with the current state
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsUnauthorized reports whether err is a 401 response, for credentials that
+// are invalid, revoked, or expired
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden reports whether err is a 403 response
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsRateLimited reports whether err is a 429 response
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsServerError reports whether err is a 5xx response
+func IsServerError(err error) bool {
+	return errors.Is(err, ErrServer)
+}
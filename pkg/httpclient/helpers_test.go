@@ -0,0 +1,38 @@
+package httpclient_test
+
+import (
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// newClient returns the authenticated HTTP client of a server, with a
+// configuration changed by configure
+func newClient(t *testing.T, server *appstoretest.Server, configure func(config *appstore.Config)) *httpclient.Client {
+	t.Helper()
+	config := server.Config()
+	if configure != nil {
+		configure(&config)
+	}
+	client, err := appstore.NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if err := client.EnsureAuth(); err != nil {
+		t.Fatalf("EnsureAuth: %v", err)
+	}
+	return client.GetHTTPClient()
+}
+
+// countRequests returns the number of requests a server received for an endpoint
+func countRequests(server *appstoretest.Server, method, path string) int {
+	count := 0
+	for _, request := range server.Requests() {
+		if request.Method == method && request.Path == path {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,100 @@
+package httpclient
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Logger receives the log records of a client: retries, waits for rate
+// limits, token renewals, and failed requests at Warn and Error, and every
+// request at Debug. *slog.Logger implements it. Clients without a logger
+// are silent.
+type Logger interface {
+	Log(ctx context.Context, level slog.Level, msg string, args ...any)
+}
+
+// NewSlogLogger returns a Logger writing to logger, or to slog.Default()
+// when it is nil
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		return slog.Default()
+	}
+	return logger
+}
+
+// SetLogger sets the logger of the client, nil to silence it
+func (c *Client) SetLogger(logger Logger) {
+	c.config.Logger = logger
+}
+
+// log writes a record to the logger of the client, if it has one
+func (c *Client) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if c.config.Logger != nil {
+		c.config.Logger.Log(ctx, level, msg, args...)
+	}
+}
+
+// logRetry logs the delay before retrying a request that received resp
+func (c *Client) logRetry(ctx context.Context, method, fullURL string, resp *http.Response, attempt int, delay time.Duration) {
+	msg := "retrying request"
+	if resp.StatusCode == http.StatusTooManyRequests {
+		msg = "rate limited, waiting to retry request"
+	}
+	args := []any{
+		"method", method,
+		"endpoint", c.endpoint(fullURL),
+		"status", resp.StatusCode,
+		"attempt", attempt,
+		"delay", delay,
+	}
+	c.log(ctx, slog.LevelWarn, msg, append(args, requestIDArgs(resp.Header)...)...)
+}
+
+// logReauthorization logs a request sent again with a new token after it
+// was rejected with 401
+func (c *Client) logReauthorization(ctx context.Context, method, fullURL string) {
+	c.log(ctx, slog.LevelInfo, "token rejected, retrying request with a new token",
+		"method", method,
+		"endpoint", c.endpoint(fullURL),
+	)
+}
+
+// logResult logs the outcome of a request: failures at Error, API errors at
+// Warn, and other responses at Debug
+func (c *Client) logResult(ctx context.Context, method, fullURL string, resp *http.Response, attempts int, duration time.Duration, err error) {
+	if c.config.Logger == nil {
+		return
+	}
+	args := []any{
+		"method", method,
+		"endpoint", c.endpoint(fullURL),
+		"attempts", attempts,
+		"duration", duration,
+	}
+	switch {
+	case err != nil:
+		c.log(ctx, slog.LevelError, "request failed", append(args, "error", err)...)
+	case resp.StatusCode >= 400:
+		args = append(args, "status", resp.StatusCode)
+		args = append(args, requestIDArgs(resp.Header)...)
+		c.log(ctx, slog.LevelWarn, "request failed", args...)
+	default:
+		c.log(ctx, slog.LevelDebug, "request", append(args, "status", resp.StatusCode)...)
+	}
+}
+
+// requestIDArgs returns the Apple request identifiers of a response that are
+// set, to quote when reporting the failure to Apple
+func requestIDArgs(header http.Header) []any {
+	ids := ResponseRequestIDs(header)
+	var args []any
+	if ids.CorrelationKey != "" {
+		args = append(args, "correlation_key", ids.CorrelationKey)
+	}
+	if ids.RequestUUID != "" {
+		args = append(args, "request_uuid", ids.RequestUUID)
+	}
+	return args
+}
@@ -0,0 +1,94 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Observer is notified of every API request of a client, to emit spans and
+// metrics such as with OpenTelemetry. Clients without an observer skip the
+// notifications entirely.
+type Observer interface {
+	// RequestStarted is called before a request is sent and returns the
+	// context to send it with, such as one holding a span
+	RequestStarted(ctx context.Context, request RequestInfo) context.Context
+	// RequestFinished is called with the context returned by RequestStarted
+	// once the request completed, after its retries and reauthorizations
+	RequestFinished(ctx context.Context, request RequestInfo, result RequestResult)
+}
+
+// RequestInfo describes an API request
+type RequestInfo struct {
+	Method string
+	URL    string
+	// Endpoint is the request path with the resource ID replaced, such as
+	// /apps/{id}/builds, a low-cardinality name for spans and metrics
+	Endpoint string
+}
+
+// RequestResult describes the outcome of an API request
+type RequestResult struct {
+	// StatusCode is the status of the last response, 0 when none was received
+	StatusCode int
+	// Duration is the time from the first attempt to the last response,
+	// including the delays between retries
+	Duration time.Duration
+	// Attempts is the number of times the request was sent, counting
+	// retries and reauthorizations
+	Attempts int
+	// Err is the error sending the request, API errors are reported by
+	// StatusCode
+	Err error
+}
+
+// SetObserver sets the observer notified of every API request, nil to
+// remove it
+func (c *Client) SetObserver(observer Observer) {
+	c.config.Observer = observer
+}
+
+// startRequest notifies the observer of a request and returns the context
+// to send it with and a function to call with its outcome, which also logs
+// it
+func (c *Client) startRequest(method, fullURL string) (context.Context, func(resp *http.Response, attempts int, err error)) {
+	ctx := c.Context()
+	observer := c.config.Observer
+	if observer == nil && c.config.Logger == nil {
+		return ctx, func(*http.Response, int, error) {}
+	}
+
+	request := RequestInfo{Method: method, URL: fullURL, Endpoint: c.endpoint(fullURL)}
+	if observer != nil {
+		ctx = observer.RequestStarted(ctx, request)
+	}
+	start := time.Now()
+	return ctx, func(resp *http.Response, attempts int, err error) {
+		duration := time.Since(start)
+		c.logResult(ctx, method, fullURL, resp, attempts, duration, err)
+		if observer == nil {
+			return
+		}
+		result := RequestResult{Duration: duration, Attempts: attempts, Err: err}
+		if resp != nil {
+			result.StatusCode = resp.StatusCode
+		}
+		observer.RequestFinished(ctx, request, result)
+	}
+}
+
+// endpoint returns the path of an API URL without its query, API version,
+// and resource ID. Paths of App Store Connect resources have the resource
+// ID as their second segment, as in /apps/{id}/relationships/builds.
+func (c *Client) endpoint(fullURL string) string {
+	path := strings.TrimPrefix(fullURL, c.config.BaseURL+"/"+c.config.APIVersion)
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+	if len(segments) > 2 && segments[2] != "" {
+		segments[2] = "{id}"
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,99 @@
+package httpclient
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// maxPageLimit is the largest page size of App Store Connect list endpoints
+const maxPageLimit = 200
+
+// EachPage performs GET requests for the pages of a list endpoint, following
+// links.next, and calls fn with each page response. Pages hold 200 resources
+// unless params set a limit. Iteration stops at the first request error or
+// error returned by fn.
+func (c *Client) EachPage(path string, params map[string]string, fn func(page map[string]interface{}) error) error {
+	query := map[string]string{"limit": strconv.Itoa(maxPageLimit)}
+	for k, v := range params {
+		query[k] = v
+	}
+
+	for query != nil {
+		page, err := c.Get(path, query)
+		if err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		query = NextPageParams(page)
+	}
+	return nil
+}
+
+// GetAllPages performs GET requests for every page of a list endpoint and
+// returns a single response holding the resources and included resources of
+// all pages, with meta.paging.total set to the number of resources
+func (c *Client) GetAllPages(path string, params map[string]string) (map[string]interface{}, error) {
+	data := []interface{}{}
+	var included []interface{}
+	seen := make(map[string]bool)
+
+	err := c.EachPage(path, params, func(page map[string]interface{}) error {
+		if items, ok := page["data"].([]interface{}); ok {
+			data = append(data, items...)
+		}
+		if items, ok := page["included"].([]interface{}); ok {
+			for _, item := range items {
+				resource, _ := item.(map[string]interface{})
+				resourceType, _ := resource["type"].(string)
+				id, _ := resource["id"].(string)
+				if key := resourceType + "/" + id; !seen[key] {
+					seen[key] = true
+					included = append(included, item)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := map[string]interface{}{
+		"data": data,
+		"meta": map[string]interface{}{
+			"paging": map[string]interface{}{"total": float64(len(data))},
+		},
+	}
+	if included != nil {
+		response["included"] = included
+	}
+	return response, nil
+}
+
+// NextPageParams returns the query parameters of the next page of a list
+// response, or nil when the response is the last page
+func NextPageParams(response map[string]interface{}) map[string]string {
+	links, ok := response["links"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	next, ok := links["next"].(string)
+	if !ok || next == "" {
+		return nil
+	}
+
+	nextURL, err := url.Parse(next)
+	if err != nil {
+		return nil
+	}
+
+	params := make(map[string]string)
+	for k, v := range nextURL.Query() {
+		if len(v) > 0 {
+			params[k] = v[0]
+		}
+	}
+	return params
+}
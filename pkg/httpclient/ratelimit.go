@@ -0,0 +1,71 @@
+package httpclient
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is the request budget App Store Connect reports in the
+// X-Rate-Limit header of its responses, such as
+// "user-hour-lim:3600;user-hour-rem:3412;"
+type RateLimit struct {
+	// Limit is the number of requests allowed per hour
+	Limit int
+	// Remaining is the number of requests left in the current hour
+	Remaining int
+	// Updated is when the response reporting the budget was received
+	Updated time.Time
+}
+
+// rateLimitState is the last reported rate limit, shared by copies of a client
+type rateLimitState struct {
+	mu    sync.Mutex
+	limit RateLimit
+	ok    bool
+}
+
+// RateLimit returns the request budget reported by the last response with
+// an X-Rate-Limit header, and false before any response reported one
+func (c *Client) RateLimit() (RateLimit, bool) {
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+	return c.rateLimit.limit, c.rateLimit.ok
+}
+
+// update stores the rate limit of a response, if it reports one
+func (s *rateLimitState) update(resp *http.Response) {
+	limit, ok := parseRateLimit(resp.Header.Get("X-Rate-Limit"))
+	if !ok {
+		return
+	}
+	limit.Updated = time.Now()
+	s.mu.Lock()
+	s.limit, s.ok = limit, true
+	s.mu.Unlock()
+}
+
+// parseRateLimit parses an X-Rate-Limit header
+func parseRateLimit(header string) (RateLimit, bool) {
+	var limit RateLimit
+	var hasLimit, hasRemaining bool
+	for _, item := range strings.Split(header, ";") {
+		name, value, found := strings.Cut(strings.TrimSpace(item), ":")
+		if !found {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		switch strings.TrimSpace(name) {
+		case "user-hour-lim":
+			limit.Limit, hasLimit = n, true
+		case "user-hour-rem":
+			limit.Remaining, hasRemaining = n, true
+		}
+	}
+	return limit, hasLimit && hasRemaining
+}
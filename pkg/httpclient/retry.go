@@ -0,0 +1,118 @@
+package httpclient
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig configures retries of requests rejected with 429 Too Many
+// Requests, and of GET and HEAD requests that failed with a 5xx status.
+// Delays double with every retry, and a Retry-After header of the response
+// takes precedence.
+type RetryConfig struct {
+	// MaxAttempts is the number of attempts including the first, 3 when zero
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, 1s when zero
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts, 30s when zero
+	MaxDelay time.Duration
+	// Jitter randomizes every delay by up to this fraction, such as 0.2 for
+	// ±20%, so concurrent clients do not retry in lockstep
+	Jitter float64
+	// RetryNonIdempotent also retries POST, PATCH, PUT, and DELETE requests
+	// that failed with a 5xx status. The server may have applied such a
+	// request before failing, so a retry can create a duplicate resource.
+	RetryNonIdempotent bool
+}
+
+// SetRetry enables retries of throttled and failed requests
+func (c *Client) SetRetry(config RetryConfig) {
+	c.config.Retry = &config
+}
+
+// maxAttempts returns the number of attempts of a request
+func (r *RetryConfig) maxAttempts() int {
+	if r == nil {
+		return 1
+	}
+	if r.MaxAttempts <= 0 {
+		return 3
+	}
+	return r.MaxAttempts
+}
+
+// delay returns the delay before retry number attempt, counting from 1,
+// of a request that received resp
+func (r *RetryConfig) delay(attempt int, resp *http.Response) time.Duration {
+	base, maxDelay := r.BaseDelay, r.MaxDelay
+	if base <= 0 {
+		base = time.Second
+	}
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+
+	delay, ok := retryAfter(resp)
+	if !ok {
+		delay = base << (attempt - 1)
+		if r.Jitter > 0 {
+			delay += time.Duration((rand.Float64()*2 - 1) * r.Jitter * float64(delay))
+		}
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if delay < 0 {
+		// a Retry-After date in the past allows retrying right away
+		delay = 0
+	}
+	return delay
+}
+
+// retryable reports whether a request that received a response status is
+// worth retrying. Throttled requests were not processed and are always
+// retried, failed ones only when the method is idempotent or
+// RetryNonIdempotent is set.
+func (r *RetryConfig) retryable(method string, statusCode int) bool {
+	if r == nil {
+		return false
+	}
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if statusCode < 500 {
+		return false
+	}
+	return method == http.MethodGet || method == http.MethodHead || r.RetryNonIdempotent
+}
+
+// retryAfter returns the delay of the Retry-After header of a response, in
+// seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+	return 0, false
+}
+
+// sleep waits for d, returning early with the error of the client context
+// when it is done
+func (c *Client) sleep(d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-c.Context().Done():
+		return c.Context().Err()
+	}
+}
@@ -0,0 +1,95 @@
+package httpclient_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+// failing answers the first failures requests with status and counts every request
+type failing struct {
+	mu         sync.Mutex
+	status     int
+	retryAfter string
+	failures   int
+	requests   int
+}
+
+// serveHTTP answers a request
+func (f *failing) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests++
+	fail := f.requests <= f.failures
+	f.mu.Unlock()
+	if fail {
+		if f.retryAfter != "" {
+			w.Header().Set("Retry-After", f.retryAfter)
+		}
+		w.WriteHeader(f.status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"data": []}`))
+}
+
+// count returns the number of requests received
+func (f *failing) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name               string
+		method             string
+		status             int
+		retryAfter         string
+		retryNonIdempotent bool
+		wantRequests       int
+		wantErr            bool
+	}{
+		{name: "GET after 503", method: http.MethodGet, status: http.StatusServiceUnavailable, wantRequests: 3},
+		{name: "GET after 429", method: http.MethodGet, status: http.StatusTooManyRequests, wantRequests: 3},
+		{name: "POST after 429", method: http.MethodPost, status: http.StatusTooManyRequests, wantRequests: 3},
+		{name: "POST after 503", method: http.MethodPost, status: http.StatusServiceUnavailable, wantRequests: 1, wantErr: true},
+		{name: "POST after 503 opted in", method: http.MethodPost, status: http.StatusServiceUnavailable, retryNonIdempotent: true, wantRequests: 3},
+		{name: "GET after 400", method: http.MethodGet, status: http.StatusBadRequest, wantRequests: 1, wantErr: true},
+		{name: "Retry-After beyond the maximum delay", method: http.MethodGet, status: http.StatusTooManyRequests, retryAfter: "3600", wantRequests: 3},
+		{name: "Retry-After date in the past", method: http.MethodGet, status: http.StatusTooManyRequests, retryAfter: "Mon, 02 Jan 2006 15:04:05 GMT", wantRequests: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := appstoretest.NewServer()
+			defer server.Close()
+			handler := &failing{status: tt.status, retryAfter: tt.retryAfter, failures: 2}
+			server.Handle(tt.method, "/devices", handler.serveHTTP)
+			client := newClient(t, server, func(config *appstore.Config) {
+				config.Retry = &httpclient.RetryConfig{
+					MaxAttempts:        3,
+					BaseDelay:          time.Millisecond,
+					MaxDelay:           10 * time.Millisecond,
+					RetryNonIdempotent: tt.retryNonIdempotent,
+				}
+			})
+
+			start := time.Now()
+			_, err := client.Do(tt.method, "/devices", nil, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got := handler.count(); got != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", got, tt.wantRequests)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("retries took %v beyond the maximum delay", elapsed)
+			}
+		})
+	}
+}
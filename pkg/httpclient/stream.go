@@ -0,0 +1,134 @@
+package httpclient
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Download performs an authenticated GET request and returns the response
+// body as a stream instead of buffering and decoding it, for large or
+// non-JSON content such as gzip report files. Content compressed with gzip,
+// by Content-Encoding or as an application/a-gzip file, is decompressed as
+// it is read. The caller must close the body. Requests are retried and
+// reauthorized as configured, and error statuses return an *APIError. The
+// body is read without the overall timeout of the HTTP client, bounded by
+// the client context instead.
+func (c *Client) Download(path string, params map[string]string) (io.ReadCloser, http.Header, error) {
+	fullURL := c.BuildURL(path) + encodeQuery(params)
+	headers := map[string]string{
+		"Accept":          "application/a-gzip, application/json;q=0.9, */*;q=0.8",
+		"Accept-Encoding": "gzip",
+	}
+
+	ctx, finish := c.startRequest(http.MethodGet, fullURL)
+	resp, attempts, err := c.open(ctx, fullURL, headers)
+	finish(resp, attempts, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := decompress(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer body.Close()
+		content, _ := io.ReadAll(body)
+		return nil, resp.Header, newAPIError(resp, content)
+	}
+	return body, resp.Header, nil
+}
+
+// open performs a GET request and returns the response with its body
+// unread, sending it again when rejected with 401 and the unauthorized
+// handler returns a new token, and retrying it as configured by
+// Config.Retry. It returns the number of requests sent.
+func (c *Client) open(ctx context.Context, fullURL string, headers map[string]string) (*http.Response, int, error) {
+	retries, reauthorizations := 0, 0
+	for attempts := 1; ; attempts++ {
+		resp, err := c.do(ctx, c.downloadClient, http.MethodGet, fullURL, nil, headers)
+		if err != nil {
+			return nil, attempts, err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && c.unauthorized != nil && reauthorizations < maxReauthorizations {
+			if token, ok := c.unauthorized(c.sentToken(resp)); ok {
+				discard(resp)
+				c.SetToken(token)
+				c.logReauthorization(ctx, http.MethodGet, fullURL)
+				reauthorizations++
+				continue
+			}
+		}
+		if c.config.Retry.retryable(http.MethodGet, resp.StatusCode) && retries+1 < c.config.Retry.maxAttempts() {
+			retries++
+			delay := c.config.Retry.delay(retries, resp)
+			c.logRetry(ctx, http.MethodGet, fullURL, resp, retries, delay)
+			discard(resp)
+			if err := c.sleep(delay); err != nil {
+				return nil, attempts, err
+			}
+			continue
+		}
+		return resp, attempts, nil
+	}
+}
+
+// discard drains and closes a response body, so its connection is reused
+func discard(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// gzipBody decompresses a response body as it is read
+type gzipBody struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+// Close closes the decompressor and the response body
+func (g gzipBody) Close() error {
+	g.Reader.Close()
+	return g.body.Close()
+}
+
+// decompress returns the body of a response, decompressing it when it is
+// compressed with gzip. Content-Encoding and Content-Length of decompressed
+// responses are removed from the headers.
+func decompress(resp *http.Response) (io.ReadCloser, error) {
+	body := struct {
+		io.Reader
+		io.Closer
+	}{resp.Body, resp.Body}
+
+	compressed := strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip")
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); !compressed && mediaType == "application/a-gzip" {
+		// Report files are gzip files, unless a proxy decompressed them
+		buffered := bufio.NewReader(resp.Body)
+		magic, _ := buffered.Peek(2)
+		compressed = len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b
+		body.Reader = buffered
+	}
+	if !compressed {
+		return body, nil
+	}
+
+	reader, err := gzip.NewReader(body.Reader)
+	if err == io.EOF {
+		// Responses such as 204 No Content have no body to decompress
+		return body, nil
+	}
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	return gzipBody{Reader: reader, body: resp.Body}, nil
+}
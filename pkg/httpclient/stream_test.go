@@ -0,0 +1,102 @@
+package httpclient_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/httpclient"
+)
+
+const reportContent = "Provider\tSKU\tUnits\nAPPLE\tapp.sku\t3\n"
+
+// gzipped returns content compressed with gzip
+func gzipped(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDownload(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentType     string
+		contentEncoding string
+		body            []byte
+	}{
+		{name: "gzip file", contentType: "application/a-gzip", body: gzipped(t, reportContent)},
+		{name: "decompressed gzip file", contentType: "application/a-gzip", body: []byte(reportContent)},
+		{name: "gzip encoding", contentType: "text/tab-separated-values", contentEncoding: "gzip", body: gzipped(t, reportContent)},
+		{name: "plain", contentType: "text/tab-separated-values", body: []byte(reportContent)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := appstoretest.NewServer()
+			defer server.Close()
+			server.Handle(http.MethodGet, "/salesReports", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				if tt.contentEncoding != "" {
+					w.Header().Set("Content-Encoding", tt.contentEncoding)
+				}
+				w.Write(tt.body)
+			})
+
+			body, header, err := newClient(t, server, nil).Download("/salesReports", map[string]string{"filter[reportType]": "SALES"})
+			if err != nil {
+				t.Fatalf("Download: %v", err)
+			}
+			defer body.Close()
+			content, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			if string(content) != reportContent {
+				t.Errorf("got %q, want %q", content, reportContent)
+			}
+			if header.Get("Content-Encoding") != "" {
+				t.Errorf("got Content-Encoding %q on a decompressed body", header.Get("Content-Encoding"))
+			}
+		})
+	}
+}
+
+func TestDownloadError(t *testing.T) {
+	server := appstoretest.NewServer()
+	defer server.Close()
+	handler := &failing{status: http.StatusServiceUnavailable, failures: 1}
+	server.Handle(http.MethodGet, "/salesReports", handler.serveHTTP)
+
+	// Without retries, the error status is returned as an *APIError
+	_, _, err := newClient(t, server, nil).Download("/salesReports", nil)
+	if !httpclient.IsServerError(err) {
+		t.Fatalf("got %v, want a server error", err)
+	}
+
+	// With retries, the request is sent again
+	client := newClient(t, server, func(config *appstore.Config) {
+		config.Retry = &httpclient.RetryConfig{BaseDelay: time.Millisecond}
+	})
+	handler.mu.Lock()
+	handler.requests = 0
+	handler.mu.Unlock()
+	body, _, err := client.Download("/salesReports", nil)
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	body.Close()
+	if got := handler.count(); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+}
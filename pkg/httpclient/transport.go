@@ -0,0 +1,62 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TransportConfig configures the network access of the default HTTP client,
+// for build machines that reach Apple only through an egress proxy
+type TransportConfig struct {
+	// Proxy is the URL of the HTTP or HTTPS proxy requests are sent through.
+	// Without it, the HTTPS_PROXY and NO_PROXY environment variables apply.
+	Proxy *url.URL
+	// RootCAs verifies server certificates instead of the system roots, for
+	// example to trust a TLS-intercepting proxy, see LoadCertPool
+	RootCAs *x509.CertPool
+	// MinTLSVersion is the minimum TLS version, such as tls.VersionTLS13,
+	// TLS 1.2 when zero
+	MinTLSVersion uint16
+}
+
+// transport returns a transport with the defaults of http.DefaultTransport
+// and the proxy and TLS settings of the configuration
+func (t *TransportConfig) transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if t.Proxy != nil {
+		transport.Proxy = http.ProxyURL(t.Proxy)
+	}
+	minVersion := t.MinTLSVersion
+	if minVersion == 0 {
+		minVersion = tls.VersionTLS12
+	}
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    t.RootCAs,
+		MinVersion: minVersion,
+	}
+	return transport
+}
+
+// LoadCertPool returns the system certificate pool with the PEM encoded
+// certificates of the given files added, such as the root CA of a corporate
+// proxy
+func LoadCertPool(paths ...string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificates: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(content) {
+			return nil, fmt.Errorf("no certificates found in %s", path)
+		}
+	}
+	return pool, nil
+}
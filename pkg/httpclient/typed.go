@@ -0,0 +1,92 @@
+package httpclient
+
+import "appstore-connect-api/pkg/jsonapi"
+
+// Get performs a GET request and decodes the response into a value of type
+// T, such as a struct mirroring the response document:
+//
+//	type buildsResponse struct {
+//		Data []struct {
+//			ID         string `json:"id"`
+//			Attributes struct {
+//				Version string `json:"version"`
+//			} `json:"attributes"`
+//		} `json:"data"`
+//	}
+//	builds, err := httpclient.Get[buildsResponse](client, "/builds", nil)
+//
+// Responses are cached and errors are returned as for Client.Get.
+func Get[T any](c *Client, path string, params map[string]string) (T, error) {
+	var result T
+	err := c.get(path, params, &result)
+	return result, err
+}
+
+// Post performs a POST request with JSON body and decodes the response into
+// a value of type T
+func Post[T any](c *Client, path string, body interface{}) (T, error) {
+	var result T
+	err := c.sendJSONInto("POST", path, body, &result)
+	return result, err
+}
+
+// Patch performs a PATCH request with JSON body and decodes the response
+// into a value of type T
+func Patch[T any](c *Client, path string, body interface{}) (T, error) {
+	var result T
+	err := c.sendJSONInto("PATCH", path, body, &result)
+	return result, err
+}
+
+// Put performs a PUT request with JSON body and decodes the response into a
+// value of type T
+func Put[T any](c *Client, path string, body interface{}) (T, error) {
+	var result T
+	err := c.sendJSONInto("PUT", path, body, &result)
+	return result, err
+}
+
+// Delete performs a DELETE request and decodes the response into a value of
+// type T
+func Delete[T any](c *Client, path string, params map[string]string) (T, error) {
+	var result T
+	err := c.deleteInto(path, params, &result)
+	return result, err
+}
+
+// DeleteJSON performs a DELETE request with JSON body and decodes the
+// response into a value of type T
+func DeleteJSON[T any](c *Client, path string, body interface{}) (T, error) {
+	var result T
+	err := c.sendJSONInto("DELETE", path, body, &result)
+	return result, err
+}
+
+// GetResource performs a GET request for a single resource and decodes its
+// resource object into a flat struct of type T, see jsonapi.Unmarshal
+func GetResource[T any](c *Client, path string, params map[string]string) (T, error) {
+	var result T
+	response, err := c.Get(path, params)
+	if err != nil {
+		return result, err
+	}
+	resource, err := jsonapi.Data(response)
+	if err != nil {
+		return result, err
+	}
+	err = jsonapi.Unmarshal(resource, &result)
+	return result, err
+}
+
+// GetResources performs GET requests for every page of a list endpoint and
+// decodes the resource objects into flat structs of type T, see
+// jsonapi.Unmarshal
+func GetResources[T any](c *Client, path string, params map[string]string) ([]T, error) {
+	response, err := c.GetAllPages(path, params)
+	if err != nil {
+		return nil, err
+	}
+	var result []T
+	err = jsonapi.UnmarshalList(response, &result)
+	return result, err
+}
@@ -0,0 +1,251 @@
+// Package jsonapi builds and reads the JSON:API documents exchanged with
+// App Store Connect: resource objects with a type, an ID, attributes, and
+// relationships holding resource linkage.
+//
+// Request documents are built from the typed Document, Resource, and
+// Relationship values. Responses decoded by the HTTP client are generic maps,
+// which the decoding helpers read into typed values.
+package jsonapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Linkage identifies a resource in a relationship
+type Linkage struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Relationship is a relationship of a request resource object. Data holds a
+// Linkage for to-one relationships, a []Linkage for to-many relationships,
+// or nil to clear a to-one relationship.
+type Relationship struct {
+	Data interface{} `json:"data"`
+}
+
+// ToOne returns a to-one relationship to a resource
+func ToOne(resourceType, id string) Relationship {
+	return Relationship{Data: Linkage{Type: resourceType, ID: id}}
+}
+
+// ToMany returns a to-many relationship to resources of one type
+func ToMany(resourceType string, ids []string) Relationship {
+	return Relationship{Data: Linkages(resourceType, ids)}
+}
+
+// Linkages returns the linkage of resources of one type, as sent in the body
+// of relationship endpoints. It is never nil, so it encodes as an empty array.
+func Linkages(resourceType string, ids []string) []Linkage {
+	linkages := make([]Linkage, len(ids))
+	for i, id := range ids {
+		linkages[i] = Linkage{Type: resourceType, ID: id}
+	}
+	return linkages
+}
+
+// Resource is a request resource object. Attributes is any value encoding to
+// a JSON object, typically a struct with omitempty tags or a map.
+type Resource struct {
+	Type          string                  `json:"type"`
+	ID            string                  `json:"id,omitempty"`
+	Attributes    interface{}             `json:"attributes,omitempty"`
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+}
+
+// Document is a request document
+type Document struct {
+	Data interface{} `json:"data"`
+}
+
+// Links holds the links of a response resource object or relationship
+type Links struct {
+	Self    string `json:"self,omitempty"`
+	Related string `json:"related,omitempty"`
+}
+
+// ResourceRelationship is a relationship of a response resource object. Data
+// holds its linkage, one item at most for to-one relationships, which
+// responses only hold for included relationships.
+type ResourceRelationship struct {
+	Links Links     `json:"links"`
+	Data  []Linkage `json:"data,omitempty"`
+}
+
+// NewDocument returns a document holding a resource object, the body of
+// create and update requests
+func NewDocument(resource Resource) Document {
+	return Document{Data: resource}
+}
+
+// NewLinkageDocument returns a document holding resource linkage, the body of
+// requests to relationship endpoints
+func NewLinkageDocument(resourceType string, ids []string) Document {
+	return Document{Data: Linkages(resourceType, ids)}
+}
+
+// Data returns the resource object of a single resource response
+func Data(response map[string]interface{}) (map[string]interface{}, error) {
+	resource, ok := response["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid resource data")
+	}
+	return resource, nil
+}
+
+// DataList returns the resource objects of a list response
+func DataList(response map[string]interface{}) []map[string]interface{} {
+	var resources []map[string]interface{}
+	if data, ok := response["data"].([]interface{}); ok {
+		for _, item := range data {
+			if resource, ok := item.(map[string]interface{}); ok {
+				resources = append(resources, resource)
+			}
+		}
+	}
+	return resources
+}
+
+// ResourceID returns the ID of a resource object
+func ResourceID(resource map[string]interface{}) string {
+	id, _ := resource["id"].(string)
+	return id
+}
+
+// ResourceType returns the type of a resource object
+func ResourceType(resource map[string]interface{}) string {
+	resourceType, _ := resource["type"].(string)
+	return resourceType
+}
+
+// DecodeAttributes decodes the attributes of a resource object into out, a
+// pointer to a struct with json tags matching the attribute names
+func DecodeAttributes(resource map[string]interface{}, out interface{}) error {
+	attributes, err := json.Marshal(resource["attributes"])
+	if err != nil {
+		return fmt.Errorf("failed to marshal attributes: %w", err)
+	}
+	if err := json.Unmarshal(attributes, out); err != nil {
+		return fmt.Errorf("failed to decode attributes: %w", err)
+	}
+	return nil
+}
+
+// Unmarshal decodes a resource object into out, a pointer to a struct. The
+// attributes are decoded by their json tags, and the resource ID is stored in
+// an exported string field named ID, if out has one. Likewise the links are
+// stored in a Links field of type Links, and the relationships in a
+// Relationships field of type map[string]ResourceRelationship.
+func Unmarshal(resource map[string]interface{}, out interface{}) error {
+	if err := DecodeAttributes(resource, out); err != nil {
+		return err
+	}
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("out must be a pointer to a struct")
+	}
+	if field := value.Elem().FieldByName("ID"); field.IsValid() && field.Kind() == reflect.String && field.CanSet() {
+		field.SetString(ResourceID(resource))
+	}
+	if field := value.Elem().FieldByName("Links"); field.IsValid() && field.Type() == reflect.TypeOf(Links{}) && field.CanSet() {
+		field.Set(reflect.ValueOf(ResourceLinks(resource)))
+	}
+	if field := value.Elem().FieldByName("Relationships"); field.IsValid() && field.Type() == reflect.TypeOf(map[string]ResourceRelationship{}) && field.CanSet() {
+		field.Set(reflect.ValueOf(Relationships(resource)))
+	}
+	return nil
+}
+
+// UnmarshalList decodes the resource objects of a list response into out, a
+// pointer to a slice of structs, see Unmarshal
+func UnmarshalList(response map[string]interface{}, out interface{}) error {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("out must be a pointer to a slice")
+	}
+	resources := DataList(response)
+	slice := reflect.MakeSlice(value.Elem().Type(), len(resources), len(resources))
+	for i, resource := range resources {
+		if err := Unmarshal(resource, slice.Index(i).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	value.Elem().Set(slice)
+	return nil
+}
+
+// ToOneLinkage returns the linkage of a to-one relationship of a resource
+// object, and whether the relationship holds a resource
+func ToOneLinkage(resource map[string]interface{}, name string) (Linkage, bool) {
+	data, ok := relationshipData(resource, name).(map[string]interface{})
+	if !ok {
+		return Linkage{}, false
+	}
+	return Linkage{Type: ResourceType(data), ID: ResourceID(data)}, true
+}
+
+// ToManyLinkages returns the linkage of a to-many relationship of a resource
+// object, which responses only hold for included relationships
+func ToManyLinkages(resource map[string]interface{}, name string) []Linkage {
+	data, _ := relationshipData(resource, name).([]interface{})
+	var linkages []Linkage
+	for _, item := range data {
+		if linkage, ok := item.(map[string]interface{}); ok {
+			linkages = append(linkages, Linkage{Type: ResourceType(linkage), ID: ResourceID(linkage)})
+		}
+	}
+	return linkages
+}
+
+// ResourceLinks returns the links of a resource object
+func ResourceLinks(resource map[string]interface{}) Links {
+	return decodeLinks(resource["links"])
+}
+
+// Relationships returns the relationships of a resource object by name
+func Relationships(resource map[string]interface{}) map[string]ResourceRelationship {
+	relationships, _ := resource["relationships"].(map[string]interface{})
+	if len(relationships) == 0 {
+		return nil
+	}
+	decoded := make(map[string]ResourceRelationship, len(relationships))
+	for name, item := range relationships {
+		relationship, _ := item.(map[string]interface{})
+		data := ToManyLinkages(resource, name)
+		if linkage, ok := ToOneLinkage(resource, name); ok {
+			data = []Linkage{linkage}
+		}
+		decoded[name] = ResourceRelationship{Links: decodeLinks(relationship["links"]), Data: data}
+	}
+	return decoded
+}
+
+// decodeLinks reads the links member of a resource object or relationship
+func decodeLinks(value interface{}) Links {
+	links, _ := value.(map[string]interface{})
+	self, _ := links["self"].(string)
+	related, _ := links["related"].(string)
+	return Links{Self: self, Related: related}
+}
+
+// Included indexes the included resources of a response by their linkage
+func Included(response map[string]interface{}) map[Linkage]map[string]interface{} {
+	index := make(map[Linkage]map[string]interface{})
+	if included, ok := response["included"].([]interface{}); ok {
+		for _, item := range included {
+			if resource, ok := item.(map[string]interface{}); ok {
+				index[Linkage{Type: ResourceType(resource), ID: ResourceID(resource)}] = resource
+			}
+		}
+	}
+	return index
+}
+
+// relationshipData returns the data member of a relationship of a resource object
+func relationshipData(resource map[string]interface{}, name string) interface{} {
+	relationships, _ := resource["relationships"].(map[string]interface{})
+	relationship, _ := relationships[name].(map[string]interface{})
+	return relationship["data"]
+}
@@ -0,0 +1,161 @@
+package jsonapi_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"appstore-connect-api/pkg/jsonapi"
+)
+
+func TestNewDocument(t *testing.T) {
+	type attributes struct {
+		Name     string `json:"name,omitempty"`
+		Platform string `json:"platform,omitempty"`
+	}
+
+	tests := []struct {
+		name     string
+		document jsonapi.Document
+		want     string
+	}{
+		{
+			name: "resource",
+			document: jsonapi.NewDocument(jsonapi.Resource{
+				Type:       "profiles",
+				Attributes: attributes{Name: "Development"},
+				Relationships: map[string]jsonapi.Relationship{
+					"bundleId": jsonapi.ToOne("bundleIds", "B1"),
+					"devices":  jsonapi.ToMany("devices", nil),
+				},
+			}),
+			want: `{"data":{"type":"profiles","attributes":{"name":"Development"},"relationships":{"bundleId":{"data":{"type":"bundleIds","id":"B1"}},"devices":{"data":[]}}}}`,
+		},
+		{
+			name: "cleared to-one relationship",
+			document: jsonapi.NewDocument(jsonapi.Resource{
+				Type:          "builds",
+				ID:            "BUILD1",
+				Relationships: map[string]jsonapi.Relationship{"appEncryptionDeclaration": {}},
+			}),
+			want: `{"data":{"type":"builds","id":"BUILD1","relationships":{"appEncryptionDeclaration":{"data":null}}}}`,
+		},
+		{
+			name:     "linkage",
+			document: jsonapi.NewLinkageDocument("betaTesters", []string{"T1", "T2"}),
+			want:     `{"data":[{"type":"betaTesters","id":"T1"},{"type":"betaTesters","id":"T2"}]}`,
+		},
+		{
+			name:     "empty linkage",
+			document: jsonapi.NewLinkageDocument("betaTesters", nil),
+			want:     `{"data":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.document)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// profileResponse is a profile response with an included certificate
+const profileResponse = `{
+	"data": {
+		"type": "profiles",
+		"id": "P1",
+		"attributes": {"name": "Development", "profileState": "ACTIVE"},
+		"links": {"self": "https://api.appstoreconnect.apple.com/v1/profiles/P1"},
+		"relationships": {
+			"bundleId": {
+				"data": {"type": "bundleIds", "id": "B1"},
+				"links": {"related": "https://api.appstoreconnect.apple.com/v1/profiles/P1/bundleId"}
+			},
+			"certificates": {"data": [{"type": "certificates", "id": "C1"}, {"type": "certificates", "id": "C2"}]},
+			"devices": {"links": {"related": "https://api.appstoreconnect.apple.com/v1/profiles/P1/devices"}}
+		}
+	},
+	"included": [{"type": "certificates", "id": "C1", "attributes": {"name": "Apple Development"}}]
+}`
+
+// profile is a typed profile resource
+type profile struct {
+	ID           string
+	Name         string `json:"name"`
+	ProfileState string `json:"profileState"`
+}
+
+func decodeResponse(t *testing.T, content string) map[string]interface{} {
+	t.Helper()
+	var response map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &response); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	return response
+}
+
+func TestUnmarshal(t *testing.T) {
+	response := decodeResponse(t, profileResponse)
+	resource, err := jsonapi.Data(response)
+	if err != nil {
+		t.Fatalf("Data: %v", err)
+	}
+
+	var got profile
+	if err := jsonapi.Unmarshal(resource, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	want := profile{
+		ID:           "P1",
+		Name:         "Development",
+		ProfileState: "ACTIVE",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if linkage, ok := jsonapi.ToOneLinkage(resource, "bundleId"); !ok || linkage.ID != "B1" {
+		t.Errorf("got to-one linkage %v, %v, want B1", linkage, ok)
+	}
+	if _, ok := jsonapi.ToOneLinkage(resource, "devices"); ok {
+		t.Error("got linkage of a relationship without data")
+	}
+	if linkages := jsonapi.ToManyLinkages(resource, "certificates"); len(linkages) != 2 {
+		t.Errorf("got to-many linkage %v, want two certificates", linkages)
+	}
+	included := jsonapi.Included(response)
+	if certificate, ok := included[jsonapi.Linkage{Type: "certificates", ID: "C1"}]; !ok || jsonapi.ResourceID(certificate) != "C1" {
+		t.Errorf("got included resources %v, want certificate C1", included)
+	}
+}
+
+func TestUnmarshalList(t *testing.T) {
+	response := decodeResponse(t, `{"data": [
+		{"type": "profiles", "id": "P1", "attributes": {"name": "Development"}},
+		{"type": "profiles", "id": "P2", "attributes": {"name": "App Store"}}
+	]}`)
+
+	var profiles []profile
+	if err := jsonapi.UnmarshalList(response, &profiles); err != nil {
+		t.Fatalf("UnmarshalList: %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].ID != "P1" || profiles[1].Name != "App Store" {
+		t.Errorf("got %+v", profiles)
+	}
+
+	if _, err := jsonapi.Data(response); err == nil {
+		t.Error("Data of a list response succeeded")
+	}
+	var single profile
+	if err := jsonapi.UnmarshalList(response, &single); err == nil {
+		t.Error("UnmarshalList into a struct succeeded")
+	}
+	if err := jsonapi.Unmarshal(map[string]interface{}{}, profile{}); err == nil {
+		t.Error("Unmarshal into a non-pointer succeeded")
+	}
+}
@@ -0,0 +1,54 @@
+package jsonapi
+
+import "net/url"
+
+// PageInfo is the paging metadata of a list response
+type PageInfo struct {
+	// Total is the number of resources of all pages, 0 when the response
+	// does not report it
+	Total int
+	// Limit is the number of resources per page
+	Limit int
+	// Self is the URL of the page
+	Self string
+	// Next is the URL of the next page, empty on the last page
+	Next string
+}
+
+// HasNext reports whether there is a page after this one
+func (p PageInfo) HasNext() bool {
+	return p.Next != ""
+}
+
+// NextParams returns the query parameters requesting the next page, or nil
+// on the last page
+func (p PageInfo) NextParams() map[string]string {
+	if p.Next == "" {
+		return nil
+	}
+	next, err := url.Parse(p.Next)
+	if err != nil {
+		return nil
+	}
+	params := make(map[string]string)
+	for k, v := range next.Query() {
+		if len(v) > 0 {
+			params[k] = v[0]
+		}
+	}
+	return params
+}
+
+// Paging returns the paging metadata of a list response, read from
+// meta.paging and links
+func Paging(response map[string]interface{}) PageInfo {
+	meta, _ := response["meta"].(map[string]interface{})
+	paging, _ := meta["paging"].(map[string]interface{})
+	total, _ := paging["total"].(float64)
+	limit, _ := paging["limit"].(float64)
+
+	links, _ := response["links"].(map[string]interface{})
+	self, _ := links["self"].(string)
+	next, _ := links["next"].(string)
+	return PageInfo{Total: int(total), Limit: int(limit), Self: self, Next: next}
+}
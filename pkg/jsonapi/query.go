@@ -0,0 +1,90 @@
+package jsonapi
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Query builds the query parameters of list and get requests. Its methods
+// set a parameter and return the query, so calls chain:
+//
+//	jsonapi.NewQuery().Filter("platform", "IOS").Sort("-addedDate").Limit(50)
+//
+// A Query is a map[string]string, so it is accepted as the params of every
+// list endpoint.
+type Query map[string]string
+
+// NewQuery creates an empty query
+func NewQuery() Query {
+	return make(Query)
+}
+
+// Filter sets filter[field] to match any of values
+func (q Query) Filter(field string, values ...string) Query {
+	q["filter["+field+"]"] = strings.Join(values, ",")
+	return q
+}
+
+// Fields adds the attributes and relationships returned for resources of a
+// type to fields[resourceType]
+func (q Query) Fields(resourceType string, fields ...string) Query {
+	return q.appendList("fields["+resourceType+"]", fields)
+}
+
+// Include adds relationships whose resources are returned in included
+func (q Query) Include(relationships ...string) Query {
+	return q.appendList("include", relationships)
+}
+
+// Sort adds fields to sort by, in order. A field prefixed with "-" sorts in
+// descending order.
+func (q Query) Sort(fields ...string) Query {
+	return q.appendList("sort", fields)
+}
+
+// Limit sets the number of resources per page
+func (q Query) Limit(limit int) Query {
+	q["limit"] = strconv.Itoa(limit)
+	return q
+}
+
+// LimitIncluded sets limit[relationship], the number of included resources
+// of a to-many relationship
+func (q Query) LimitIncluded(relationship string, limit int) Query {
+	q["limit["+relationship+"]"] = strconv.Itoa(limit)
+	return q
+}
+
+// Set sets a parameter without a dedicated method
+func (q Query) Set(name, value string) Query {
+	q[name] = value
+	return q
+}
+
+// Values returns the query as URL query values
+func (q Query) Values() url.Values {
+	values := make(url.Values, len(q))
+	for name, value := range q {
+		values.Set(name, value)
+	}
+	return values
+}
+
+// Encode returns the query URL-encoded, sorted by parameter name
+func (q Query) Encode() string {
+	return q.Values().Encode()
+}
+
+// appendList adds items to a comma-separated parameter
+func (q Query) appendList(name string, items []string) Query {
+	if len(items) == 0 {
+		return q
+	}
+	if current := q[name]; current != "" {
+		q[name] = current + "," + strings.Join(items, ",")
+	} else {
+		q[name] = strings.Join(items, ",")
+	}
+	return q
+}
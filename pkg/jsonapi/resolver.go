@@ -0,0 +1,89 @@
+package jsonapi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Resolver links the relationships of resource objects to the included
+// resources of compound documents, the responses of requests with an
+// include parameter such as include=bundleId,certificates
+type Resolver struct {
+	included map[Linkage]map[string]interface{}
+}
+
+// NewResolver indexes the included resources of responses, such as the
+// pages of a list endpoint
+func NewResolver(responses ...map[string]interface{}) *Resolver {
+	included := make(map[Linkage]map[string]interface{})
+	for _, response := range responses {
+		for linkage, resource := range Included(response) {
+			included[linkage] = resource
+		}
+	}
+	return &Resolver{included: included}
+}
+
+// Resource returns the included resource object identified by a linkage
+func (r *Resolver) Resource(linkage Linkage) (map[string]interface{}, bool) {
+	resource, ok := r.included[linkage]
+	return resource, ok
+}
+
+// Related returns the included resource objects of a relationship of a
+// resource object, in the order of its linkage. Related resources that were
+// not included are skipped.
+func (r *Resolver) Related(resource map[string]interface{}, name string) []map[string]interface{} {
+	linkages := ToManyLinkages(resource, name)
+	if linkage, ok := ToOneLinkage(resource, name); ok {
+		linkages = []Linkage{linkage}
+	}
+	return r.resources(linkages)
+}
+
+// UnmarshalOne decodes the included resource of the first of linkages, such
+// as the Data of a to-one ResourceRelationship, into out, a pointer to a
+// struct, see Unmarshal. It reports whether the resource was included.
+func (r *Resolver) UnmarshalOne(linkages []Linkage, out interface{}) (bool, error) {
+	if len(linkages) == 0 {
+		return false, nil
+	}
+	resource, ok := r.included[linkages[0]]
+	if !ok {
+		return false, nil
+	}
+	return true, Unmarshal(resource, out)
+}
+
+// UnmarshalMany decodes the included resources of linkages, such as the
+// Data of a to-many ResourceRelationship, into out, a pointer to a slice of
+// structs, see Unmarshal. Resources that were not included are skipped.
+func (r *Resolver) UnmarshalMany(linkages []Linkage, out interface{}) error {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("out must be a pointer to a slice")
+	}
+	resources := r.resources(linkages)
+	if len(resources) == 0 {
+		return nil
+	}
+	slice := reflect.MakeSlice(value.Elem().Type(), len(resources), len(resources))
+	for i, resource := range resources {
+		if err := Unmarshal(resource, slice.Index(i).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	value.Elem().Set(slice)
+	return nil
+}
+
+// resources returns the included resource objects of linkages
+func (r *Resolver) resources(linkages []Linkage) []map[string]interface{} {
+	var resources []map[string]interface{}
+	for _, linkage := range linkages {
+		if resource, ok := r.included[linkage]; ok {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
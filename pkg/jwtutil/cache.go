@@ -0,0 +1,81 @@
+package jwtutil
+
+import (
+	"sync"
+	"time"
+)
+
+// RefreshMargin is how long before its expiry a cached token is replaced
+const RefreshMargin = 2 * time.Minute
+
+// TokenCache reuses the tokens of a generator until shortly before they
+// expire. It is safe for concurrent use.
+type TokenCache struct {
+	generator *Generator
+	mu        sync.Mutex
+	token     string
+	expires   time.Time
+	// renewed is the last token generated by Renew
+	renewed string
+}
+
+// NewTokenCache creates a token cache for a generator
+func NewTokenCache(generator *Generator) *TokenCache {
+	return &TokenCache{generator: generator}
+}
+
+// Token returns the cached token, generating a new one when none is cached
+// or the cached one expires within RefreshMargin
+func (c *TokenCache) Token() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Until(c.expires) > RefreshMargin {
+		return c.token, nil
+	}
+	expires := time.Now().Add(TokenLifetime)
+	token, err := c.generator.GenerateToken()
+	if err != nil {
+		return "", err
+	}
+	c.token, c.expires = token, expires
+	return token, nil
+}
+
+// Renew replaces a token that was rejected, such as one that expired early
+// because of clock skew, with a newly generated one. When another caller
+// renewed it already, the current token is returned. It returns false when
+// rejected is itself a renewed token, since the key is then rejected rather
+// than the token.
+func (c *TokenCache) Renew(rejected string) (string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.renewed != "" && rejected == c.renewed {
+		return "", false, nil
+	}
+	if rejected != c.token && c.token != "" && time.Until(c.expires) > RefreshMargin {
+		return c.token, true, nil
+	}
+	expires := time.Now().Add(TokenLifetime)
+	token, err := c.generator.GenerateToken()
+	if err != nil {
+		return "", false, err
+	}
+	c.token, c.expires, c.renewed = token, expires, token
+	return token, true, nil
+}
+
+// Expires returns the expiry of the cached token, the zero time without one
+func (c *TokenCache) Expires() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.expires
+}
+
+// Invalidate discards the cached token, so the next one is newly generated
+func (c *TokenCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token, c.expires = "", time.Time{}
+}
@@ -0,0 +1,155 @@
+package jwtutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	jwtAud = "appstoreconnect-v1"
+	jwtAlg = "ES256"
+)
+
+// TokenLifetime is the time until generated tokens expire, App Store Connect
+// rejects tokens valid for more than 20 minutes
+const TokenLifetime = 19 * time.Minute
+
+// KeyType is the kind of an App Store Connect API key
+type KeyType string
+
+const (
+	// KeyTypeTeam is a team key, whose tokens have the issuer ID as "iss" claim
+	KeyTypeTeam KeyType = "team"
+	// KeyTypeIndividual is an individual key of a user, which has no issuer
+	// ID. Its tokens have a "sub" claim of "user" instead of an "iss" claim.
+	KeyTypeIndividual KeyType = "individual"
+)
+
+// individualSubject is the "sub" claim of tokens of individual keys
+const individualSubject = "user"
+
+// JWTConfig holds JWT configuration
+type JWTConfig struct {
+	// Issuer is the issuer ID, required unless KeyType is KeyTypeIndividual
+	Issuer     string
+	KeyID      string
+	PrivateKey string
+	// BundleID is added as the "bid" claim, which the App Store Server API requires
+	BundleID string
+	// Audience overrides the "aud" claim, which defaults to appstoreconnect-v1
+	Audience string
+	// KeyType is the kind of key, KeyTypeTeam when empty
+	KeyType KeyType
+	// Scope restricts tokens to the listed requests, such as "GET /v1/apps"
+	// or "GET /v1/apps?filter[platform]=IOS", as the "scope" claim. Tokens
+	// without a scope are valid for every request the key is allowed.
+	Scope []string
+}
+
+// Generator generates JWT tokens for App Store Connect API
+type Generator struct {
+	config JWTConfig
+}
+
+// NewGenerator creates a new JWT generator
+func NewGenerator(config JWTConfig) (*Generator, error) {
+	switch config.KeyType {
+	case "", KeyTypeTeam:
+		if config.Issuer == "" {
+			return nil, fmt.Errorf("issuer is required")
+		}
+	case KeyTypeIndividual:
+	default:
+		return nil, fmt.Errorf("unknown key type %q", config.KeyType)
+	}
+	if config.KeyID == "" {
+		return nil, fmt.Errorf("key id is required")
+	}
+	if config.PrivateKey == "" {
+		return nil, fmt.Errorf("private key is required")
+	}
+
+	return &Generator{config: config}, nil
+}
+
+// GenerateToken generates a JWT token
+func (g *Generator) GenerateToken() (string, error) {
+	// Parse the private key
+	privateKey, err := g.parsePrivateKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	// Create token claims
+	now := time.Now()
+	audience := g.config.Audience
+	if audience == "" {
+		audience = jwtAud
+	}
+	claims := jwt.MapClaims{
+		"iat": now.Add(-60 * time.Second).Unix(), // issued 60 seconds ago
+		"exp": now.Add(TokenLifetime).Unix(),     // expires in 19 minutes
+		"aud": audience,
+	}
+	if g.config.KeyType == KeyTypeIndividual {
+		claims["sub"] = individualSubject
+	} else {
+		claims["iss"] = g.config.Issuer
+	}
+	if len(g.config.Scope) > 0 {
+		claims["scope"] = g.config.Scope
+	}
+	if g.config.BundleID != "" {
+		claims["bid"] = g.config.BundleID
+	}
+
+	// Create token with ES256 algorithm
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = g.config.KeyID
+
+	// Sign the token
+	tokenString, err := token.SignedString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+// parsePrivateKey parses the private key from string or PEM format
+func (g *Generator) parsePrivateKey() (*ecdsa.PrivateKey, error) {
+	return ParsePrivateKey(g.config.PrivateKey)
+}
+
+// ParsePrivateKey parses an ECDSA private key in PEM format, such as the
+// content of a .p8 key file downloaded from App Store Connect
+func ParsePrivateKey(privateKey string) (*ecdsa.PrivateKey, error) {
+	// Decode PEM block
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM block")
+	}
+
+	// Parse PKCS8 or PKCS1
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		// Try PKCS1
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+	}
+
+	// Assert to ECDSA private key
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not ECDSA")
+	}
+
+	return ecdsaKey, nil
+}
@@ -0,0 +1,112 @@
+package notary
+
+import (
+	"fmt"
+	"os"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/httpclient"
+	"appstore-connect-api/pkg/jwtutil"
+)
+
+const (
+	baseURI    = "https://appstoreconnect.apple.com/notary"
+	apiVersion = "v2"
+
+	// notaryAudience is the audience of Notary API tokens, which Apple shares
+	// with the App Store Connect API so the same key works for both
+	notaryAudience = "appstoreconnect-v1"
+)
+
+// Client represents the Notary API client
+type Client struct {
+	httpClient *httpclient.Client
+	tokens     *jwtutil.TokenCache
+}
+
+// NewClient creates a new Notary API client from the same configuration as
+// the App Store Connect client. The API version of the configuration is ignored.
+func NewClient(config appstore.Config) (*Client, error) {
+	// Validate required fields
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("issuer is required")
+	}
+	if config.KeyID == "" {
+		return nil, fmt.Errorf("key id is required")
+	}
+	if config.Secret == "" {
+		return nil, fmt.Errorf("secret is required")
+	}
+
+	// Read secret from file if it's a file path
+	privateKey := config.Secret
+	if _, err := os.Stat(config.Secret); err == nil {
+		content, err := os.ReadFile(config.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret file: %w", err)
+		}
+		privateKey = string(content)
+	}
+
+	// Create JWT generator
+	jwtGenerator, err := jwtutil.NewGenerator(jwtutil.JWTConfig{
+		Issuer:     config.Issuer,
+		KeyID:      config.KeyID,
+		PrivateKey: privateKey,
+		Audience:   notaryAudience,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWT generator: %w", err)
+	}
+
+	// Create HTTP client
+	httpClient := httpclient.NewClient(httpclient.Config{
+		BaseURL:    baseURI,
+		APIVersion: apiVersion,
+		HTTPClient: config.HTTPClient,
+		Transport:  config.Transport,
+		UserAgent:  config.UserAgent,
+		Headers:    config.Headers,
+	})
+
+	client := &Client{
+		httpClient: httpClient,
+		tokens:     jwtutil.NewTokenCache(jwtGenerator),
+	}
+	httpClient.SetUnauthorizedHandler(client.reauthorize)
+	return client, nil
+}
+
+// GetToken returns a JWT token, cached until shortly before it expires
+func (c *Client) GetToken() (string, error) {
+	token, err := c.tokens.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return token, nil
+}
+
+// EnsureAuth ensures the client has an auth header with a JWT token that is
+// not about to expire
+func (c *Client) EnsureAuth() error {
+	token, err := c.GetToken()
+	if err != nil {
+		return err
+	}
+	if c.httpClient.GetHeaders()["Authorization"] != "Bearer "+token {
+		c.httpClient.SetToken(token)
+	}
+	return nil
+}
+
+// reauthorize returns a newly signed token to send a request rejected with
+// 401 again with, in case the rejected token expired early
+func (c *Client) reauthorize(rejected string) (string, bool) {
+	token, ok, err := c.tokens.Renew(rejected)
+	return token, ok && err == nil
+}
+
+// GetHTTPClient returns the underlying HTTP client
+func (c *Client) GetHTTPClient() *httpclient.Client {
+	return c.httpClient
+}
@@ -0,0 +1,17 @@
+package notary
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// Staple attaches the notarization ticket to an app, disk image, or
+// installer package so it validates offline. Stapling is not part of the
+// Notary API, it runs xcrun stapler and therefore requires macOS with Xcode.
+func Staple(path string) error {
+	output, err := exec.Command("xcrun", "stapler", "staple", path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to staple %s: %w: %s", path, err, output)
+	}
+	return nil
+}
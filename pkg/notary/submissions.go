@@ -0,0 +1,192 @@
+package notary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const defaultPollInterval = 30 * time.Second
+
+// SubmissionStatus represents the notarization status of a submission
+type SubmissionStatus string
+
+// Submission statuses
+const (
+	SubmissionStatusAccepted   SubmissionStatus = "Accepted"
+	SubmissionStatusInProgress SubmissionStatus = "In Progress"
+	SubmissionStatusInvalid    SubmissionStatus = "Invalid"
+	SubmissionStatusRejected   SubmissionStatus = "Rejected"
+)
+
+// Submission describes a notarization submission
+type Submission struct {
+	ID          string           `json:"-"`
+	Name        string           `json:"name"`
+	Status      SubmissionStatus `json:"status"`
+	CreatedDate string           `json:"createdDate"`
+}
+
+// Done reports whether notarization of the submission finished
+func (s Submission) Done() bool {
+	return s.Status != "" && s.Status != SubmissionStatusInProgress
+}
+
+// uploadCredentials are the temporary credentials for uploading a submission to S3
+type uploadCredentials struct {
+	AccessKeyID     string `json:"awsAccessKeyId"`
+	SecretAccessKey string `json:"awsSecretAccessKey"`
+	SessionToken    string `json:"awsSessionToken"`
+	Bucket          string `json:"bucket"`
+	Object          string `json:"object"`
+}
+
+// Submit uploads a zip archive, disk image, or flat installer package for
+// notarization and returns the ID of the new submission
+func (c *Client) Submit(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := c.EnsureAuth(); err != nil {
+		return "", err
+	}
+
+	response, err := c.GetHTTPClient().PostJSON("/submissions", map[string]string{
+		"submissionName": filepath.Base(path),
+		"sha256":         sha256Hex(content),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	data, _ := response["data"].(map[string]interface{})
+	submissionId, _ := data["id"].(string)
+	if submissionId == "" {
+		return "", fmt.Errorf("response has no submission id")
+	}
+	var credentials uploadCredentials
+	if err := decode(data["attributes"], &credentials); err != nil {
+		return "", err
+	}
+
+	if err := c.upload(credentials, content); err != nil {
+		return submissionId, fmt.Errorf("failed to upload submission: %w", err)
+	}
+	return submissionId, nil
+}
+
+// Submissions retrieves the latest submissions of the team
+func (c *Client) Submissions() ([]Submission, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	response, err := c.GetHTTPClient().Get("/submissions", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var submissions []Submission
+	items, _ := response["data"].([]interface{})
+	for _, item := range items {
+		submission, err := parseSubmission(item)
+		if err != nil {
+			return submissions, err
+		}
+		submissions = append(submissions, submission)
+	}
+	return submissions, nil
+}
+
+// Status retrieves the status of a submission
+func (c *Client) Status(submissionId string) (Submission, error) {
+	return c.status(context.Background(), submissionId)
+}
+
+// status retrieves the status of a submission with a request sent with ctx
+func (c *Client) status(ctx context.Context, submissionId string) (Submission, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return Submission{}, err
+	}
+	response, err := c.GetHTTPClient().WithContext(ctx).Get("/submissions/"+submissionId, nil)
+	if err != nil {
+		return Submission{}, err
+	}
+	return parseSubmission(response["data"])
+}
+
+// WaitForCompletion polls a submission every interval until notarization
+// finishes. A zero interval polls every 30 seconds.
+func (c *Client) WaitForCompletion(ctx context.Context, submissionId string, interval time.Duration) (Submission, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	for {
+		submission, err := c.status(ctx, submissionId)
+		if err != nil {
+			return submission, err
+		}
+		if submission.Done() {
+			return submission, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return submission, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Logs downloads the developer log of a finished submission, a JSON
+// document listing the issues found during notarization
+func (c *Client) Logs(submissionId string) ([]byte, error) {
+	if err := c.EnsureAuth(); err != nil {
+		return nil, err
+	}
+	response, err := c.GetHTTPClient().Get("/submissions/"+submissionId+"/logs", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var attributes struct {
+		DeveloperLogURL string `json:"developerLogUrl"`
+	}
+	data, _ := response["data"].(map[string]interface{})
+	if err := decode(data["attributes"], &attributes); err != nil {
+		return nil, err
+	}
+	if attributes.DeveloperLogURL == "" {
+		return nil, fmt.Errorf("submission %s has no developer log", submissionId)
+	}
+	return c.GetHTTPClient().DownloadURL(attributes.DeveloperLogURL)
+}
+
+// parseSubmission converts a submission resource object to a Submission
+func parseSubmission(value interface{}) (Submission, error) {
+	resource, ok := value.(map[string]interface{})
+	if !ok {
+		return Submission{}, fmt.Errorf("invalid submission data")
+	}
+	var submission Submission
+	if err := decode(resource["attributes"], &submission); err != nil {
+		return Submission{}, err
+	}
+	submission.ID, _ = resource["id"].(string)
+	return submission, nil
+}
+
+// decode converts a decoded JSON value into out, a pointer to a struct with json tags
+func decode(value interface{}, out interface{}) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal attributes: %w", err)
+	}
+	if err := json.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("failed to decode attributes: %w", err)
+	}
+	return nil
+}
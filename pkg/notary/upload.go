@@ -0,0 +1,94 @@
+package notary
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// uploadRegion is the AWS region of the bucket receiving notarization submissions
+const uploadRegion = "us-west-2"
+
+// upload puts the submission content into the S3 bucket granted by the Notary
+// API, signing the request with AWS Signature Version 4
+func (c *Client) upload(credentials uploadCredentials, content []byte) error {
+	if credentials.Bucket == "" || credentials.Object == "" {
+		return fmt.Errorf("response has no upload location")
+	}
+
+	host := credentials.Bucket + ".s3." + uploadRegion + ".amazonaws.com"
+	canonicalURI := "/" + awsURIEncode(credentials.Object)
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(content)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date;x-amz-security-token"
+	canonicalRequest := strings.Join([]string{
+		"PUT",
+		canonicalURI,
+		"",
+		"host:" + host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"x-amz-security-token:" + credentials.SessionToken,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + uploadRegion + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, uploadRegion)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	headers := map[string]string{
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+		"x-amz-security-token": credentials.SessionToken,
+		"Authorization": fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+			credentials.AccessKeyID, scope, signedHeaders, signature),
+	}
+	return c.GetHTTPClient().UploadURL("PUT", "https://"+host+canonicalURI, headers, content)
+}
+
+// awsURIEncode percent-encodes an object key as required by Signature
+// Version 4, keeping unreserved characters and path separators
+func awsURIEncode(key string) string {
+	var encoded strings.Builder
+	for _, b := range []byte(key) {
+		switch {
+		case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/':
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// sha256Hex returns the hex encoded SHA-256 digest of content
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
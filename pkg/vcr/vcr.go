@@ -0,0 +1,332 @@
+// Package vcr records HTTP interactions with App Store Connect to cassette
+// files and replays them, for deterministic tests without credentials.
+//
+// A Recorder is an http.RoundTripper. Install it on a client with
+// client.GetHTTPClient().SetTransport(recorder), or pass recorder.Client()
+// as the HTTPClient of the client configuration.
+package vcr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder records or replays
+type Mode int
+
+// Recorder modes
+const (
+	// ModeRecord sends requests and records them, Save writes the cassette
+	ModeRecord Mode = iota
+	// ModeReplay answers requests from the cassette without network access
+	ModeReplay
+	// ModeRecordOnce replays the cassette when it exists and records it
+	// otherwise, so the first run against App Store Connect creates it
+	ModeRecordOnce
+)
+
+// redacted replaces redacted values in cassettes
+const redacted = "REDACTED"
+
+// tokenPattern matches JSON Web Tokens, which are redacted wherever they appear
+var tokenPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+
+// sensitiveHeaders are never written to a cassette
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// Cassette is a recorded sequence of interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request, without sensitive headers
+type Request struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    Body              `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    Body              `json:"body,omitempty"`
+}
+
+// Body is a recorded body, written as text when it is valid UTF-8 and as
+// base64 otherwise
+type Body []byte
+
+// MarshalJSON writes the body as a string, or as {"base64": "..."} for binary content
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+// UnmarshalJSON reads a body written by MarshalJSON
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = Body(text)
+		return nil
+	}
+	var encoded map[string]string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return fmt.Errorf("invalid body: %w", err)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded["base64"])
+	if err != nil {
+		return fmt.Errorf("invalid base64 body: %w", err)
+	}
+	*b = decoded
+	return nil
+}
+
+// Recorder is an http.RoundTripper recording or replaying a cassette
+type Recorder struct {
+	// Transport sends requests in record mode, http.DefaultTransport when nil
+	Transport http.RoundTripper
+	// RedactAttributes names JSON object members whose values are replaced
+	// in recorded bodies, such as certificateContent or profileContent.
+	// Tokens are always redacted.
+	RedactAttributes []string
+
+	path     string
+	mode     Mode
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// New creates a recorder for the cassette at path. In replay mode the
+// cassette is loaded and must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	if path == "" {
+		return nil, fmt.Errorf("cassette path is required")
+	}
+
+	if mode == ModeRecordOnce {
+		mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			mode = ModeReplay
+		}
+	}
+
+	r := &Recorder{path: path, mode: mode}
+	if mode == ModeReplay {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(content, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette: %w", err)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns whether the recorder records or replays, ModeRecordOnce
+// resolves to one of them
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client sending its requests through the recorder
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip records or replays a request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+// Save writes the recorded cassette to its path
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	content, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// record sends a request and records it with its response
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     redactToken(req.URL.String()),
+			Headers: sanitizeHeaders(req.Header),
+			Body:    r.redact(body),
+		},
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: sanitizeHeaders(resp.Header),
+			Body:    r.redact(responseBody),
+		},
+	})
+	return resp, nil
+}
+
+// replay answers a request with the first unused interaction with the same
+// method, URL, and body
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	url := redactToken(req.URL.String())
+	body = r.redact(body)
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.URL != url || !bytes.Equal(interaction.Request.Body, body) {
+			continue
+		}
+		r.replayed[i] = true
+
+		header := make(http.Header, len(interaction.Response.Headers))
+		for k, v := range interaction.Response.Headers {
+			header.Set(k, v)
+		}
+		// redaction may have changed the length of the recorded body
+		header.Set("Content-Length", strconv.Itoa(len(interaction.Response.Body)))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("vcr: no recorded interaction for %s %s", req.Method, url)
+}
+
+// sanitizeHeaders flattens headers, dropping sensitive ones
+func sanitizeHeaders(header http.Header) map[string]string {
+	var headers map[string]string
+	for k, values := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(k)] {
+			continue
+		}
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[k] = redactToken(strings.Join(values, ", "))
+	}
+	return headers
+}
+
+// redact replaces tokens and the values of RedactAttributes in a body
+func (r *Recorder) redact(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	if len(r.RedactAttributes) > 0 {
+		var value interface{}
+		if json.Unmarshal(body, &value) == nil {
+			names := make(map[string]bool, len(r.RedactAttributes))
+			for _, name := range r.RedactAttributes {
+				names[name] = true
+			}
+			if content, err := json.Marshal(redactMembers(value, names)); err == nil {
+				body = content
+			}
+		}
+	}
+	return tokenPattern.ReplaceAll(body, []byte(redacted))
+}
+
+// redactMembers replaces the values of object members with the given names,
+// at any depth of a decoded JSON value
+func redactMembers(value interface{}, names map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if names[key] {
+				v[key] = redacted
+			} else {
+				v[key] = redactMembers(item, names)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactMembers(item, names)
+		}
+	}
+	return value
+}
+
+// redactToken replaces the tokens in a string
+func redactToken(s string) string {
+	return tokenPattern.ReplaceAllString(s, redacted)
+}
@@ -0,0 +1,156 @@
+package vcr_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"appstore-connect-api/pkg/appstore"
+	"appstore-connect-api/pkg/appstoretest"
+	"appstore-connect-api/pkg/vcr"
+)
+
+// newClient creates a client of a server whose requests go through recorder,
+// which sends recorded requests to the server
+func newClient(t *testing.T, server *appstoretest.Server, recorder *vcr.Recorder) *appstore.Client {
+	t.Helper()
+	config := server.Config()
+	recorder.Transport = config.HTTPClient.Transport
+	config.HTTPClient = recorder.Client()
+	client, err := appstore.NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+// registerDevice lists the devices and registers one, returning both responses
+func registerDevice(t *testing.T, client *appstore.Client) []map[string]interface{} {
+	t.Helper()
+	devicesAPI := appstore.NewDeviceAPI(client)
+	devices, err := devicesAPI.All(nil)
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	device, err := devicesAPI.Register("New iPhone", "IOS", "00008110-000D4E5F60718293")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	return []map[string]interface{}{devices, device}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "register.json")
+
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	recorder, err := vcr.New(path, vcr.ModeRecord)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	recorded := registerDevice(t, newClient(t, server, recorder))
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	server.Close()
+
+	// Replay answers from the cassette, with a key of another server
+	replayServer := appstoretest.NewServer()
+	defer replayServer.Close()
+	replayer, err := vcr.New(path, vcr.ModeReplay)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	client := newClient(t, replayServer, replayer)
+	if replayed := registerDevice(t, client); !reflect.DeepEqual(replayed, recorded) {
+		t.Errorf("got replayed responses %v, want %v", replayed, recorded)
+	}
+	if requests := replayServer.Requests(); len(requests) != 0 {
+		t.Errorf("replay sent %d requests", len(requests))
+	}
+
+	// Each interaction is replayed once
+	_, err = appstore.NewDeviceAPI(client).All(nil)
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("got %v for a request beyond the cassette, want no recorded interaction", err)
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if _, err := vcr.New(filepath.Join(t.TempDir(), "missing.json"), vcr.ModeReplay); err == nil {
+		t.Error("replaying a missing cassette succeeded")
+	}
+	if _, err := vcr.New("", vcr.ModeRecord); err == nil {
+		t.Error("recording without a path succeeded")
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "register.json")
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+	// The token echoed in a body is redacted as well
+	server.Handle(http.MethodGet, "/apps", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []interface{}{},
+			"meta": map[string]string{"authorization": r.Header.Get("Authorization")},
+		})
+	})
+
+	recorder, err := vcr.New(path, vcr.ModeRecord)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	recorder.RedactAttributes = []string{"udid"}
+	client := newClient(t, server, recorder)
+	registerDevice(t, client)
+	if _, err := client.Do(http.MethodGet, "/apps", nil, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	for _, secret := range []string{"Authorization", "eyJ", "00008110-000D4E5F60718293"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+	if !strings.Contains(string(content), "REDACTED") {
+		t.Error("cassette has no redacted values")
+	}
+}
+
+func TestRecordOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "register.json")
+	server := appstoretest.NewServer(appstoretest.Fixtures()...)
+	defer server.Close()
+
+	recorder, err := vcr.New(path, vcr.ModeRecordOnce)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if recorder.Mode() != vcr.ModeRecord {
+		t.Fatalf("got mode %v without a cassette, want ModeRecord", recorder.Mode())
+	}
+	registerDevice(t, newClient(t, server, recorder))
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	replayer, err := vcr.New(path, vcr.ModeRecordOnce)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if replayer.Mode() != vcr.ModeReplay {
+		t.Errorf("got mode %v with a cassette, want ModeReplay", replayer.Mode())
+	}
+}